@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+type apiKey string
+
+// APIKey authenticates the calls of a service to the user service with the api key of its service client:
+//
+//	grpc.Dial(userServiceHost, grpc.WithPerRPCCredentials(auth.APIKey(os.Getenv("USER_SERVICE_API_KEY"))), ...)
+func APIKey(key string) credentials.PerRPCCredentials {
+	return apiKey(key)
+}
+
+func (k apiKey) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(k)}, nil
+}
+
+// RequireTransportSecurity is false, the services talk over the private network without tls
+func (k apiKey) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,158 @@
+// Package auth authenticates requests to the other Mitra services with the access tokens issued
+// by the user service. Tokens are checked with its IntrospectToken rpc, so services need neither
+// the signing secret nor to track revoked sessions and deactivated users themselves. The connection
+// authenticates with the api key of a service client granted the tokens:introspect scope.
+//
+//	conn, _ := grpc.Dial(userServiceHost, grpc.WithTransportCredentials(insecure.NewCredentials()),
+//		grpc.WithPerRPCCredentials(auth.APIKey(os.Getenv("USER_SERVICE_API_KEY"))))
+//	users := auth.New(conn)
+//	server := grpc.NewServer(grpc.UnaryInterceptor(users.UnaryServerInterceptor("/proto.Public/Ping")))
+//
+// Handlers then read the caller with auth.FromContext.
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrInactiveToken is returned for tokens that are invalid, expired or revoked
+var ErrInactiveToken = errors.New("inactive token")
+
+// Principal is the user an access token was issued to
+type Principal struct {
+	UserId uuid.UUID
+	Roles  []string
+	// SessionId is uuid.Nil for tokens issued before sessions existed
+	SessionId uuid.UUID
+	ExpiresAt time.Time
+	// ActorId is the admin impersonating the user, uuid.Nil for the tokens of the user
+	ActorId uuid.UUID
+}
+
+func (p *Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+type Client struct {
+	users pb.UserServiceClient
+}
+
+// New returns a client introspecting tokens through conn, a connection to the user service
+// carrying an api key, see APIKey
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{
+		users: pb.NewUserServiceClient(conn),
+	}
+}
+
+// Introspect returns the principal of token, ErrInactiveToken when it is not honoured anymore
+func (c *Client) Introspect(ctx context.Context, token string) (*Principal, error) {
+	res, err := c.users.IntrospectToken(ctx, &pb.IntrospectTokenRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	if !res.Active {
+		return nil, ErrInactiveToken
+	}
+	userId, err := uuid.Parse(res.Sub)
+	if err != nil {
+		return nil, err
+	}
+	principal := &Principal{
+		UserId:    userId,
+		Roles:     res.Roles,
+		ExpiresAt: res.Exp.AsTime(),
+	}
+	if res.SessionId != "" {
+		if principal.SessionId, err = uuid.Parse(res.SessionId); err != nil {
+			return nil, err
+		}
+	}
+	if res.ActSub != "" {
+		if principal.ActorId, err = uuid.Parse(res.ActSub); err != nil {
+			return nil, err
+		}
+	}
+	return principal, nil
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal of an authenticated request
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// UnaryServerInterceptor authenticates every call with its bearer token, except calls to the
+// public methods given by their full name, e.g. /proto.UserService/Login
+func (c *Client) UnaryServerInterceptor(publicMethods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if slices.Contains(publicMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := c.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func (c *Client) StreamServerInterceptor(publicMethods ...string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if slices.Contains(publicMethods, info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := c.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (c *Client) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization token is not provided")
+	}
+	token, ok := strings.CutPrefix(authorization[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization format")
+	}
+	principal, err := c.Introspect(ctx, token)
+	if errors.Is(err, ErrInactiveToken) {
+		return nil, status.Error(codes.Unauthenticated, "token is expired or revoked")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cannot introspect token: %v", err)
+	}
+	return NewContext(ctx, principal), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeUserServiceClient struct {
+	pb.UserServiceClient
+	res   *pb.IntrospectTokenResponse
+	err   error
+	token string
+}
+
+func (f *fakeUserServiceClient) IntrospectToken(ctx context.Context, in *pb.IntrospectTokenRequest, opts ...grpc.CallOption) (*pb.IntrospectTokenResponse, error) {
+	f.token = in.Token
+	return f.res, f.err
+}
+
+func TestClient_UnaryServerInterceptor(t *testing.T) {
+	userId := uuid.New()
+	sessionId := uuid.New()
+	active := &pb.IntrospectTokenResponse{
+		Active:    true,
+		Sub:       userId.String(),
+		Roles:     []string{"admin"},
+		SessionId: sessionId.String(),
+		Exp:       timestamppb.New(time.Now().Add(time.Hour)),
+	}
+	withToken := func(authorization string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+	}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		res      *pb.IntrospectTokenResponse
+		err      error
+		wantCode codes.Code
+	}{
+		{
+			name:     "public method",
+			ctx:      context.Background(),
+			method:   "/proto.Test/Public",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "no token",
+			ctx:      context.Background(),
+			method:   "/proto.Test/Private",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "not a bearer token",
+			ctx:      withToken("Basic abc"),
+			method:   "/proto.Test/Private",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "inactive token",
+			ctx:      withToken("Bearer abc"),
+			method:   "/proto.Test/Private",
+			res:      &pb.IntrospectTokenResponse{},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "user service unavailable",
+			ctx:      withToken("Bearer abc"),
+			method:   "/proto.Test/Private",
+			err:      errors.New("connection refused"),
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:     "active token",
+			ctx:      withToken("Bearer abc"),
+			method:   "/proto.Test/Private",
+			res:      active,
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeUserServiceClient{res: tt.res, err: tt.err}
+			c := &Client{users: users}
+			var principal *Principal
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				principal, _ = FromContext(ctx)
+				return nil, nil
+			}
+			_, err := c.UnaryServerInterceptor("/proto.Test/Public")(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Client.UnaryServerInterceptor() error = %v, want code %v", err, tt.wantCode)
+				return
+			}
+			if tt.res != active {
+				return
+			}
+			if users.token != "abc" {
+				t.Errorf("Client.UnaryServerInterceptor() introspected %q, want abc", users.token)
+			}
+			if principal == nil || principal.UserId != userId || principal.SessionId != sessionId || !principal.HasRole("admin") {
+				t.Errorf("Client.UnaryServerInterceptor() principal = %+v, want %v with admin role", principal, userId)
+			}
+		})
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	md, err := APIKey("mk_0123456789abcdef_secret").GetRequestMetadata(context.Background())
+	if err != nil || md["authorization"] != "Bearer mk_0123456789abcdef_secret" {
+		t.Errorf("APIKey().GetRequestMetadata() = %v, %v, want bearer api key", md, err)
+	}
+}
+
+func TestClient_IntrospectImpersonation(t *testing.T) {
+	userId := uuid.New()
+	adminId := uuid.New()
+	users := &fakeUserServiceClient{res: &pb.IntrospectTokenResponse{
+		Active: true,
+		Sub:    userId.String(),
+		ActSub: adminId.String(),
+		Exp:    timestamppb.New(time.Now().Add(time.Minute)),
+	}}
+	principal, err := (&Client{users: users}).Introspect(context.Background(), "abc")
+	if err != nil || principal.UserId != userId || principal.ActorId != adminId {
+		t.Errorf("Client.Introspect() = %+v, %v, want %v impersonated by %v", principal, err, userId, adminId)
+	}
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const slowQueryThreshold = 200 * time.Millisecond
+
+type gormLogger struct {
+	level gormlogger.LogLevel
+}
+
+// NewGormLogger routes gorm logs into the request scoped logger.
+// The level is read from DB_LOG_LEVEL (silent, error, warn or info), defaulting to warn.
+// Queries are logged with placeholders so bound values never reach the logs.
+func NewGormLogger() gormlogger.Interface {
+	return &gormLogger{
+		level: parseGormLevel(os.Getenv("DB_LOG_LEVEL")),
+	}
+}
+
+func parseGormLevel(level string) gormlogger.LogLevel {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "info":
+		return gormlogger.Info
+	default:
+		return gormlogger.Warn
+	}
+}
+
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &gormLogger{
+		level: level,
+	}
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		FromContext(ctx).Infof(msg, data...)
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		FromContext(ctx).Warnf(msg, data...)
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		FromContext(ctx).Errorf(msg, data...)
+	}
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	entry := FromContext(ctx).WithFields(logrus.Fields{
+		"sql":         sql,
+		"rows":        rows,
+		"duration_ms": float64(elapsed.Microseconds()) / 1000,
+	})
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		entry.WithError(err).Error("query failed")
+	case elapsed > slowQueryThreshold && l.level >= gormlogger.Warn:
+		entry.Warn("slow query")
+	case l.level >= gormlogger.Info:
+		entry.Info("query")
+	}
+}
+
+// ParamsFilter drops the bound values so logged statements keep their placeholders
+func (l *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
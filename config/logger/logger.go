@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader is read from incoming metadata and echoed back in the response header
+const RequestIDHeader = "x-request-id"
+
+type ctxKey struct{}
+
+// Init configures the standard logrus logger used across the service:
+// json output, level from LOG_LEVEL and redaction of sensitive data.
+func Init() {
+	logrus.SetFormatter(&logrus.JSONFormatter{})
+	logrus.SetOutput(os.Stdout)
+	level, err := logrus.ParseLevel(strings.TrimSpace(os.Getenv("LOG_LEVEL")))
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+	logrus.SetLevel(level)
+	logrus.AddHook(NewRedactHook())
+}
+
+// FromContext returns the request scoped logger stored in ctx,
+// or the standard logger when the context carries none
+func FromContext(ctx context.Context) *logrus.Entry {
+	if ctx != nil {
+		if entry, ok := ctx.Value(ctxKey{}).(*logrus.Entry); ok {
+			return entry
+		}
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
+
+// ToContext stores entry as the request scoped logger
+func ToContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, ctxKey{}, entry)
+}
+
+// WithUserID adds the authenticated user id to the request scoped logger
+// and to the grpc tags logged when the request completes
+func WithUserID(ctx context.Context, userId uuid.UUID) context.Context {
+	grpc_ctxtags.Extract(ctx).Set("user_id", userId.String())
+	return ToContext(ctx, FromContext(ctx).WithField("user_id", userId.String()))
+}
+
+// WithServiceClientID is the counterpart of WithUserID for requests authenticated with an api key
+func WithServiceClientID(ctx context.Context, clientId uuid.UUID) context.Context {
+	grpc_ctxtags.Extract(ctx).Set("service_client_id", clientId.String())
+	return ToContext(ctx, FromContext(ctx).WithField("service_client_id", clientId.String()))
+}
+
+// UnaryServerInterceptor attaches a request scoped logger carrying the request id and method
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(newRequestContext(ctx, info.FullMethod), req)
+	}
+}
+
+// StreamServerInterceptor attaches a request scoped logger carrying the request id and method
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = newRequestContext(ss.Context(), info.FullMethod)
+		return handler(srv, wrapped)
+	}
+}
+
+func newRequestContext(ctx context.Context, method string) context.Context {
+	requestId := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			requestId = values[0]
+		}
+	}
+	if requestId == "" {
+		requestId = uuid.NewString()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestId))
+	grpc_ctxtags.Extract(ctx).Set("request_id", requestId)
+
+	return ToContext(ctx, FromContext(ctx).WithFields(logrus.Fields{
+		"request_id": requestId,
+		"method":     method,
+	}))
+}
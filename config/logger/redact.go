@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// field names whose values are never logged
+	sensitiveKeys = []string{"password", "otp", "token", "secret", "authorization", "api_key", "dsn"}
+
+	emailPattern  = regexp.MustCompile(`([A-Za-z0-9._%+\-])[A-Za-z0-9._%+\-]*@([A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	bcryptPattern = regexp.MustCompile(`\$2[aby]?\$\d{2}\$[./A-Za-z0-9]{53}`)
+	// PHC strings of argon2, e.g. $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+	argon2Pattern = regexp.MustCompile(`\$argon2(?:id|i|d)\$(?:v=\d+\$)?m=\d+,t=\d+,p=\d+\$[A-Za-z0-9+/]+(?:\$[A-Za-z0-9+/]+)?`)
+	dsnPassword   = regexp.MustCompile(`(?i)(password=)\S+`)
+)
+
+type redactHook struct{}
+
+// NewRedactHook returns a logrus hook scrubbing passwords, otp codes, tokens
+// and email addresses from the message and fields of every entry
+func NewRedactHook() logrus.Hook {
+	return &redactHook{}
+}
+
+func (h *redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *redactHook) Fire(entry *logrus.Entry) error {
+	entry.Message = Redact(entry.Message)
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[k] = redactField(k, v)
+	}
+	entry.Data = data
+	return nil
+}
+
+// Redact masks email addresses and removes tokens, password hashes and dsn passwords from s
+func Redact(s string) string {
+	s = jwtPattern.ReplaceAllString(s, redacted)
+	s = bcryptPattern.ReplaceAllString(s, redacted)
+	s = argon2Pattern.ReplaceAllString(s, redacted)
+	s = dsnPassword.ReplaceAllString(s, "${1}"+redacted)
+	return emailPattern.ReplaceAllString(s, "${1}***@${2}")
+}
+
+func redactField(key string, value interface{}) interface{} {
+	lowerKey := strings.ToLower(key)
+	for _, k := range sensitiveKeys {
+		if strings.Contains(lowerKey, k) {
+			return redacted
+		}
+	}
+	switch v := value.(type) {
+	case string:
+		return Redact(v)
+	case error:
+		return Redact(v.Error())
+	}
+	return value
+}
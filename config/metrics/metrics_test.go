@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		err      error
+		wantCode string
+	}{
+		{
+			name:     "success",
+			method:   "/proto.UserService/Login",
+			err:      nil,
+			wantCode: codes.OK.String(),
+		},
+		{
+			name:     "grpc status error",
+			method:   "/proto.UserService/Login",
+			err:      status.Error(codes.NotFound, "not found"),
+			wantCode: codes.NotFound.String(),
+		},
+		{
+			name:     "plain error",
+			method:   "/proto.UserService/Register",
+			err:      errors.New("any error"),
+			wantCode: codes.Unknown.String(),
+		},
+	}
+	interceptor := UnaryServerInterceptor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := sampleCount(tt.method, tt.wantCode)
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					return nil, tt.err
+				})
+			if err != tt.err {
+				t.Errorf("UnaryServerInterceptor() error = %v, want %v", err, tt.err)
+			}
+			if got := sampleCount(tt.method, tt.wantCode); got != before+1 {
+				t.Errorf("UnaryServerInterceptor() recorded %d samples for %s %s, want %d", got, tt.method, tt.wantCode, before+1)
+			}
+		})
+	}
+}
+
+func sampleCount(method, code string) uint64 {
+	m := &dto.Metric{}
+	if err := RpcDuration.WithLabelValues(method, code).(prometheus.Histogram).Write(m); err != nil {
+		return 0
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestNewRedisPoolCollector(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats *redis.PoolStats
+		want  int
+	}{
+		{
+			name:  "no stats",
+			stats: nil,
+			want:  0,
+		},
+		{
+			name: "pool stats",
+			stats: &redis.PoolStats{
+				Hits:       3,
+				TotalConns: 2,
+				IdleConns:  1,
+			},
+			want: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewRedisPoolCollector(func() *redis.PoolStats { return tt.stats })
+			if got := testutil.CollectAndCount(c); got != tt.want {
+				t.Errorf("NewRedisPoolCollector() collected %d metrics, want %d", got, tt.want)
+			}
+		})
+	}
+}
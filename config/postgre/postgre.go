@@ -6,12 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/tracing"
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	"github.com/sirupsen/logrus"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 func Connection() *gorm.DB {
@@ -25,19 +26,23 @@ func Connection() *gorm.DB {
 		portStr = fmt.Sprintf("port=%s ", port)
 	}
 	dsn := fmt.Sprintf("host=%s "+portStr+"user=%s dbname=%s sslmode=disable password=%s", host, username, dbName, password)
-	fmt.Printf("dsn: %s\n", dsn)
+	logrus.WithFields(logrus.Fields{"host": host, "db": dbName}).Info("connecting to database")
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		SkipDefaultTransaction: true,
-		Logger:                 logger.Default.LogMode(logger.Info),
+		Logger:                 logger.NewGormLogger(),
 	})
 	if err != nil {
 		logrus.Panicf("failed to connect database: %v", err)
 	}
 
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		logrus.Panicf("failed to register tracing plugin: %v", err)
+	}
+
 	db.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";")
 
 	// db.Migrator().DropTable("user_roles", &entity.Role{}, &entity.User{})
-	err = db.AutoMigrate(&entity.User{}, &entity.Role{})
+	err = db.AutoMigrate(&entity.User{}, &entity.Role{}, &entity.OutboxMessage{}, &entity.UserMfa{}, &entity.MfaRecoveryCode{}, &entity.Session{}, &entity.AuditEvent{}, &entity.LoginAttempt{}, &entity.ExternalIdentity{}, &entity.OAuthClient{}, &entity.ServiceClient{})
 	if err != nil {
 		logrus.Panicf("failed to migrate database: %v", err)
 	}
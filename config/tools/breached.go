@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// hashPrefixLength is the length of the sha1 prefixes ranges are looked up by, as in the pwned passwords api
+const hashPrefixLength = 5
+
+// HashPrefixList holds the sha1 hashes of breached passwords grouped by their 5 characters prefix.
+// A password is checked against the range of its prefix only, the k-anonymity model of the
+// pwned passwords api, so the list can be swapped for the remote api without changing callers.
+type HashPrefixList struct {
+	ranges map[string][]string
+}
+
+// LoadHashPrefixList reads one uppercase or lowercase hex sha1 hash per line, optionally followed by
+// `:count` like the pwned passwords downloads. Empty lines and lines starting with # are skipped.
+func LoadHashPrefixList(r io.Reader) (*HashPrefixList, error) {
+	list := &HashPrefixList{ranges: map[string][]string{}}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		hash, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if hash == "" || strings.HasPrefix(hash, "#") {
+			continue
+		}
+		hash = strings.ToUpper(hash)
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("line %d: invalid sha1 hash", line)
+		}
+		prefix := hash[:hashPrefixLength]
+		list.ranges[prefix] = append(list.ranges[prefix], hash[hashPrefixLength:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for prefix := range list.ranges {
+		slices.Sort(list.ranges[prefix])
+		list.ranges[prefix] = slices.Compact(list.ranges[prefix])
+	}
+	return list, nil
+}
+
+// Range returns the sorted hash suffixes listed under prefix
+func (l *HashPrefixList) Range(prefix string) []string {
+	return l.ranges[strings.ToUpper(prefix)]
+}
+
+// IsBreached reports whether password is listed
+func (l *HashPrefixList) IsBreached(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	_, found := slices.BinarySearch(l.Range(hash[:hashPrefixLength]), hash[hashPrefixLength:])
+	return found
+}
@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashPrefixList(t *testing.T) {
+	if _, err := LoadHashPrefixList(strings.NewReader("5BAA61E4C9\n")); err == nil {
+		t.Errorf("LoadHashPrefixList() of a truncated hash error = nil, want error")
+	}
+
+	// sha1 of "password" as in the pwned passwords downloads, and of "123456" in lowercase
+	list, err := LoadHashPrefixList(strings.NewReader("# breached\n5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\n\n7c4a8d09ca3762af61e59520943dc26494f8941b\n"))
+	if err != nil {
+		t.Fatalf("LoadHashPrefixList() error = %v", err)
+	}
+	for _, password := range []string{"password", "123456"} {
+		if !list.IsBreached(password) {
+			t.Errorf("HashPrefixList.IsBreached(%q) = false, want true", password)
+		}
+	}
+	if list.IsBreached("Password") {
+		t.Errorf("HashPrefixList.IsBreached(%q) = true, want false", "Password")
+	}
+	if got := list.Range("5baa6"); len(got) != 1 || got[0] != "1E4C9B93F3F0682250B6CF8331B7EE68FD8" {
+		t.Errorf("HashPrefixList.Range() = %v, want the suffix of password", got)
+	}
+}
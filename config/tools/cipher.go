@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+)
+
+var errCiphertextTooShort = errors.New("ciphertext too short")
+
+//go:generate mockgen -source=cipher.go -destination=mock/cipher.go -package=mock
+type CipherInterface interface {
+	Encrypt(plaintext []byte) (string, error)
+	Decrypt(ciphertext string) ([]byte, error)
+}
+
+// AesGcm encrypts secrets stored in the database with AES-256-GCM
+type AesGcm struct {
+	aead cipher.AEAD
+}
+
+// NewAesGcm expects a 32 bytes key
+func NewAesGcm(key []byte) (*AesGcm, error) {
+	if len(key) != 32 {
+		return nil, aes.KeySizeError(len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AesGcm{
+		aead: aead,
+	}, nil
+}
+
+// Encrypt returns the random nonce followed by the sealed plaintext, base64 encoded
+func (c *AesGcm) Encrypt(plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(c.aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+func (c *AesGcm) Decrypt(ciphertext string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < c.aead.NonceSize() {
+		return nil, errCiphertextTooShort
+	}
+	nonce, sealed := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	return c.aead.Open(nil, nonce, sealed, nil)
+}
@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAesGcm(t *testing.T) {
+	if _, err := NewAesGcm([]byte("short")); err == nil {
+		t.Errorf("NewAesGcm() with a 5 bytes key error = nil, want error")
+	}
+
+	c, err := NewAesGcm(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("NewAesGcm() error = %v", err)
+	}
+	ciphertext, err := c.Encrypt([]byte("secret"))
+	if err != nil {
+		t.Fatalf("AesGcm.Encrypt() error = %v", err)
+	}
+	if again, _ := c.Encrypt([]byte("secret")); again == ciphertext {
+		t.Errorf("AesGcm.Encrypt() returned the same ciphertext twice")
+	}
+	got, err := c.Decrypt(ciphertext)
+	if err != nil || string(got) != "secret" {
+		t.Errorf("AesGcm.Decrypt() = %v, %v, want secret", string(got), err)
+	}
+
+	tampered := []byte(ciphertext)
+	tampered[len(tampered)-2] ^= 1
+	if _, err := c.Decrypt(string(tampered)); err == nil {
+		t.Errorf("AesGcm.Decrypt() of a tampered ciphertext error = nil, want error")
+	}
+
+	other, _ := NewAesGcm(bytes.Repeat([]byte{2}, 32))
+	if _, err := other.Decrypt(ciphertext); err == nil {
+		t.Errorf("AesGcm.Decrypt() with another key error = nil, want error")
+	}
+}
@@ -1,5 +1,26 @@
 package tools
 
 const (
-	OtpRedisPrefix = "otp:"
+	OtpRedisPrefix                  = "otp:"
+	PhoneVerificationOtpRedisPrefix = "otp:phone-verification:"
+	PhoneLoginOtpRedisPrefix        = "otp:phone-login:"
+	EmailChangeOtpRedisPrefix       = "otp:email-change:"
+	// holds the new email of a change waiting for its otp
+	EmailChangeRedisPrefix = "email-change:"
+	// maps a pending mfa challenge token to its user id
+	MfaChallengeRedisPrefix = "mfa-challenge:"
+	// counts the second factors entered by a user across mfa challenges
+	MfaAttemptsRedisPrefix = "mfa-attempts:"
+	// caches the summary of a user by id
+	UserSummaryRedisPrefix = "user-summary:"
+	// maps an email to the id of its user for the summary cache
+	UserEmailRedisPrefix = "user-email:"
+	// holds an authorization code of the openid connect provider until it is exchanged
+	OAuthCodeRedisPrefix = "oauth-code:"
+	// appended to an otp key to count wrong codes entered for it
+	OtpAttemptsRedisSuffix = ":attempts"
+
+	PhoneOtpCooldownRedisPrefix       = "otp-cooldown:phone:"
+	EmailChangeOtpCooldownRedisPrefix = "otp-cooldown:email-change:"
+	OtpIpRedisPrefix                  = "otp-sent:ip:"
 )
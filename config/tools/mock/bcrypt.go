@@ -1,68 +0,0 @@
-// Code generated by MockGen. DO NOT EDIT.
-// Source: bcrypt.go
-//
-// Generated by this command:
-//
-//	mockgen -source=bcrypt.go -destination=mock/bcrypt.go -package=mock
-//
-
-// Package mock is a generated GoMock package.
-package mock
-
-import (
-	reflect "reflect"
-
-	gomock "go.uber.org/mock/gomock"
-)
-
-// MockBcryptInterface is a mock of BcryptInterface interface.
-type MockBcryptInterface struct {
-	ctrl     *gomock.Controller
-	recorder *MockBcryptInterfaceMockRecorder
-}
-
-// MockBcryptInterfaceMockRecorder is the mock recorder for MockBcryptInterface.
-type MockBcryptInterfaceMockRecorder struct {
-	mock *MockBcryptInterface
-}
-
-// NewMockBcryptInterface creates a new mock instance.
-func NewMockBcryptInterface(ctrl *gomock.Controller) *MockBcryptInterface {
-	mock := &MockBcryptInterface{ctrl: ctrl}
-	mock.recorder = &MockBcryptInterfaceMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockBcryptInterface) EXPECT() *MockBcryptInterfaceMockRecorder {
-	return m.recorder
-}
-
-// CompareHashAndPassword mocks base method.
-func (m *MockBcryptInterface) CompareHashAndPassword(hashedPassword, password []byte) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CompareHashAndPassword", hashedPassword, password)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CompareHashAndPassword indicates an expected call of CompareHashAndPassword.
-func (mr *MockBcryptInterfaceMockRecorder) CompareHashAndPassword(hashedPassword, password any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareHashAndPassword", reflect.TypeOf((*MockBcryptInterface)(nil).CompareHashAndPassword), hashedPassword, password)
-}
-
-// GenerateFromPassword mocks base method.
-func (m *MockBcryptInterface) GenerateFromPassword(password []byte, cost int) ([]byte, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateFromPassword", password, cost)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GenerateFromPassword indicates an expected call of GenerateFromPassword.
-func (mr *MockBcryptInterfaceMockRecorder) GenerateFromPassword(password, cost any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateFromPassword", reflect.TypeOf((*MockBcryptInterface)(nil).GenerateFromPassword), password, cost)
-}
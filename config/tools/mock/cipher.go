@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cipher.go
+//
+// Generated by this command:
+//
+//	mockgen -source=cipher.go -destination=mock/cipher.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCipherInterface is a mock of CipherInterface interface.
+type MockCipherInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCipherInterfaceMockRecorder
+}
+
+// MockCipherInterfaceMockRecorder is the mock recorder for MockCipherInterface.
+type MockCipherInterfaceMockRecorder struct {
+	mock *MockCipherInterface
+}
+
+// NewMockCipherInterface creates a new mock instance.
+func NewMockCipherInterface(ctrl *gomock.Controller) *MockCipherInterface {
+	mock := &MockCipherInterface{ctrl: ctrl}
+	mock.recorder = &MockCipherInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCipherInterface) EXPECT() *MockCipherInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *MockCipherInterface) Decrypt(ciphertext string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", ciphertext)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockCipherInterfaceMockRecorder) Decrypt(ciphertext any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockCipherInterface)(nil).Decrypt), ciphertext)
+}
+
+// Encrypt mocks base method.
+func (m *MockCipherInterface) Encrypt(plaintext []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encrypt", plaintext)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encrypt indicates an expected call of Encrypt.
+func (mr *MockCipherInterfaceMockRecorder) Encrypt(plaintext any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockCipherInterface)(nil).Encrypt), plaintext)
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// BcryptMaxPasswordBytes is the longest password bcrypt hashes, Hash fails on longer ones
+const BcryptMaxPasswordBytes = 72
+
+var (
+	// ErrMismatchedPassword is returned when a password does not match its hash
+	ErrMismatchedPassword = errors.New("password does not match")
+	errUnknownHashFormat  = errors.New("unknown password hash format")
+)
+
+//go:generate mockgen -source=password.go -destination=mock/password.go -package=mock
+type PasswordHasher interface {
+	// Hash returns the PHC string of password
+	Hash(password []byte) (string, error)
+	// Compare returns ErrMismatchedPassword when password does not match hash
+	Compare(hash string, password []byte) error
+	// NeedsRehash reports whether hash uses another algorithm or weaker parameters than Hash
+	NeedsRehash(hash string) bool
+}
+
+// Argon2idParams are the argon2id costs, Memory is in KiB
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// HasherConfig picks the algorithm new hashes are made with, hashes of the other one are still verified
+type HasherConfig struct {
+	Algorithm  string
+	Argon2id   Argon2idParams
+	BcryptCost int
+}
+
+// DefaultHasherConfig follows the second recommended option of RFC 9106
+func DefaultHasherConfig() HasherConfig {
+	return HasherConfig{
+		Algorithm: AlgorithmArgon2id,
+		Argon2id: Argon2idParams{
+			Memory:      64 * 1024,
+			Iterations:  3,
+			Parallelism: 4,
+			SaltLength:  16,
+			KeyLength:   32,
+		},
+		BcryptCost: bcrypt.DefaultCost,
+	}
+}
+
+// Hasher hashes passwords with argon2id, or bcrypt for the accounts created before argon2id
+type Hasher struct {
+	config HasherConfig
+}
+
+func NewHasher(config HasherConfig) (*Hasher, error) {
+	switch config.Algorithm {
+	case AlgorithmArgon2id:
+		if config.Argon2id.Memory == 0 || config.Argon2id.Iterations == 0 || config.Argon2id.Parallelism == 0 ||
+			config.Argon2id.SaltLength < 8 || config.Argon2id.KeyLength < 16 {
+			return nil, errors.New("invalid argon2id parameters")
+		}
+	case AlgorithmBcrypt:
+		if config.BcryptCost < bcrypt.MinCost || config.BcryptCost > bcrypt.MaxCost {
+			return nil, bcrypt.InvalidCostError(config.BcryptCost)
+		}
+	default:
+		return nil, fmt.Errorf("unknown password hashing algorithm %q", config.Algorithm)
+	}
+	return &Hasher{
+		config: config,
+	}, nil
+}
+
+func (h *Hasher) Hash(password []byte) (string, error) {
+	if h.config.Algorithm == AlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword(password, h.config.BcryptCost)
+		return string(hash), err
+	}
+	params := h.config.Argon2id
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(password, salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return encodeArgon2id(params, salt, key), nil
+}
+
+func (h *Hasher) Compare(hash string, password []byte) error {
+	if isBcrypt(hash) {
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), password); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return ErrMismatchedPassword
+			}
+			return err
+		}
+		return nil
+	}
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return err
+	}
+	other := argon2.IDKey(password, salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrMismatchedPassword
+	}
+	return nil
+}
+
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if isBcrypt(hash) {
+		if h.config.Algorithm != AlgorithmBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost < h.config.BcryptCost
+	}
+	if h.config.Algorithm != AlgorithmArgon2id {
+		return true
+	}
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	want := h.config.Argon2id
+	return params.Memory < want.Memory || params.Iterations < want.Iterations || params.Parallelism != want.Parallelism ||
+		uint32(len(salt)) < want.SaltLength || uint32(len(key)) < want.KeyLength
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// encodeArgon2id formats $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>, both unpadded base64
+func encodeArgon2id(params Argon2idParams, salt []byte, key []byte) string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", AlgorithmArgon2id, argon2.Version,
+		params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodeArgon2id(hash string) (params Argon2idParams, salt []byte, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, errUnknownHashFormat
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errUnknownHashFormat
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, errUnknownHashFormat
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, errUnknownHashFormat
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return params, nil, nil, errUnknownHashFormat
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
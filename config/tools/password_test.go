@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHasher(t *testing.T) {
+	config := HasherConfig{
+		Algorithm:  AlgorithmArgon2id,
+		Argon2id:   Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		BcryptCost: bcrypt.MinCost,
+	}
+	if _, err := NewHasher(HasherConfig{Algorithm: "md5"}); err == nil {
+		t.Errorf("NewHasher() of an unknown algorithm error = nil, want error")
+	}
+	if _, err := NewHasher(HasherConfig{Algorithm: AlgorithmArgon2id}); err == nil {
+		t.Errorf("NewHasher() without argon2id parameters error = nil, want error")
+	}
+	h, err := NewHasher(config)
+	if err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	}
+
+	hash, err := h.Hash([]byte("secret"))
+	if err != nil {
+		t.Fatalf("Hasher.Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("Hasher.Hash() = %v, want an argon2id PHC string", hash)
+	}
+	if again, _ := h.Hash([]byte("secret")); again == hash {
+		t.Errorf("Hasher.Hash() returned the same hash twice")
+	}
+	if err := h.Compare(hash, []byte("secret")); err != nil {
+		t.Errorf("Hasher.Compare() error = %v", err)
+	}
+	if err := h.Compare(hash, []byte("Secret")); !errors.Is(err, ErrMismatchedPassword) {
+		t.Errorf("Hasher.Compare() of another password error = %v, want %v", err, ErrMismatchedPassword)
+	}
+	if err := h.Compare("secret", []byte("secret")); err == nil || errors.Is(err, ErrMismatchedPassword) {
+		t.Errorf("Hasher.Compare() of an unknown format error = %v, want a format error", err)
+	}
+	if h.NeedsRehash(hash) {
+		t.Errorf("Hasher.NeedsRehash() of a current hash = true, want false")
+	}
+
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err := h.Compare(string(legacy), []byte("secret")); err != nil {
+		t.Errorf("Hasher.Compare() of a bcrypt hash error = %v", err)
+	}
+	if err := h.Compare(string(legacy), []byte("other")); !errors.Is(err, ErrMismatchedPassword) {
+		t.Errorf("Hasher.Compare() of a bcrypt hash and another password error = %v, want %v", err, ErrMismatchedPassword)
+	}
+	if !h.NeedsRehash(string(legacy)) {
+		t.Errorf("Hasher.NeedsRehash() of a bcrypt hash = false, want true")
+	}
+
+	stronger := config
+	stronger.Argon2id.Iterations = 2
+	h, _ = NewHasher(stronger)
+	if !h.NeedsRehash(hash) {
+		t.Errorf("Hasher.NeedsRehash() of a hash with fewer iterations = false, want true")
+	}
+	if h.NeedsRehash("$argon2id$v=19$m=64,t=3,p=1$" + strings.Split(hash, "$")[4] + "$" + strings.Split(hash, "$")[5]) {
+		t.Errorf("Hasher.NeedsRehash() of a hash with more iterations = true, want false")
+	}
+
+	bcryptConfig := config
+	bcryptConfig.Algorithm = AlgorithmBcrypt
+	bcryptConfig.BcryptCost = bcrypt.MinCost + 1
+	h, _ = NewHasher(bcryptConfig)
+	if !h.NeedsRehash(string(legacy)) || !h.NeedsRehash(hash) {
+		t.Errorf("Hasher.NeedsRehash() of a cheaper bcrypt hash or an argon2id hash = false, want true")
+	}
+	bcryptHash, err := h.Hash([]byte("secret"))
+	if cost, _ := bcrypt.Cost([]byte(bcryptHash)); err != nil || cost != bcrypt.MinCost+1 {
+		t.Errorf("Hasher.Hash() = %v, %v, want a bcrypt hash of cost %d", bcryptHash, err, bcrypt.MinCost+1)
+	}
+	if _, err := h.Hash([]byte(strings.Repeat("a", BcryptMaxPasswordBytes))); err != nil {
+		t.Errorf("Hasher.Hash() of %d bytes with bcrypt error = %v", BcryptMaxPasswordBytes, err)
+	}
+	if _, err := h.Hash([]byte(strings.Repeat("a", BcryptMaxPasswordBytes+1))); err == nil {
+		t.Errorf("Hasher.Hash() of %d bytes with bcrypt error = nil, want error", BcryptMaxPasswordBytes+1)
+	}
+}
@@ -40,18 +40,38 @@ func (m *MockRedisInterface) EXPECT() *MockRedisInterfaceMockRecorder {
 	return m.recorder
 }
 
-// GetContext mocks base method.
-func (m *MockRedisInterface) GetContext() context.Context {
+// Del mocks base method.
+func (m *MockRedisInterface) Del(ctx context.Context, keys ...string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContext")
-	ret0, _ := ret[0].(context.Context)
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Del", varargs...)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GetContext indicates an expected call of GetContext.
-func (mr *MockRedisInterfaceMockRecorder) GetContext() *gomock.Call {
+// Del indicates an expected call of Del.
+func (mr *MockRedisInterfaceMockRecorder) Del(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockRedisInterface)(nil).Del), varargs...)
+}
+
+// GetDel mocks base method.
+func (m *MockRedisInterface) GetDel(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDel", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDel indicates an expected call of GetDel.
+func (mr *MockRedisInterfaceMockRecorder) GetDel(ctx, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContext", reflect.TypeOf((*MockRedisInterface)(nil).GetContext))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDel", reflect.TypeOf((*MockRedisInterface)(nil).GetDel), ctx, key)
 }
 
 // GetStringKey mocks base method.
@@ -69,6 +89,41 @@ func (mr *MockRedisInterfaceMockRecorder) GetStringKey(ctx, key any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStringKey", reflect.TypeOf((*MockRedisInterface)(nil).GetStringKey), ctx, key)
 }
 
+// Incr mocks base method.
+func (m *MockRedisInterface) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, key, expiration)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockRedisInterfaceMockRecorder) Incr(ctx, key, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockRedisInterface)(nil).Incr), ctx, key, expiration)
+}
+
+// MGet mocks base method.
+func (m *MockRedisInterface) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MGet", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MGet indicates an expected call of MGet.
+func (mr *MockRedisInterfaceMockRecorder) MGet(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGet", reflect.TypeOf((*MockRedisInterface)(nil).MGet), varargs...)
+}
+
 // Set mocks base method.
 func (m *MockRedisInterface) Set(ctx context.Context, key string, value any, expiration time.Duration) error {
 	m.ctrl.T.Helper()
@@ -82,3 +137,18 @@ func (mr *MockRedisInterfaceMockRecorder) Set(ctx, key, value, expiration any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRedisInterface)(nil).Set), ctx, key, value, expiration)
 }
+
+// XAdd mocks base method.
+func (m *MockRedisInterface) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]any) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XAdd", ctx, stream, maxLen, values)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XAdd indicates an expected call of XAdd.
+func (mr *MockRedisInterfaceMockRecorder) XAdd(ctx, stream, maxLen, values any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XAdd", reflect.TypeOf((*MockRedisInterface)(nil).XAdd), ctx, stream, maxLen, values)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/Mitra-Apps/be-user-service/config/tracing"
 	"github.com/go-redis/redis/v8"
 )
 
@@ -16,9 +17,17 @@ type redisClient struct {
 
 //go:generate mockgen -source=redis.go -destination=mock/redis.go -package=mock
 type RedisInterface interface {
-	GetContext() context.Context
 	GetStringKey(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+	// GetDel returns the value of key and deletes it at once, so a value is never read twice
+	GetDel(ctx context.Context, key string) (string, error)
+	// MGet returns the values of keys in order, empty for missing keys
+	MGet(ctx context.Context, keys ...string) ([]string, error)
+	// Incr increments key and returns its new value, the key expires after expiration once created
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	// XAdd appends an entry made of values to stream, trimmed to about maxLen entries, and returns its id
+	XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error)
 }
 
 func Connection() *redisClient {
@@ -29,7 +38,7 @@ func Connection() *redisClient {
 		Password: "",          // No password
 		DB:       0,           // Default DB
 	})
-	client.Context()
+	client.AddHook(tracing.NewRedisHook(redisServer))
 	return &redisClient{
 		client: client,
 	}
@@ -43,6 +52,48 @@ func (r *redisClient) Set(ctx context.Context, key string, value interface{}, ex
 	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
-func (r *redisClient) GetContext() context.Context {
-	return r.client.Context()
+func (r *redisClient) Del(ctx context.Context, keys ...string) error {
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *redisClient) GetDel(ctx context.Context, key string) (string, error) {
+	return r.client.GetDel(ctx, key).Result()
+}
+
+func (r *redisClient) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i], _ = value.(string)
+	}
+	return strs, nil
+}
+
+func (r *redisClient) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	value, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if value == 1 {
+		if err := r.client.Expire(ctx, key, expiration).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return value, nil
+}
+
+func (r *redisClient) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: values,
+	}).Result()
+}
+
+func (r *redisClient) PoolStats() *redis.PoolStats {
+	return r.client.PoolStats()
 }
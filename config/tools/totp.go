@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters of RFC 6238 as understood by authenticator apps
+const (
+	TotpDigits = 6
+	TotpPeriod = 30 * time.Second
+	// steps accepted before and after the current one to absorb clock drift
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret returns a random 160 bit secret encoded in base32
+func GenerateTotpSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TotpUri returns the otpauth uri shown as a qr code to enroll secret in an authenticator app
+func TotpUri(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TotpDigits))
+	query.Set("period", fmt.Sprint(int(TotpPeriod.Seconds())))
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + query.Encode()
+}
+
+// GenerateTotp returns the code of secret at t, as shown by an authenticator app
+func GenerateTotp(secret string, t time.Time) (string, error) {
+	key, err := decodeTotpSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return totp(key, uint64(t.Unix()/int64(TotpPeriod.Seconds()))), nil
+}
+
+// ValidateTotp reports whether code is the totp of secret at t or at a neighbouring step
+func ValidateTotp(secret, code string, t time.Time) bool {
+	_, ok := MatchTotp(secret, code, t)
+	return ok
+}
+
+// MatchTotp returns the time step code was generated for when it is the totp of secret at t
+// or at a neighbouring step, callers record it to refuse the code once used
+func MatchTotp(secret, code string, t time.Time) (int64, bool) {
+	key, err := decodeTotpSecret(secret)
+	if err != nil || len(code) != TotpDigits {
+		return 0, false
+	}
+	step := t.Unix() / int64(TotpPeriod.Seconds())
+	for i := -totpSkew; i <= totpSkew; i++ {
+		if subtle.ConstantTimeCompare([]byte(totp(key, uint64(step+int64(i)))), []byte(code)) == 1 {
+			return step + int64(i), true
+		}
+	}
+	return 0, false
+}
+
+func decodeTotpSecret(secret string) ([]byte, error) {
+	return totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+}
+
+func totp(key []byte, step uint64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], step)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", TotpDigits, value%1000000)
+}
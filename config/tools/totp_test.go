@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateTotp(t *testing.T) {
+	// secret of the RFC 6238 test vectors
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		name string
+		code string
+		t    time.Time
+		want bool
+	}{
+		{name: "rfc 6238 vector at 59", code: "287082", t: time.Unix(59, 0), want: true},
+		{name: "rfc 6238 vector at 1111111109", code: "081804", t: time.Unix(1111111109, 0), want: true},
+		{name: "previous step accepted", code: "081804", t: time.Unix(1111111109+30, 0), want: true},
+		{name: "two steps late rejected", code: "081804", t: time.Unix(1111111109+60, 0), want: false},
+		{name: "wrong code", code: "123456", t: time.Unix(59, 0), want: false},
+		{name: "wrong length", code: "28708", t: time.Unix(59, 0), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateTotp(secret, tt.code, tt.t); got != tt.want {
+				t.Errorf("ValidateTotp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchTotp(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		name     string
+		code     string
+		t        time.Time
+		wantStep int64
+		wantOk   bool
+	}{
+		{name: "current step", code: "081804", t: time.Unix(1111111109, 0), wantStep: 1111111109 / 30, wantOk: true},
+		{name: "previous step", code: "081804", t: time.Unix(1111111109+30, 0), wantStep: 1111111109 / 30, wantOk: true},
+		{name: "wrong code", code: "123456", t: time.Unix(59, 0), wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := MatchTotp(secret, tt.code, tt.t)
+			if ok != tt.wantOk || step != tt.wantStep {
+				t.Errorf("MatchTotp() = %v, %v, want %v, %v", step, ok, tt.wantStep, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGenerateTotpSecret(t *testing.T) {
+	secret, err := GenerateTotpSecret()
+	if err != nil {
+		t.Fatalf("GenerateTotpSecret() error = %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("GenerateTotpSecret() = %v, want 32 base32 characters", secret)
+	}
+	now := time.Now()
+	code, err := GenerateTotp(secret, now)
+	if err != nil {
+		t.Fatalf("GenerateTotp() error = %v", err)
+	}
+	if !ValidateTotp(secret, code, now) {
+		t.Errorf("ValidateTotp() rejected the current code of a generated secret")
+	}
+}
+
+func TestTotpUri(t *testing.T) {
+	got := TotpUri("Mitra", "test@mail.com", "JBSWY3DPEHPK3PXP")
+	want := "otpauth://totp/Mitra:test@mail.com?algorithm=SHA1&digits=6&issuer=Mitra&period=30&secret=JBSWY3DPEHPK3PXP"
+	if !strings.EqualFold(got, want) {
+		t.Errorf("TotpUri() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+type gormPlugin struct{}
+
+// NewGormPlugin returns a gorm plugin creating a client span for every statement.
+// Spans are only linked to the request when the query is run with db.WithContext(ctx).
+func NewGormPlugin() gorm.Plugin {
+	return &gormPlugin{}
+}
+
+func (p *gormPlugin) Name() string {
+	return "otel"
+}
+
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registers := []error{
+		cb.Create().Before("gorm:create").Register("otel:before_create", before("create")),
+		cb.Create().After("gorm:create").Register("otel:after_create", after),
+		cb.Query().Before("gorm:query").Register("otel:before_query", before("query")),
+		cb.Query().After("gorm:query").Register("otel:after_query", after),
+		cb.Update().Before("gorm:update").Register("otel:before_update", before("update")),
+		cb.Update().After("gorm:update").Register("otel:after_update", after),
+		cb.Delete().Before("gorm:delete").Register("otel:before_delete", before("delete")),
+		cb.Delete().After("gorm:delete").Register("otel:after_delete", after),
+		cb.Row().Before("gorm:row").Register("otel:before_row", before("row")),
+		cb.Row().After("gorm:row").Register("otel:after_row", after),
+		cb.Raw().Before("gorm:raw").Register("otel:before_raw", before("raw")),
+		cb.Raw().After("gorm:raw").Register("otel:after_raw", after),
+	}
+	return errors.Join(registers...)
+}
+
+func before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+		ctx, _ := Tracer().Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemPostgreSQL,
+				semconv.DBSQLTable(db.Statement.Table),
+			),
+		)
+		db.Statement.Context = ctx
+	}
+}
+
+func after(db *gorm.DB) {
+	if db.Statement.Context == nil {
+		return
+	}
+	span := trace.SpanFromContext(db.Statement.Context)
+	if !span.IsRecording() {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBStatement(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
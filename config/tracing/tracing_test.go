@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{
+			name:     "disabled by default",
+			exporter: "",
+			wantErr:  false,
+		},
+		{
+			name:     "disabled",
+			exporter: ExporterNone,
+			wantErr:  false,
+		},
+		{
+			name:     "stdout",
+			exporter: ExporterStdout,
+			wantErr:  false,
+		},
+		{
+			name:     "unknown exporter",
+			exporter: "zipkin",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_EXPORTER", tt.exporter)
+			shutdown, err := Init(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Init() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if shutdown != nil {
+				if err := shutdown(context.Background()); err != nil {
+					t.Errorf("Init() shutdown error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestRedisHook(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	hook := NewRedisHook("localhost:6379")
+	tests := []struct {
+		name       string
+		cmd        *redis.StatusCmd
+		err        error
+		wantName   string
+		wantStatus codes.Code
+	}{
+		{
+			name:       "success",
+			cmd:        redis.NewStatusCmd(context.Background(), "set", "otp:test@mail.com", "secret"),
+			wantName:   "redis.set",
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "key not found is not an error",
+			cmd:        redis.NewStatusCmd(context.Background(), "get", "otp:test@mail.com"),
+			err:        redis.Nil,
+			wantName:   "redis.get",
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "error",
+			cmd:        redis.NewStatusCmd(context.Background(), "get", "otp:test@mail.com"),
+			err:        errors.New("any error"),
+			wantName:   "redis.get",
+			wantStatus: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := hook.BeforeProcess(context.Background(), tt.cmd)
+			if err != nil {
+				t.Fatalf("BeforeProcess() error = %v", err)
+			}
+			tt.cmd.SetErr(tt.err)
+			if err := hook.AfterProcess(ctx, tt.cmd); err != nil {
+				t.Fatalf("AfterProcess() error = %v", err)
+			}
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			if span.Name() != tt.wantName {
+				t.Errorf("span name = %v, want %v", span.Name(), tt.wantName)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+			for _, attr := range span.Attributes() {
+				if attr.Value.Emit() == "secret" {
+					t.Errorf("span attribute %s leaks command arguments", attr.Key)
+				}
+			}
+		})
+	}
+}
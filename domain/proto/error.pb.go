@@ -33,21 +33,61 @@ const (
 	ErrorCode_AUTH_LOGIN_PASSWORD_INCORRECT_3X ErrorCode = 7
 	ErrorCode_AUTH_OTP_INVALID                 ErrorCode = 8
 	ErrorCode_AUTH_OTP_ERROR_VERIFIED_USER     ErrorCode = 9
+	ErrorCode_AUTH_REGISTER_PHONE_REGISTERED   ErrorCode = 10
+	ErrorCode_AUTH_OTP_CHANNEL_UNAVAILABLE     ErrorCode = 11
+	ErrorCode_AUTH_OTP_TOO_MANY_ATTEMPTS       ErrorCode = 12
+	ErrorCode_AUTH_EMAIL_REGISTERED            ErrorCode = 13
+	ErrorCode_AUTH_MFA_ALREADY_ENABLED         ErrorCode = 14
+	ErrorCode_AUTH_MFA_NOT_ENROLLED            ErrorCode = 15
+	ErrorCode_AUTH_MFA_INVALID                 ErrorCode = 16
+	ErrorCode_AUTH_MFA_CHALLENGE_INVALID       ErrorCode = 17
+	ErrorCode_AUTH_SESSION_INVALID             ErrorCode = 18
+	ErrorCode_AUTH_USER_INACTIVE               ErrorCode = 19
+	ErrorCode_AUTH_SOCIAL_TOKEN_INVALID        ErrorCode = 20
+	ErrorCode_AUTH_SOCIAL_EMAIL_UNVERIFIED     ErrorCode = 21
+	ErrorCode_OAUTH_CLIENT_INVALID             ErrorCode = 22
+	ErrorCode_OAUTH_GRANT_INVALID              ErrorCode = 23
+	ErrorCode_AUTH_API_KEY_INVALID             ErrorCode = 24
+	ErrorCode_SERVICE_CLIENT_NOT_FOUND         ErrorCode = 25
+	ErrorCode_IMPERSONATION_NOT_ALLOWED        ErrorCode = 26
+	ErrorCode_AUTH_PASSWORD_POLICY             ErrorCode = 27
+	ErrorCode_AUTH_PASSWORD_BREACHED           ErrorCode = 28
+	ErrorCode_AUTH_OTP_RATE_LIMITED            ErrorCode = 29
 )
 
 // Enum value maps for ErrorCode.
 var (
 	ErrorCode_name = map[int32]string{
-		0: "UNKNOWN",
-		1: "RECORD_NOT_FOUND",
-		2: "AUTH_REGISTER_USER_UNVERIFIED",
-		3: "AUTH_REGISTER_USER_VERIFIED",
-		4: "AUTH_LOGIN_NOT_FOUND",
-		5: "AUTH_LOGIN_USER_UNVERIFIED",
-		6: "AUTH_LOGIN_PASSWORD_INCORRECT",
-		7: "AUTH_LOGIN_PASSWORD_INCORRECT_3X",
-		8: "AUTH_OTP_INVALID",
-		9: "AUTH_OTP_ERROR_VERIFIED_USER",
+		0:  "UNKNOWN",
+		1:  "RECORD_NOT_FOUND",
+		2:  "AUTH_REGISTER_USER_UNVERIFIED",
+		3:  "AUTH_REGISTER_USER_VERIFIED",
+		4:  "AUTH_LOGIN_NOT_FOUND",
+		5:  "AUTH_LOGIN_USER_UNVERIFIED",
+		6:  "AUTH_LOGIN_PASSWORD_INCORRECT",
+		7:  "AUTH_LOGIN_PASSWORD_INCORRECT_3X",
+		8:  "AUTH_OTP_INVALID",
+		9:  "AUTH_OTP_ERROR_VERIFIED_USER",
+		10: "AUTH_REGISTER_PHONE_REGISTERED",
+		11: "AUTH_OTP_CHANNEL_UNAVAILABLE",
+		12: "AUTH_OTP_TOO_MANY_ATTEMPTS",
+		13: "AUTH_EMAIL_REGISTERED",
+		14: "AUTH_MFA_ALREADY_ENABLED",
+		15: "AUTH_MFA_NOT_ENROLLED",
+		16: "AUTH_MFA_INVALID",
+		17: "AUTH_MFA_CHALLENGE_INVALID",
+		18: "AUTH_SESSION_INVALID",
+		19: "AUTH_USER_INACTIVE",
+		20: "AUTH_SOCIAL_TOKEN_INVALID",
+		21: "AUTH_SOCIAL_EMAIL_UNVERIFIED",
+		22: "OAUTH_CLIENT_INVALID",
+		23: "OAUTH_GRANT_INVALID",
+		24: "AUTH_API_KEY_INVALID",
+		25: "SERVICE_CLIENT_NOT_FOUND",
+		26: "IMPERSONATION_NOT_ALLOWED",
+		27: "AUTH_PASSWORD_POLICY",
+		28: "AUTH_PASSWORD_BREACHED",
+		29: "AUTH_OTP_RATE_LIMITED",
 	}
 	ErrorCode_value = map[string]int32{
 		"UNKNOWN":                          0,
@@ -60,6 +100,26 @@ var (
 		"AUTH_LOGIN_PASSWORD_INCORRECT_3X": 7,
 		"AUTH_OTP_INVALID":                 8,
 		"AUTH_OTP_ERROR_VERIFIED_USER":     9,
+		"AUTH_REGISTER_PHONE_REGISTERED":   10,
+		"AUTH_OTP_CHANNEL_UNAVAILABLE":     11,
+		"AUTH_OTP_TOO_MANY_ATTEMPTS":       12,
+		"AUTH_EMAIL_REGISTERED":            13,
+		"AUTH_MFA_ALREADY_ENABLED":         14,
+		"AUTH_MFA_NOT_ENROLLED":            15,
+		"AUTH_MFA_INVALID":                 16,
+		"AUTH_MFA_CHALLENGE_INVALID":       17,
+		"AUTH_SESSION_INVALID":             18,
+		"AUTH_USER_INACTIVE":               19,
+		"AUTH_SOCIAL_TOKEN_INVALID":        20,
+		"AUTH_SOCIAL_EMAIL_UNVERIFIED":     21,
+		"OAUTH_CLIENT_INVALID":             22,
+		"OAUTH_GRANT_INVALID":              23,
+		"AUTH_API_KEY_INVALID":             24,
+		"SERVICE_CLIENT_NOT_FOUND":         25,
+		"IMPERSONATION_NOT_ALLOWED":        26,
+		"AUTH_PASSWORD_POLICY":             27,
+		"AUTH_PASSWORD_BREACHED":           28,
+		"AUTH_OTP_RATE_LIMITED":            29,
 	}
 )
 
@@ -94,7 +154,7 @@ var File_proto_error_proto protoreflect.FileDescriptor
 
 var file_proto_error_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0xad, 0x02, 0x0a, 0x09, 0x45,
+	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0xeb, 0x06, 0x0a, 0x09, 0x45,
 	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
 	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f,
 	0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x41,
@@ -113,16 +173,52 @@ var file_proto_error_proto_rawDesc = []byte{
 	0x10, 0x07, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4f, 0x54, 0x50, 0x5f, 0x49,
 	0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x08, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x55, 0x54, 0x48,
 	0x5f, 0x4f, 0x54, 0x50, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x56, 0x45, 0x52, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10, 0x09, 0x42, 0x85, 0x01, 0x0a, 0x09, 0x63,
-	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x0a, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x4d, 0x69, 0x74, 0x72, 0x61, 0x2d, 0x41, 0x70, 0x70, 0x73, 0x2f, 0x62, 0x65,
-	0x2d, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x64, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0xa2, 0x02, 0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x05, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0xca, 0x02,
-	0x05, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0xe2, 0x02, 0x11, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x47,
-	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x05, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x49, 0x45, 0x44, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10, 0x09, 0x12, 0x22, 0x0a, 0x1e, 0x41, 0x55,
+	0x54, 0x48, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x50, 0x48, 0x4f, 0x4e,
+	0x45, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x45, 0x44, 0x10, 0x0a, 0x12, 0x20,
+	0x0a, 0x1c, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4f, 0x54, 0x50, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e,
+	0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x0b,
+	0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4f, 0x54, 0x50, 0x5f, 0x54, 0x4f, 0x4f,
+	0x5f, 0x4d, 0x41, 0x4e, 0x59, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x4d, 0x50, 0x54, 0x53, 0x10, 0x0c,
+	0x12, 0x19, 0x0a, 0x15, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x5f, 0x52,
+	0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x45, 0x44, 0x10, 0x0d, 0x12, 0x1c, 0x0a, 0x18, 0x41,
+	0x55, 0x54, 0x48, 0x5f, 0x4d, 0x46, 0x41, 0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f,
+	0x45, 0x4e, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x0e, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x55, 0x54,
+	0x48, 0x5f, 0x4d, 0x46, 0x41, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4e, 0x52, 0x4f, 0x4c, 0x4c,
+	0x45, 0x44, 0x10, 0x0f, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x46, 0x41,
+	0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x10, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55,
+	0x54, 0x48, 0x5f, 0x4d, 0x46, 0x41, 0x5f, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45,
+	0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x11, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x55,
+	0x54, 0x48, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x10, 0x12, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x55, 0x53, 0x45,
+	0x52, 0x5f, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x13, 0x12, 0x1d, 0x0a, 0x19,
+	0x41, 0x55, 0x54, 0x48, 0x5f, 0x53, 0x4f, 0x43, 0x49, 0x41, 0x4c, 0x5f, 0x54, 0x4f, 0x4b, 0x45,
+	0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x14, 0x12, 0x20, 0x0a, 0x1c, 0x41,
+	0x55, 0x54, 0x48, 0x5f, 0x53, 0x4f, 0x43, 0x49, 0x41, 0x4c, 0x5f, 0x45, 0x4d, 0x41, 0x49, 0x4c,
+	0x5f, 0x55, 0x4e, 0x56, 0x45, 0x52, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x15, 0x12, 0x18, 0x0a,
+	0x14, 0x4f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x49, 0x4e,
+	0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x16, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x41, 0x55, 0x54, 0x48,
+	0x5f, 0x47, 0x52, 0x41, 0x4e, 0x54, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x17,
+	0x12, 0x18, 0x0a, 0x14, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x41, 0x50, 0x49, 0x5f, 0x4b, 0x45, 0x59,
+	0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x18, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x45,
+	0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x4e, 0x4f, 0x54,
+	0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x19, 0x12, 0x1d, 0x0a, 0x19, 0x49, 0x4d, 0x50, 0x45,
+	0x52, 0x53, 0x4f, 0x4e, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x41, 0x4c,
+	0x4c, 0x4f, 0x57, 0x45, 0x44, 0x10, 0x1a, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x55, 0x54, 0x48, 0x5f,
+	0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x10,
+	0x1b, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f,
+	0x52, 0x44, 0x5f, 0x42, 0x52, 0x45, 0x41, 0x43, 0x48, 0x45, 0x44, 0x10, 0x1c, 0x12, 0x19, 0x0a,
+	0x15, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4f, 0x54, 0x50, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c,
+	0x49, 0x4d, 0x49, 0x54, 0x45, 0x44, 0x10, 0x1d, 0x42, 0x85, 0x01, 0x0a, 0x09, 0x63, 0x6f, 0x6d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x0a, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x4d, 0x69, 0x74, 0x72, 0x61, 0x2d, 0x41, 0x70, 0x70, 0x73, 0x2f, 0x62, 0x65, 0x2d, 0x75,
+	0x73, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0xa2, 0x02,
+	0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x05, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0xca, 0x02, 0x05, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0xe2, 0x02, 0x11, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x47, 0x50, 0x42,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x05, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -0,0 +1,571 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: proto/events/v1/user_events.proto
+
+package events
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on UserRegistered with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserRegistered) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserRegistered with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserRegisteredMultiError,
+// or nil if none found.
+func (m *UserRegistered) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserRegistered) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Email
+
+	// no validation rules for PhoneNumber
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return UserRegisteredMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserRegisteredMultiError is an error wrapping multiple validation errors
+// returned by UserRegistered.ValidateAll() if the designated constraints
+// aren't met.
+type UserRegisteredMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserRegisteredMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserRegisteredMultiError) AllErrors() []error { return m }
+
+// UserRegisteredValidationError is the validation error returned by
+// UserRegistered.Validate if the designated constraints aren't met.
+type UserRegisteredValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserRegisteredValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserRegisteredValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserRegisteredValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserRegisteredValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserRegisteredValidationError) ErrorName() string { return "UserRegisteredValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserRegisteredValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserRegistered.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserRegisteredValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserRegisteredValidationError{}
+
+// Validate checks the field values on UserVerified with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserVerified) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserVerified with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserVerifiedMultiError, or
+// nil if none found.
+func (m *UserVerified) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserVerified) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for EmailVerified
+
+	// no validation rules for PhoneVerified
+
+	if len(errors) > 0 {
+		return UserVerifiedMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserVerifiedMultiError is an error wrapping multiple validation errors
+// returned by UserVerified.ValidateAll() if the designated constraints aren't met.
+type UserVerifiedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserVerifiedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserVerifiedMultiError) AllErrors() []error { return m }
+
+// UserVerifiedValidationError is the validation error returned by
+// UserVerified.Validate if the designated constraints aren't met.
+type UserVerifiedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserVerifiedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserVerifiedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserVerifiedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserVerifiedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserVerifiedValidationError) ErrorName() string { return "UserVerifiedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserVerifiedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserVerified.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserVerifiedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserVerifiedValidationError{}
+
+// Validate checks the field values on UserProfileUpdated with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserProfileUpdated) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserProfileUpdated with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserProfileUpdatedMultiError, or nil if none found.
+func (m *UserProfileUpdated) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserProfileUpdated) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Email
+
+	// no validation rules for PhoneNumber
+
+	// no validation rules for Name
+
+	// no validation rules for Address
+
+	if len(errors) > 0 {
+		return UserProfileUpdatedMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserProfileUpdatedMultiError is an error wrapping multiple validation errors
+// returned by UserProfileUpdated.ValidateAll() if the designated constraints
+// aren't met.
+type UserProfileUpdatedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserProfileUpdatedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserProfileUpdatedMultiError) AllErrors() []error { return m }
+
+// UserProfileUpdatedValidationError is the validation error returned by
+// UserProfileUpdated.Validate if the designated constraints aren't met.
+type UserProfileUpdatedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserProfileUpdatedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserProfileUpdatedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserProfileUpdatedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserProfileUpdatedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserProfileUpdatedValidationError) ErrorName() string {
+	return "UserProfileUpdatedValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserProfileUpdatedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserProfileUpdated.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserProfileUpdatedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserProfileUpdatedValidationError{}
+
+// Validate checks the field values on UserRolesChanged with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserRolesChanged) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserRolesChanged with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserRolesChangedMultiError, or nil if none found.
+func (m *UserRolesChanged) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserRolesChanged) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for ChangedBy
+
+	if len(errors) > 0 {
+		return UserRolesChangedMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserRolesChangedMultiError is an error wrapping multiple validation errors
+// returned by UserRolesChanged.ValidateAll() if the designated constraints
+// aren't met.
+type UserRolesChangedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserRolesChangedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserRolesChangedMultiError) AllErrors() []error { return m }
+
+// UserRolesChangedValidationError is the validation error returned by
+// UserRolesChanged.Validate if the designated constraints aren't met.
+type UserRolesChangedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserRolesChangedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserRolesChangedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserRolesChangedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserRolesChangedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserRolesChangedValidationError) ErrorName() string { return "UserRolesChangedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserRolesChangedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserRolesChanged.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserRolesChangedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserRolesChangedValidationError{}
+
+// Validate checks the field values on UserDeactivated with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserDeactivated) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserDeactivated with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserDeactivatedMultiError, or nil if none found.
+func (m *UserDeactivated) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserDeactivated) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Reason
+
+	// no validation rules for DeactivatedBy
+
+	if len(errors) > 0 {
+		return UserDeactivatedMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserDeactivatedMultiError is an error wrapping multiple validation errors
+// returned by UserDeactivated.ValidateAll() if the designated constraints
+// aren't met.
+type UserDeactivatedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserDeactivatedMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserDeactivatedMultiError) AllErrors() []error { return m }
+
+// UserDeactivatedValidationError is the validation error returned by
+// UserDeactivated.Validate if the designated constraints aren't met.
+type UserDeactivatedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserDeactivatedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserDeactivatedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserDeactivatedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserDeactivatedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserDeactivatedValidationError) ErrorName() string { return "UserDeactivatedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserDeactivatedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserDeactivated.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserDeactivatedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserDeactivatedValidationError{}
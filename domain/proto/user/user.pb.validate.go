@@ -35,6 +35,9 @@ var (
 	_ = sort.Sort
 )
 
+// define the regex for a UUID once up-front
+var _user_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
 // Validate checks the field values on User with the rules defined in the proto
 // definition for this message. If any rules are violated, the first error
 // encountered is returned, or nil if there are no violations.
@@ -78,6 +81,8 @@ func (m *User) validate(all bool) error {
 
 	// no validation rules for Address
 
+	// no validation rules for IsPhoneVerified
+
 	if len(errors) > 0 {
 		return UserMultiError(errors)
 	}
@@ -155,6 +160,115 @@ var _ interface {
 	ErrorName() string
 } = UserValidationError{}
 
+// Validate checks the field values on UserSummary with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserSummary) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserSummary with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserSummaryMultiError, or
+// nil if none found.
+func (m *UserSummary) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserSummary) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for Email
+
+	// no validation rules for AvatarImageId
+
+	// no validation rules for IsActive
+
+	if len(errors) > 0 {
+		return UserSummaryMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserSummaryMultiError is an error wrapping multiple validation errors
+// returned by UserSummary.ValidateAll() if the designated constraints aren't met.
+type UserSummaryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserSummaryMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserSummaryMultiError) AllErrors() []error { return m }
+
+// UserSummaryValidationError is the validation error returned by
+// UserSummary.Validate if the designated constraints aren't met.
+type UserSummaryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserSummaryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserSummaryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserSummaryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserSummaryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserSummaryValidationError) ErrorName() string { return "UserSummaryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserSummaryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserSummary.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserSummaryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserSummaryValidationError{}
+
 // Validate checks the field values on Role with the rules defined in the proto
 // definition for this message. If any rules are violated, the first error
 // encountered is returned, or nil if there are no violations.
@@ -457,10 +571,10 @@ func (m *UserLoginRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if l := utf8.RuneCountInString(m.GetPassword()); l < 6 || l > 8 {
+	if l := utf8.RuneCountInString(m.GetPassword()); l < 1 || l > 128 {
 		err := UserLoginRequestValidationError{
 			field:  "Password",
-			reason: "value length must be between 6 and 8 runes, inclusive",
+			reason: "value length must be between 1 and 128 runes, inclusive",
 		}
 		if !all {
 			return err
@@ -630,10 +744,10 @@ func (m *UserRegisterRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if l := utf8.RuneCountInString(m.GetPassword()); l < 6 || l > 8 {
+	if l := utf8.RuneCountInString(m.GetPassword()); l < 1 || l > 128 {
 		err := UserRegisterRequestValidationError{
 			field:  "Password",
-			reason: "value length must be between 6 and 8 runes, inclusive",
+			reason: "value length must be between 1 and 128 runes, inclusive",
 		}
 		if !all {
 			return err
@@ -665,6 +779,17 @@ func (m *UserRegisterRequest) validate(all bool) error {
 
 	// no validation rules for Address
 
+	if _, ok := OtpChannel_name[int32(m.GetOtpChannel())]; !ok {
+		err := UserRegisterRequestValidationError{
+			field:  "OtpChannel",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return UserRegisterRequestMultiError(errors)
 	}
@@ -1290,6 +1415,17 @@ func (m *ResendOTPRequest) validate(all bool) error {
 
 	// no validation rules for Email
 
+	if _, ok := OtpChannel_name[int32(m.GetOtpChannel())]; !ok {
+		err := ResendOTPRequestValidationError{
+			field:  "OtpChannel",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return ResendOTPRequestMultiError(errors)
 	}
@@ -1472,6 +1608,5392 @@ var _ interface {
 	ErrorName() string
 } = ResendOTPResponseValidationError{}
 
+// Validate checks the field values on SendPhoneVerificationOtpRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SendPhoneVerificationOtpRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SendPhoneVerificationOtpRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// SendPhoneVerificationOtpRequestMultiError, or nil if none found.
+func (m *SendPhoneVerificationOtpRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SendPhoneVerificationOtpRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := OtpChannel_name[int32(m.GetOtpChannel())]; !ok {
+		err := SendPhoneVerificationOtpRequestValidationError{
+			field:  "OtpChannel",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return SendPhoneVerificationOtpRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SendPhoneVerificationOtpRequestMultiError is an error wrapping multiple
+// validation errors returned by SendPhoneVerificationOtpRequest.ValidateAll()
+// if the designated constraints aren't met.
+type SendPhoneVerificationOtpRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SendPhoneVerificationOtpRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SendPhoneVerificationOtpRequestMultiError) AllErrors() []error { return m }
+
+// SendPhoneVerificationOtpRequestValidationError is the validation error
+// returned by SendPhoneVerificationOtpRequest.Validate if the designated
+// constraints aren't met.
+type SendPhoneVerificationOtpRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SendPhoneVerificationOtpRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SendPhoneVerificationOtpRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SendPhoneVerificationOtpRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SendPhoneVerificationOtpRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SendPhoneVerificationOtpRequestValidationError) ErrorName() string {
+	return "SendPhoneVerificationOtpRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SendPhoneVerificationOtpRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSendPhoneVerificationOtpRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SendPhoneVerificationOtpRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SendPhoneVerificationOtpRequestValidationError{}
+
+// Validate checks the field values on VerifyPhoneRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyPhoneRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyPhoneRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyPhoneRequestMultiError, or nil if none found.
+func (m *VerifyPhoneRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyPhoneRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for OtpCode
+
+	if len(errors) > 0 {
+		return VerifyPhoneRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyPhoneRequestMultiError is an error wrapping multiple validation errors
+// returned by VerifyPhoneRequest.ValidateAll() if the designated constraints
+// aren't met.
+type VerifyPhoneRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyPhoneRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyPhoneRequestMultiError) AllErrors() []error { return m }
+
+// VerifyPhoneRequestValidationError is the validation error returned by
+// VerifyPhoneRequest.Validate if the designated constraints aren't met.
+type VerifyPhoneRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyPhoneRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyPhoneRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyPhoneRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyPhoneRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyPhoneRequestValidationError) ErrorName() string {
+	return "VerifyPhoneRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyPhoneRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyPhoneRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyPhoneRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyPhoneRequestValidationError{}
+
+// Validate checks the field values on RequestPhoneLoginOtpRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestPhoneLoginOtpRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestPhoneLoginOtpRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RequestPhoneLoginOtpRequestMultiError, or nil if none found.
+func (m *RequestPhoneLoginOtpRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestPhoneLoginOtpRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetPhoneNumber()); l < 9 || l > 14 {
+		err := RequestPhoneLoginOtpRequestValidationError{
+			field:  "PhoneNumber",
+			reason: "value length must be between 9 and 14 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := OtpChannel_name[int32(m.GetOtpChannel())]; !ok {
+		err := RequestPhoneLoginOtpRequestValidationError{
+			field:  "OtpChannel",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RequestPhoneLoginOtpRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RequestPhoneLoginOtpRequestMultiError is an error wrapping multiple
+// validation errors returned by RequestPhoneLoginOtpRequest.ValidateAll() if
+// the designated constraints aren't met.
+type RequestPhoneLoginOtpRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestPhoneLoginOtpRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestPhoneLoginOtpRequestMultiError) AllErrors() []error { return m }
+
+// RequestPhoneLoginOtpRequestValidationError is the validation error returned
+// by RequestPhoneLoginOtpRequest.Validate if the designated constraints
+// aren't met.
+type RequestPhoneLoginOtpRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestPhoneLoginOtpRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestPhoneLoginOtpRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestPhoneLoginOtpRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestPhoneLoginOtpRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestPhoneLoginOtpRequestValidationError) ErrorName() string {
+	return "RequestPhoneLoginOtpRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestPhoneLoginOtpRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestPhoneLoginOtpRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestPhoneLoginOtpRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestPhoneLoginOtpRequestValidationError{}
+
+// Validate checks the field values on LoginWithPhoneOtpRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *LoginWithPhoneOtpRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoginWithPhoneOtpRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LoginWithPhoneOtpRequestMultiError, or nil if none found.
+func (m *LoginWithPhoneOtpRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoginWithPhoneOtpRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetPhoneNumber()); l < 9 || l > 14 {
+		err := LoginWithPhoneOtpRequestValidationError{
+			field:  "PhoneNumber",
+			reason: "value length must be between 9 and 14 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for OtpCode
+
+	if len(errors) > 0 {
+		return LoginWithPhoneOtpRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoginWithPhoneOtpRequestMultiError is an error wrapping multiple validation
+// errors returned by LoginWithPhoneOtpRequest.ValidateAll() if the designated
+// constraints aren't met.
+type LoginWithPhoneOtpRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoginWithPhoneOtpRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoginWithPhoneOtpRequestMultiError) AllErrors() []error { return m }
+
+// LoginWithPhoneOtpRequestValidationError is the validation error returned by
+// LoginWithPhoneOtpRequest.Validate if the designated constraints aren't met.
+type LoginWithPhoneOtpRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoginWithPhoneOtpRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoginWithPhoneOtpRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoginWithPhoneOtpRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoginWithPhoneOtpRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoginWithPhoneOtpRequestValidationError) ErrorName() string {
+	return "LoginWithPhoneOtpRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e LoginWithPhoneOtpRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoginWithPhoneOtpRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoginWithPhoneOtpRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoginWithPhoneOtpRequestValidationError{}
+
+// Validate checks the field values on LoginWithGoogleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *LoginWithGoogleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoginWithGoogleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LoginWithGoogleRequestMultiError, or nil if none found.
+func (m *LoginWithGoogleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoginWithGoogleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetIdToken()) < 1 {
+		err := LoginWithGoogleRequestValidationError{
+			field:  "IdToken",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return LoginWithGoogleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoginWithGoogleRequestMultiError is an error wrapping multiple validation
+// errors returned by LoginWithGoogleRequest.ValidateAll() if the designated
+// constraints aren't met.
+type LoginWithGoogleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoginWithGoogleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoginWithGoogleRequestMultiError) AllErrors() []error { return m }
+
+// LoginWithGoogleRequestValidationError is the validation error returned by
+// LoginWithGoogleRequest.Validate if the designated constraints aren't met.
+type LoginWithGoogleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoginWithGoogleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoginWithGoogleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoginWithGoogleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoginWithGoogleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoginWithGoogleRequestValidationError) ErrorName() string {
+	return "LoginWithGoogleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e LoginWithGoogleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoginWithGoogleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoginWithGoogleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoginWithGoogleRequestValidationError{}
+
+// Validate checks the field values on RequestEmailChangeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestEmailChangeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestEmailChangeRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RequestEmailChangeRequestMultiError, or nil if none found.
+func (m *RequestEmailChangeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestEmailChangeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateEmail(m.GetNewEmail()); err != nil {
+		err = RequestEmailChangeRequestValidationError{
+			field:  "NewEmail",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RequestEmailChangeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *RequestEmailChangeRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *RequestEmailChangeRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// RequestEmailChangeRequestMultiError is an error wrapping multiple validation
+// errors returned by RequestEmailChangeRequest.ValidateAll() if the
+// designated constraints aren't met.
+type RequestEmailChangeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestEmailChangeRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestEmailChangeRequestMultiError) AllErrors() []error { return m }
+
+// RequestEmailChangeRequestValidationError is the validation error returned by
+// RequestEmailChangeRequest.Validate if the designated constraints aren't met.
+type RequestEmailChangeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestEmailChangeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestEmailChangeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestEmailChangeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestEmailChangeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestEmailChangeRequestValidationError) ErrorName() string {
+	return "RequestEmailChangeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestEmailChangeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestEmailChangeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestEmailChangeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestEmailChangeRequestValidationError{}
+
+// Validate checks the field values on ConfirmEmailChangeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfirmEmailChangeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfirmEmailChangeRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfirmEmailChangeRequestMultiError, or nil if none found.
+func (m *ConfirmEmailChangeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfirmEmailChangeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for OtpCode
+
+	if len(errors) > 0 {
+		return ConfirmEmailChangeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChangeRequestMultiError is an error wrapping multiple validation
+// errors returned by ConfirmEmailChangeRequest.ValidateAll() if the
+// designated constraints aren't met.
+type ConfirmEmailChangeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfirmEmailChangeRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfirmEmailChangeRequestMultiError) AllErrors() []error { return m }
+
+// ConfirmEmailChangeRequestValidationError is the validation error returned by
+// ConfirmEmailChangeRequest.Validate if the designated constraints aren't met.
+type ConfirmEmailChangeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfirmEmailChangeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfirmEmailChangeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfirmEmailChangeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfirmEmailChangeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfirmEmailChangeRequestValidationError) ErrorName() string {
+	return "ConfirmEmailChangeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConfirmEmailChangeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfirmEmailChangeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfirmEmailChangeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfirmEmailChangeRequestValidationError{}
+
+// Validate checks the field values on ConfirmMfaRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ConfirmMfaRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfirmMfaRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfirmMfaRequestMultiError, or nil if none found.
+func (m *ConfirmMfaRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfirmMfaRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCode()) != 6 {
+		err := ConfirmMfaRequestValidationError{
+			field:  "Code",
+			reason: "value length must be 6 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+
+	}
+
+	if !_ConfirmMfaRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := ConfirmMfaRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9]+$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ConfirmMfaRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfirmMfaRequestMultiError is an error wrapping multiple validation errors
+// returned by ConfirmMfaRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ConfirmMfaRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfirmMfaRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfirmMfaRequestMultiError) AllErrors() []error { return m }
+
+// ConfirmMfaRequestValidationError is the validation error returned by
+// ConfirmMfaRequest.Validate if the designated constraints aren't met.
+type ConfirmMfaRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfirmMfaRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfirmMfaRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfirmMfaRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfirmMfaRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfirmMfaRequestValidationError) ErrorName() string {
+	return "ConfirmMfaRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConfirmMfaRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfirmMfaRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfirmMfaRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfirmMfaRequestValidationError{}
+
+var _ConfirmMfaRequest_Code_Pattern = regexp.MustCompile("^[0-9]+$")
+
+// Validate checks the field values on VerifyMfaRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *VerifyMfaRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyMfaRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyMfaRequestMultiError, or nil if none found.
+func (m *VerifyMfaRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyMfaRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetMfaToken()) < 1 {
+		err := VerifyMfaRequestValidationError{
+			field:  "MfaToken",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetCode()); l < 6 || l > 32 {
+		err := VerifyMfaRequestValidationError{
+			field:  "Code",
+			reason: "value length must be between 6 and 32 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return VerifyMfaRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyMfaRequestMultiError is an error wrapping multiple validation errors
+// returned by VerifyMfaRequest.ValidateAll() if the designated constraints
+// aren't met.
+type VerifyMfaRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyMfaRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyMfaRequestMultiError) AllErrors() []error { return m }
+
+// VerifyMfaRequestValidationError is the validation error returned by
+// VerifyMfaRequest.Validate if the designated constraints aren't met.
+type VerifyMfaRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyMfaRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyMfaRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyMfaRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyMfaRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyMfaRequestValidationError) ErrorName() string { return "VerifyMfaRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e VerifyMfaRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyMfaRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyMfaRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyMfaRequestValidationError{}
+
+// Validate checks the field values on Session with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Session) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Session with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in SessionMultiError, or nil if none found.
+func (m *Session) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Session) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for DeviceName
+
+	// no validation rules for UserAgent
+
+	// no validation rules for IpAddress
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SessionValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SessionValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SessionValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLastSeenAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SessionValidationError{
+					field:  "LastSeenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SessionValidationError{
+					field:  "LastSeenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastSeenAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SessionValidationError{
+				field:  "LastSeenAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Current
+
+	// no validation rules for Impersonated
+
+	if len(errors) > 0 {
+		return SessionMultiError(errors)
+	}
+
+	return nil
+}
+
+// SessionMultiError is an error wrapping multiple validation errors returned
+// by Session.ValidateAll() if the designated constraints aren't met.
+type SessionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SessionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SessionMultiError) AllErrors() []error { return m }
+
+// SessionValidationError is the validation error returned by Session.Validate
+// if the designated constraints aren't met.
+type SessionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SessionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SessionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SessionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SessionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SessionValidationError) ErrorName() string { return "SessionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SessionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSession.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SessionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SessionValidationError{}
+
+// Validate checks the field values on ListSessionsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListSessionsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSessionsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListSessionsResponseMultiError, or nil if none found.
+func (m *ListSessionsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSessionsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetSessions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListSessionsResponseValidationError{
+						field:  fmt.Sprintf("Sessions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListSessionsResponseValidationError{
+						field:  fmt.Sprintf("Sessions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListSessionsResponseValidationError{
+					field:  fmt.Sprintf("Sessions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListSessionsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSessionsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListSessionsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListSessionsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSessionsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSessionsResponseMultiError) AllErrors() []error { return m }
+
+// ListSessionsResponseValidationError is the validation error returned by
+// ListSessionsResponse.Validate if the designated constraints aren't met.
+type ListSessionsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSessionsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSessionsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSessionsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSessionsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSessionsResponseValidationError) ErrorName() string {
+	return "ListSessionsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSessionsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSessionsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSessionsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSessionsResponseValidationError{}
+
+// Validate checks the field values on RevokeSessionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeSessionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeSessionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeSessionRequestMultiError, or nil if none found.
+func (m *RevokeSessionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeSessionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetSessionId()); err != nil {
+		err = RevokeSessionRequestValidationError{
+			field:  "SessionId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RevokeSessionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *RevokeSessionRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// RevokeSessionRequestMultiError is an error wrapping multiple validation
+// errors returned by RevokeSessionRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeSessionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeSessionRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeSessionRequestMultiError) AllErrors() []error { return m }
+
+// RevokeSessionRequestValidationError is the validation error returned by
+// RevokeSessionRequest.Validate if the designated constraints aren't met.
+type RevokeSessionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeSessionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeSessionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeSessionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeSessionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeSessionRequestValidationError) ErrorName() string {
+	return "RevokeSessionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeSessionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeSessionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeSessionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeSessionRequestValidationError{}
+
+// Validate checks the field values on RefreshTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RefreshTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RefreshTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RefreshTokenRequestMultiError, or nil if none found.
+func (m *RefreshTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RefreshTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetRefreshToken()) < 1 {
+		err := RefreshTokenRequestValidationError{
+			field:  "RefreshToken",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RefreshTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RefreshTokenRequestMultiError is an error wrapping multiple validation
+// errors returned by RefreshTokenRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RefreshTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RefreshTokenRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RefreshTokenRequestMultiError) AllErrors() []error { return m }
+
+// RefreshTokenRequestValidationError is the validation error returned by
+// RefreshTokenRequest.Validate if the designated constraints aren't met.
+type RefreshTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RefreshTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RefreshTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RefreshTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RefreshTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RefreshTokenRequestValidationError) ErrorName() string {
+	return "RefreshTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RefreshTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRefreshTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RefreshTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RefreshTokenRequestValidationError{}
+
+// Validate checks the field values on DeactivateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeactivateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeactivateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeactivateUserRequestMultiError, or nil if none found.
+func (m *DeactivateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeactivateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = DeactivateUserRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeactivateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *DeactivateUserRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// DeactivateUserRequestMultiError is an error wrapping multiple validation
+// errors returned by DeactivateUserRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DeactivateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeactivateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeactivateUserRequestMultiError) AllErrors() []error { return m }
+
+// DeactivateUserRequestValidationError is the validation error returned by
+// DeactivateUserRequest.Validate if the designated constraints aren't met.
+type DeactivateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeactivateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeactivateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeactivateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeactivateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeactivateUserRequestValidationError) ErrorName() string {
+	return "DeactivateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeactivateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeactivateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeactivateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeactivateUserRequestValidationError{}
+
+// Validate checks the field values on ImpersonateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ImpersonateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ImpersonateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ImpersonateUserRequestMultiError, or nil if none found.
+func (m *ImpersonateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ImpersonateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = ImpersonateUserRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetReason()); l < 1 || l > 500 {
+		err := ImpersonateUserRequestValidationError{
+			field:  "Reason",
+			reason: "value length must be between 1 and 500 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ImpersonateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ImpersonateUserRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ImpersonateUserRequestMultiError is an error wrapping multiple validation
+// errors returned by ImpersonateUserRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ImpersonateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ImpersonateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ImpersonateUserRequestMultiError) AllErrors() []error { return m }
+
+// ImpersonateUserRequestValidationError is the validation error returned by
+// ImpersonateUserRequest.Validate if the designated constraints aren't met.
+type ImpersonateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ImpersonateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ImpersonateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ImpersonateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ImpersonateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ImpersonateUserRequestValidationError) ErrorName() string {
+	return "ImpersonateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ImpersonateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sImpersonateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ImpersonateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ImpersonateUserRequestValidationError{}
+
+// Validate checks the field values on ImpersonateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ImpersonateUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ImpersonateUserResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ImpersonateUserResponseMultiError, or nil if none found.
+func (m *ImpersonateUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ImpersonateUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for AccessToken
+
+	// no validation rules for ExpiresIn
+
+	// no validation rules for SessionId
+
+	if len(errors) > 0 {
+		return ImpersonateUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ImpersonateUserResponseMultiError is an error wrapping multiple validation
+// errors returned by ImpersonateUserResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ImpersonateUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ImpersonateUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ImpersonateUserResponseMultiError) AllErrors() []error { return m }
+
+// ImpersonateUserResponseValidationError is the validation error returned by
+// ImpersonateUserResponse.Validate if the designated constraints aren't met.
+type ImpersonateUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ImpersonateUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ImpersonateUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ImpersonateUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ImpersonateUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ImpersonateUserResponseValidationError) ErrorName() string {
+	return "ImpersonateUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ImpersonateUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sImpersonateUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ImpersonateUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ImpersonateUserResponseValidationError{}
+
+// Validate checks the field values on ReactivateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReactivateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReactivateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReactivateUserRequestMultiError, or nil if none found.
+func (m *ReactivateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReactivateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = ReactivateUserRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReactivateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ReactivateUserRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ReactivateUserRequestMultiError is an error wrapping multiple validation
+// errors returned by ReactivateUserRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ReactivateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReactivateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReactivateUserRequestMultiError) AllErrors() []error { return m }
+
+// ReactivateUserRequestValidationError is the validation error returned by
+// ReactivateUserRequest.Validate if the designated constraints aren't met.
+type ReactivateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReactivateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReactivateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReactivateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReactivateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReactivateUserRequestValidationError) ErrorName() string {
+	return "ReactivateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReactivateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReactivateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReactivateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReactivateUserRequestValidationError{}
+
+// Validate checks the field values on IntrospectTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *IntrospectTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IntrospectTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// IntrospectTokenRequestMultiError, or nil if none found.
+func (m *IntrospectTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IntrospectTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := IntrospectTokenRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return IntrospectTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// IntrospectTokenRequestMultiError is an error wrapping multiple validation
+// errors returned by IntrospectTokenRequest.ValidateAll() if the designated
+// constraints aren't met.
+type IntrospectTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IntrospectTokenRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IntrospectTokenRequestMultiError) AllErrors() []error { return m }
+
+// IntrospectTokenRequestValidationError is the validation error returned by
+// IntrospectTokenRequest.Validate if the designated constraints aren't met.
+type IntrospectTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IntrospectTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IntrospectTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IntrospectTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IntrospectTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IntrospectTokenRequestValidationError) ErrorName() string {
+	return "IntrospectTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e IntrospectTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIntrospectTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IntrospectTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IntrospectTokenRequestValidationError{}
+
+// Validate checks the field values on IntrospectTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *IntrospectTokenResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IntrospectTokenResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// IntrospectTokenResponseMultiError, or nil if none found.
+func (m *IntrospectTokenResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IntrospectTokenResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Active
+
+	// no validation rules for Sub
+
+	if all {
+		switch v := interface{}(m.GetExp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, IntrospectTokenResponseValidationError{
+					field:  "Exp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, IntrospectTokenResponseValidationError{
+					field:  "Exp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return IntrospectTokenResponseValidationError{
+				field:  "Exp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetIat()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, IntrospectTokenResponseValidationError{
+					field:  "Iat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, IntrospectTokenResponseValidationError{
+					field:  "Iat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetIat()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return IntrospectTokenResponseValidationError{
+				field:  "Iat",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for SessionId
+
+	// no validation rules for TokenType
+
+	// no validation rules for ActSub
+
+	if len(errors) > 0 {
+		return IntrospectTokenResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// IntrospectTokenResponseMultiError is an error wrapping multiple validation
+// errors returned by IntrospectTokenResponse.ValidateAll() if the designated
+// constraints aren't met.
+type IntrospectTokenResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IntrospectTokenResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IntrospectTokenResponseMultiError) AllErrors() []error { return m }
+
+// IntrospectTokenResponseValidationError is the validation error returned by
+// IntrospectTokenResponse.Validate if the designated constraints aren't met.
+type IntrospectTokenResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IntrospectTokenResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IntrospectTokenResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IntrospectTokenResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IntrospectTokenResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IntrospectTokenResponseValidationError) ErrorName() string {
+	return "IntrospectTokenResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e IntrospectTokenResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIntrospectTokenResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IntrospectTokenResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IntrospectTokenResponseValidationError{}
+
+// Validate checks the field values on GetUsersByIdsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUsersByIdsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUsersByIdsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUsersByIdsRequestMultiError, or nil if none found.
+func (m *GetUsersByIdsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUsersByIdsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetIds()); l < 1 || l > 100 {
+		err := GetUsersByIdsRequestValidationError{
+			field:  "Ids",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIds() {
+		_, _ = idx, item
+
+		if err := m._validateUuid(item); err != nil {
+			err = GetUsersByIdsRequestValidationError{
+				field:  fmt.Sprintf("Ids[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetUsersByIdsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *GetUsersByIdsRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// GetUsersByIdsRequestMultiError is an error wrapping multiple validation
+// errors returned by GetUsersByIdsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetUsersByIdsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUsersByIdsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUsersByIdsRequestMultiError) AllErrors() []error { return m }
+
+// GetUsersByIdsRequestValidationError is the validation error returned by
+// GetUsersByIdsRequest.Validate if the designated constraints aren't met.
+type GetUsersByIdsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUsersByIdsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUsersByIdsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUsersByIdsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUsersByIdsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUsersByIdsRequestValidationError) ErrorName() string {
+	return "GetUsersByIdsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetUsersByIdsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUsersByIdsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUsersByIdsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUsersByIdsRequestValidationError{}
+
+// Validate checks the field values on GetUsersByIdsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUsersByIdsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUsersByIdsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUsersByIdsResponseMultiError, or nil if none found.
+func (m *GetUsersByIdsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUsersByIdsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetUsersByIdsResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetUsersByIdsResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetUsersByIdsResponseValidationError{
+					field:  fmt.Sprintf("Users[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetUsersByIdsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUsersByIdsResponseMultiError is an error wrapping multiple validation
+// errors returned by GetUsersByIdsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetUsersByIdsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUsersByIdsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUsersByIdsResponseMultiError) AllErrors() []error { return m }
+
+// GetUsersByIdsResponseValidationError is the validation error returned by
+// GetUsersByIdsResponse.Validate if the designated constraints aren't met.
+type GetUsersByIdsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUsersByIdsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUsersByIdsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUsersByIdsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUsersByIdsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUsersByIdsResponseValidationError) ErrorName() string {
+	return "GetUsersByIdsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetUsersByIdsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUsersByIdsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUsersByIdsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUsersByIdsResponseValidationError{}
+
+// Validate checks the field values on GetUserByEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUserByEmailRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserByEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUserByEmailRequestMultiError, or nil if none found.
+func (m *GetUserByEmailRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserByEmailRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateEmail(m.GetEmail()); err != nil {
+		err = GetUserByEmailRequestValidationError{
+			field:  "Email",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetUserByEmailRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *GetUserByEmailRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetUserByEmailRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// GetUserByEmailRequestMultiError is an error wrapping multiple validation
+// errors returned by GetUserByEmailRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetUserByEmailRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserByEmailRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserByEmailRequestMultiError) AllErrors() []error { return m }
+
+// GetUserByEmailRequestValidationError is the validation error returned by
+// GetUserByEmailRequest.Validate if the designated constraints aren't met.
+type GetUserByEmailRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserByEmailRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserByEmailRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserByEmailRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserByEmailRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserByEmailRequestValidationError) ErrorName() string {
+	return "GetUserByEmailRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetUserByEmailRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserByEmailRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserByEmailRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserByEmailRequestValidationError{}
+
+// Validate checks the field values on GetUserByEmailResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUserByEmailResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserByEmailResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUserByEmailResponseMultiError, or nil if none found.
+func (m *GetUserByEmailResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserByEmailResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetUserByEmailResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetUserByEmailResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetUserByEmailResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetUserByEmailResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUserByEmailResponseMultiError is an error wrapping multiple validation
+// errors returned by GetUserByEmailResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetUserByEmailResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserByEmailResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserByEmailResponseMultiError) AllErrors() []error { return m }
+
+// GetUserByEmailResponseValidationError is the validation error returned by
+// GetUserByEmailResponse.Validate if the designated constraints aren't met.
+type GetUserByEmailResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserByEmailResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserByEmailResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserByEmailResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserByEmailResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserByEmailResponseValidationError) ErrorName() string {
+	return "GetUserByEmailResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetUserByEmailResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserByEmailResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserByEmailResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserByEmailResponseValidationError{}
+
+// Validate checks the field values on SetUserRolesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetUserRolesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetUserRolesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetUserRolesRequestMultiError, or nil if none found.
+func (m *SetUserRolesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetUserRolesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = SetUserRolesRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetRoleIds()) < 1 {
+		err := SetUserRolesRequestValidationError{
+			field:  "RoleIds",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_SetUserRolesRequest_RoleIds_Unique := make(map[string]struct{}, len(m.GetRoleIds()))
+
+	for idx, item := range m.GetRoleIds() {
+		_, _ = idx, item
+
+		if _, exists := _SetUserRolesRequest_RoleIds_Unique[item]; exists {
+			err := SetUserRolesRequestValidationError{
+				field:  fmt.Sprintf("RoleIds[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_SetUserRolesRequest_RoleIds_Unique[item] = struct{}{}
+		}
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := SetUserRolesRequestValidationError{
+				field:  fmt.Sprintf("RoleIds[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return SetUserRolesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *SetUserRolesRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// SetUserRolesRequestMultiError is an error wrapping multiple validation
+// errors returned by SetUserRolesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SetUserRolesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetUserRolesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetUserRolesRequestMultiError) AllErrors() []error { return m }
+
+// SetUserRolesRequestValidationError is the validation error returned by
+// SetUserRolesRequest.Validate if the designated constraints aren't met.
+type SetUserRolesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetUserRolesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetUserRolesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetUserRolesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetUserRolesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetUserRolesRequestValidationError) ErrorName() string {
+	return "SetUserRolesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SetUserRolesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetUserRolesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetUserRolesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetUserRolesRequestValidationError{}
+
+// Validate checks the field values on OAuthClient with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *OAuthClient) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on OAuthClient with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in OAuthClientMultiError, or
+// nil if none found.
+func (m *OAuthClient) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *OAuthClient) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ClientId
+
+	// no validation rules for Name
+
+	// no validation rules for Confidential
+
+	if len(errors) > 0 {
+		return OAuthClientMultiError(errors)
+	}
+
+	return nil
+}
+
+// OAuthClientMultiError is an error wrapping multiple validation errors
+// returned by OAuthClient.ValidateAll() if the designated constraints aren't met.
+type OAuthClientMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m OAuthClientMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m OAuthClientMultiError) AllErrors() []error { return m }
+
+// OAuthClientValidationError is the validation error returned by
+// OAuthClient.Validate if the designated constraints aren't met.
+type OAuthClientValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OAuthClientValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OAuthClientValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OAuthClientValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OAuthClientValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OAuthClientValidationError) ErrorName() string { return "OAuthClientValidationError" }
+
+// Error satisfies the builtin error interface
+func (e OAuthClientValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOAuthClient.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OAuthClientValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OAuthClientValidationError{}
+
+// Validate checks the field values on CreateOAuthClientRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateOAuthClientRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateOAuthClientRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateOAuthClientRequestMultiError, or nil if none found.
+func (m *CreateOAuthClientRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateOAuthClientRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 255 {
+		err := CreateOAuthClientRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetRedirectUris()) < 1 {
+		err := CreateOAuthClientRequestValidationError{
+			field:  "RedirectUris",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_CreateOAuthClientRequest_RedirectUris_Unique := make(map[string]struct{}, len(m.GetRedirectUris()))
+
+	for idx, item := range m.GetRedirectUris() {
+		_, _ = idx, item
+
+		if _, exists := _CreateOAuthClientRequest_RedirectUris_Unique[item]; exists {
+			err := CreateOAuthClientRequestValidationError{
+				field:  fmt.Sprintf("RedirectUris[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_CreateOAuthClientRequest_RedirectUris_Unique[item] = struct{}{}
+		}
+
+		if utf8.RuneCountInString(item) > 2048 {
+			err := CreateOAuthClientRequestValidationError{
+				field:  fmt.Sprintf("RedirectUris[%v]", idx),
+				reason: "value length must be at most 2048 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if uri, err := url.Parse(item); err != nil {
+			err = CreateOAuthClientRequestValidationError{
+				field:  fmt.Sprintf("RedirectUris[%v]", idx),
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := CreateOAuthClientRequestValidationError{
+				field:  fmt.Sprintf("RedirectUris[%v]", idx),
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Confidential
+
+	if len(errors) > 0 {
+		return CreateOAuthClientRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateOAuthClientRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateOAuthClientRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateOAuthClientRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateOAuthClientRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateOAuthClientRequestMultiError) AllErrors() []error { return m }
+
+// CreateOAuthClientRequestValidationError is the validation error returned by
+// CreateOAuthClientRequest.Validate if the designated constraints aren't met.
+type CreateOAuthClientRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateOAuthClientRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateOAuthClientRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateOAuthClientRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateOAuthClientRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateOAuthClientRequestValidationError) ErrorName() string {
+	return "CreateOAuthClientRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateOAuthClientRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateOAuthClientRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateOAuthClientRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateOAuthClientRequestValidationError{}
+
+// Validate checks the field values on CreateOAuthClientResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateOAuthClientResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateOAuthClientResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateOAuthClientResponseMultiError, or nil if none found.
+func (m *CreateOAuthClientResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateOAuthClientResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetClient()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateOAuthClientResponseValidationError{
+					field:  "Client",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateOAuthClientResponseValidationError{
+					field:  "Client",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClient()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateOAuthClientResponseValidationError{
+				field:  "Client",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ClientSecret
+
+	if len(errors) > 0 {
+		return CreateOAuthClientResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateOAuthClientResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateOAuthClientResponse.ValidateAll() if the
+// designated constraints aren't met.
+type CreateOAuthClientResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateOAuthClientResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateOAuthClientResponseMultiError) AllErrors() []error { return m }
+
+// CreateOAuthClientResponseValidationError is the validation error returned by
+// CreateOAuthClientResponse.Validate if the designated constraints aren't met.
+type CreateOAuthClientResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateOAuthClientResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateOAuthClientResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateOAuthClientResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateOAuthClientResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateOAuthClientResponseValidationError) ErrorName() string {
+	return "CreateOAuthClientResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateOAuthClientResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateOAuthClientResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateOAuthClientResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateOAuthClientResponseValidationError{}
+
+// Validate checks the field values on ServiceClient with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ServiceClient) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServiceClient with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ServiceClientMultiError, or
+// nil if none found.
+func (m *ServiceClient) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServiceClient) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for KeyPrefix
+
+	// no validation rules for Revoked
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServiceClientValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServiceClientValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServiceClientValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ServiceClientMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServiceClientMultiError is an error wrapping multiple validation errors
+// returned by ServiceClient.ValidateAll() if the designated constraints
+// aren't met.
+type ServiceClientMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServiceClientMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServiceClientMultiError) AllErrors() []error { return m }
+
+// ServiceClientValidationError is the validation error returned by
+// ServiceClient.Validate if the designated constraints aren't met.
+type ServiceClientValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServiceClientValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServiceClientValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServiceClientValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServiceClientValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServiceClientValidationError) ErrorName() string { return "ServiceClientValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ServiceClientValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServiceClient.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServiceClientValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServiceClientValidationError{}
+
+// Validate checks the field values on CreateServiceClientRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateServiceClientRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateServiceClientRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateServiceClientRequestMultiError, or nil if none found.
+func (m *CreateServiceClientRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateServiceClientRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 255 {
+		err := CreateServiceClientRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetScopes()) < 1 {
+		err := CreateServiceClientRequestValidationError{
+			field:  "Scopes",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_CreateServiceClientRequest_Scopes_Unique := make(map[string]struct{}, len(m.GetScopes()))
+
+	for idx, item := range m.GetScopes() {
+		_, _ = idx, item
+
+		if _, exists := _CreateServiceClientRequest_Scopes_Unique[item]; exists {
+			err := CreateServiceClientRequestValidationError{
+				field:  fmt.Sprintf("Scopes[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_CreateServiceClientRequest_Scopes_Unique[item] = struct{}{}
+		}
+
+		if _, ok := _CreateServiceClientRequest_Scopes_InLookup[item]; !ok {
+			err := CreateServiceClientRequestValidationError{
+				field:  fmt.Sprintf("Scopes[%v]", idx),
+				reason: "value must be in list [users:read audit:read tokens:introspect]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateServiceClientRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateServiceClientRequestMultiError is an error wrapping multiple
+// validation errors returned by CreateServiceClientRequest.ValidateAll() if
+// the designated constraints aren't met.
+type CreateServiceClientRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateServiceClientRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateServiceClientRequestMultiError) AllErrors() []error { return m }
+
+// CreateServiceClientRequestValidationError is the validation error returned
+// by CreateServiceClientRequest.Validate if the designated constraints aren't met.
+type CreateServiceClientRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateServiceClientRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateServiceClientRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateServiceClientRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateServiceClientRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateServiceClientRequestValidationError) ErrorName() string {
+	return "CreateServiceClientRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateServiceClientRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateServiceClientRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateServiceClientRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateServiceClientRequestValidationError{}
+
+var _CreateServiceClientRequest_Scopes_InLookup = map[string]struct{}{
+	"users:read":        {},
+	"audit:read":        {},
+	"tokens:introspect": {},
+}
+
+// Validate checks the field values on CreateServiceClientResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateServiceClientResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateServiceClientResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateServiceClientResponseMultiError, or nil if none found.
+func (m *CreateServiceClientResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateServiceClientResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetClient()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateServiceClientResponseValidationError{
+					field:  "Client",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateServiceClientResponseValidationError{
+					field:  "Client",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClient()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateServiceClientResponseValidationError{
+				field:  "Client",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ApiKey
+
+	if len(errors) > 0 {
+		return CreateServiceClientResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateServiceClientResponseMultiError is an error wrapping multiple
+// validation errors returned by CreateServiceClientResponse.ValidateAll() if
+// the designated constraints aren't met.
+type CreateServiceClientResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateServiceClientResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateServiceClientResponseMultiError) AllErrors() []error { return m }
+
+// CreateServiceClientResponseValidationError is the validation error returned
+// by CreateServiceClientResponse.Validate if the designated constraints
+// aren't met.
+type CreateServiceClientResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateServiceClientResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateServiceClientResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateServiceClientResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateServiceClientResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateServiceClientResponseValidationError) ErrorName() string {
+	return "CreateServiceClientResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateServiceClientResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateServiceClientResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateServiceClientResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateServiceClientResponseValidationError{}
+
+// Validate checks the field values on ListServiceClientsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListServiceClientsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListServiceClientsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListServiceClientsResponseMultiError, or nil if none found.
+func (m *ListServiceClientsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListServiceClientsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetClients() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListServiceClientsResponseValidationError{
+						field:  fmt.Sprintf("Clients[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListServiceClientsResponseValidationError{
+						field:  fmt.Sprintf("Clients[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListServiceClientsResponseValidationError{
+					field:  fmt.Sprintf("Clients[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListServiceClientsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListServiceClientsResponseMultiError is an error wrapping multiple
+// validation errors returned by ListServiceClientsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ListServiceClientsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListServiceClientsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListServiceClientsResponseMultiError) AllErrors() []error { return m }
+
+// ListServiceClientsResponseValidationError is the validation error returned
+// by ListServiceClientsResponse.Validate if the designated constraints aren't met.
+type ListServiceClientsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListServiceClientsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListServiceClientsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListServiceClientsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListServiceClientsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListServiceClientsResponseValidationError) ErrorName() string {
+	return "ListServiceClientsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListServiceClientsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListServiceClientsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListServiceClientsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListServiceClientsResponseValidationError{}
+
+// Validate checks the field values on RevokeServiceClientRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeServiceClientRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeServiceClientRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeServiceClientRequestMultiError, or nil if none found.
+func (m *RevokeServiceClientRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeServiceClientRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetClientId()); err != nil {
+		err = RevokeServiceClientRequestValidationError{
+			field:  "ClientId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RevokeServiceClientRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *RevokeServiceClientRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// RevokeServiceClientRequestMultiError is an error wrapping multiple
+// validation errors returned by RevokeServiceClientRequest.ValidateAll() if
+// the designated constraints aren't met.
+type RevokeServiceClientRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeServiceClientRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeServiceClientRequestMultiError) AllErrors() []error { return m }
+
+// RevokeServiceClientRequestValidationError is the validation error returned
+// by RevokeServiceClientRequest.Validate if the designated constraints aren't met.
+type RevokeServiceClientRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeServiceClientRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeServiceClientRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeServiceClientRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeServiceClientRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeServiceClientRequestValidationError) ErrorName() string {
+	return "RevokeServiceClientRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeServiceClientRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeServiceClientRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeServiceClientRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeServiceClientRequestValidationError{}
+
+// Validate checks the field values on DeleteAccountRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteAccountRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteAccountRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteAccountRequestMultiError, or nil if none found.
+func (m *DeleteAccountRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteAccountRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetPassword()) < 1 {
+		err := DeleteAccountRequestValidationError{
+			field:  "Password",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteAccountRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteAccountRequestMultiError is an error wrapping multiple validation
+// errors returned by DeleteAccountRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteAccountRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteAccountRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteAccountRequestMultiError) AllErrors() []error { return m }
+
+// DeleteAccountRequestValidationError is the validation error returned by
+// DeleteAccountRequest.Validate if the designated constraints aren't met.
+type DeleteAccountRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteAccountRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteAccountRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteAccountRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteAccountRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteAccountRequestValidationError) ErrorName() string {
+	return "DeleteAccountRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteAccountRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteAccountRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteAccountRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteAccountRequestValidationError{}
+
+// Validate checks the field values on ExportUserDataRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExportUserDataRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportUserDataRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExportUserDataRequestMultiError, or nil if none found.
+func (m *ExportUserDataRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportUserDataRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = ExportUserDataRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ExportUserDataRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ExportUserDataRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ExportUserDataRequestMultiError is an error wrapping multiple validation
+// errors returned by ExportUserDataRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ExportUserDataRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportUserDataRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportUserDataRequestMultiError) AllErrors() []error { return m }
+
+// ExportUserDataRequestValidationError is the validation error returned by
+// ExportUserDataRequest.Validate if the designated constraints aren't met.
+type ExportUserDataRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportUserDataRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportUserDataRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportUserDataRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportUserDataRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportUserDataRequestValidationError) ErrorName() string {
+	return "ExportUserDataRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExportUserDataRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportUserDataRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportUserDataRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportUserDataRequestValidationError{}
+
+// Validate checks the field values on AuditEvent with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AuditEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AuditEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AuditEventMultiError, or
+// nil if none found.
+func (m *AuditEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AuditEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Action
+
+	// no validation rules for ActorId
+
+	// no validation rules for TargetUserId
+
+	// no validation rules for IpAddress
+
+	// no validation rules for UserAgent
+
+	// no validation rules for Metadata
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AuditEventValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AuditEventValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AuditEventValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AuditEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// AuditEventMultiError is an error wrapping multiple validation errors
+// returned by AuditEvent.ValidateAll() if the designated constraints aren't met.
+type AuditEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AuditEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AuditEventMultiError) AllErrors() []error { return m }
+
+// AuditEventValidationError is the validation error returned by
+// AuditEvent.Validate if the designated constraints aren't met.
+type AuditEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditEventValidationError) ErrorName() string { return "AuditEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditEventValidationError{}
+
+// Validate checks the field values on ListAuditEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAuditEventsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAuditEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAuditEventsRequestMultiError, or nil if none found.
+func (m *ListAuditEventsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAuditEventsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetActorId() != "" {
+
+		if err := m._validateUuid(m.GetActorId()); err != nil {
+			err = ListAuditEventsRequestValidationError{
+				field:  "ActorId",
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetTargetUserId() != "" {
+
+		if err := m._validateUuid(m.GetTargetUserId()); err != nil {
+			err = ListAuditEventsRequestValidationError{
+				field:  "TargetUserId",
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetAction()) > 100 {
+		err := ListAuditEventsRequestValidationError{
+			field:  "Action",
+			reason: "value length must be at most 100 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetFrom()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListAuditEventsRequestValidationError{
+					field:  "From",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListAuditEventsRequestValidationError{
+					field:  "From",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFrom()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListAuditEventsRequestValidationError{
+				field:  "From",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTo()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListAuditEventsRequestValidationError{
+					field:  "To",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListAuditEventsRequestValidationError{
+					field:  "To",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTo()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListAuditEventsRequestValidationError{
+				field:  "To",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if val := m.GetPageSize(); val < 0 || val > 500 {
+		err := ListAuditEventsRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 500]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPage() < 0 {
+		err := ListAuditEventsRequestValidationError{
+			field:  "Page",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListAuditEventsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ListAuditEventsRequest) _validateUuid(uuid string) error {
+	if matched := _user_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ListAuditEventsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListAuditEventsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListAuditEventsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAuditEventsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAuditEventsRequestMultiError) AllErrors() []error { return m }
+
+// ListAuditEventsRequestValidationError is the validation error returned by
+// ListAuditEventsRequest.Validate if the designated constraints aren't met.
+type ListAuditEventsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAuditEventsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAuditEventsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAuditEventsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAuditEventsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAuditEventsRequestValidationError) ErrorName() string {
+	return "ListAuditEventsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAuditEventsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAuditEventsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAuditEventsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAuditEventsRequestValidationError{}
+
+// Validate checks the field values on ListAuditEventsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAuditEventsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAuditEventsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAuditEventsResponseMultiError, or nil if none found.
+func (m *ListAuditEventsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAuditEventsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetEvents() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAuditEventsResponseValidationError{
+						field:  fmt.Sprintf("Events[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAuditEventsResponseValidationError{
+						field:  fmt.Sprintf("Events[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAuditEventsResponseValidationError{
+					field:  fmt.Sprintf("Events[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListAuditEventsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAuditEventsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListAuditEventsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListAuditEventsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAuditEventsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAuditEventsResponseMultiError) AllErrors() []error { return m }
+
+// ListAuditEventsResponseValidationError is the validation error returned by
+// ListAuditEventsResponse.Validate if the designated constraints aren't met.
+type ListAuditEventsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAuditEventsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAuditEventsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAuditEventsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAuditEventsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAuditEventsResponseValidationError) ErrorName() string {
+	return "ListAuditEventsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAuditEventsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAuditEventsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAuditEventsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAuditEventsResponseValidationError{}
+
+// Validate checks the field values on LoginAttempt with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LoginAttempt) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LoginAttempt with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LoginAttemptMultiError, or
+// nil if none found.
+func (m *LoginAttempt) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LoginAttempt) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Method
+
+	// no validation rules for Succeeded
+
+	// no validation rules for FailureReason
+
+	// no validation rules for DeviceName
+
+	// no validation rules for UserAgent
+
+	// no validation rules for IpAddress
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LoginAttemptValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LoginAttemptValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LoginAttemptValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return LoginAttemptMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoginAttemptMultiError is an error wrapping multiple validation errors
+// returned by LoginAttempt.ValidateAll() if the designated constraints aren't met.
+type LoginAttemptMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoginAttemptMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoginAttemptMultiError) AllErrors() []error { return m }
+
+// LoginAttemptValidationError is the validation error returned by
+// LoginAttempt.Validate if the designated constraints aren't met.
+type LoginAttemptValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoginAttemptValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoginAttemptValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoginAttemptValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoginAttemptValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoginAttemptValidationError) ErrorName() string { return "LoginAttemptValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoginAttemptValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoginAttempt.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoginAttemptValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoginAttemptValidationError{}
+
+// Validate checks the field values on GetMyLoginHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetMyLoginHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetMyLoginHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetMyLoginHistoryRequestMultiError, or nil if none found.
+func (m *GetMyLoginHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetMyLoginHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := GetMyLoginHistoryRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPage() < 0 {
+		err := GetMyLoginHistoryRequestValidationError{
+			field:  "Page",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetMyLoginHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetMyLoginHistoryRequestMultiError is an error wrapping multiple validation
+// errors returned by GetMyLoginHistoryRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetMyLoginHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetMyLoginHistoryRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetMyLoginHistoryRequestMultiError) AllErrors() []error { return m }
+
+// GetMyLoginHistoryRequestValidationError is the validation error returned by
+// GetMyLoginHistoryRequest.Validate if the designated constraints aren't met.
+type GetMyLoginHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetMyLoginHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetMyLoginHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetMyLoginHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetMyLoginHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetMyLoginHistoryRequestValidationError) ErrorName() string {
+	return "GetMyLoginHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetMyLoginHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetMyLoginHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetMyLoginHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetMyLoginHistoryRequestValidationError{}
+
+// Validate checks the field values on GetMyLoginHistoryResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetMyLoginHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetMyLoginHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetMyLoginHistoryResponseMultiError, or nil if none found.
+func (m *GetMyLoginHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetMyLoginHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetAttempts() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetMyLoginHistoryResponseValidationError{
+						field:  fmt.Sprintf("Attempts[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetMyLoginHistoryResponseValidationError{
+						field:  fmt.Sprintf("Attempts[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetMyLoginHistoryResponseValidationError{
+					field:  fmt.Sprintf("Attempts[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return GetMyLoginHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetMyLoginHistoryResponseMultiError is an error wrapping multiple validation
+// errors returned by GetMyLoginHistoryResponse.ValidateAll() if the
+// designated constraints aren't met.
+type GetMyLoginHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetMyLoginHistoryResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetMyLoginHistoryResponseMultiError) AllErrors() []error { return m }
+
+// GetMyLoginHistoryResponseValidationError is the validation error returned by
+// GetMyLoginHistoryResponse.Validate if the designated constraints aren't met.
+type GetMyLoginHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetMyLoginHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetMyLoginHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetMyLoginHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetMyLoginHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetMyLoginHistoryResponseValidationError) ErrorName() string {
+	return "GetMyLoginHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetMyLoginHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetMyLoginHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetMyLoginHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetMyLoginHistoryResponseValidationError{}
+
 // Validate checks the field values on ChangePasswordRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -1506,10 +7028,10 @@ func (m *ChangePasswordRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if l := utf8.RuneCountInString(m.GetPassword()); l < 6 || l > 8 {
+	if l := utf8.RuneCountInString(m.GetPassword()); l < 1 || l > 128 {
 		err := ChangePasswordRequestValidationError{
 			field:  "Password",
-			reason: "value length must be between 6 and 8 runes, inclusive",
+			reason: "value length must be between 1 and 128 runes, inclusive",
 		}
 		if !all {
 			return err
@@ -9,6 +9,7 @@ import (
 	context "context"
 	errors "errors"
 	user "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	http "net/http"
 	strings "strings"
@@ -36,6 +37,12 @@ const (
 const (
 	// UserServiceGetUsersProcedure is the fully-qualified name of the UserService's GetUsers RPC.
 	UserServiceGetUsersProcedure = "/proto.UserService/GetUsers"
+	// UserServiceGetUsersByIdsProcedure is the fully-qualified name of the UserService's GetUsersByIds
+	// RPC.
+	UserServiceGetUsersByIdsProcedure = "/proto.UserService/GetUsersByIds"
+	// UserServiceGetUserByEmailProcedure is the fully-qualified name of the UserService's
+	// GetUserByEmail RPC.
+	UserServiceGetUserByEmailProcedure = "/proto.UserService/GetUserByEmail"
 	// UserServiceLoginProcedure is the fully-qualified name of the UserService's Login RPC.
 	UserServiceLoginProcedure = "/proto.UserService/Login"
 	// UserServiceRegisterProcedure is the fully-qualified name of the UserService's Register RPC.
@@ -53,25 +60,138 @@ const (
 	// UserServiceChangePasswordProcedure is the fully-qualified name of the UserService's
 	// ChangePassword RPC.
 	UserServiceChangePasswordProcedure = "/proto.UserService/ChangePassword"
+	// UserServiceSendPhoneVerificationOtpProcedure is the fully-qualified name of the UserService's
+	// SendPhoneVerificationOtp RPC.
+	UserServiceSendPhoneVerificationOtpProcedure = "/proto.UserService/SendPhoneVerificationOtp"
+	// UserServiceVerifyPhoneProcedure is the fully-qualified name of the UserService's VerifyPhone RPC.
+	UserServiceVerifyPhoneProcedure = "/proto.UserService/VerifyPhone"
+	// UserServiceRequestPhoneLoginOtpProcedure is the fully-qualified name of the UserService's
+	// RequestPhoneLoginOtp RPC.
+	UserServiceRequestPhoneLoginOtpProcedure = "/proto.UserService/RequestPhoneLoginOtp"
+	// UserServiceLoginWithPhoneOtpProcedure is the fully-qualified name of the UserService's
+	// LoginWithPhoneOtp RPC.
+	UserServiceLoginWithPhoneOtpProcedure = "/proto.UserService/LoginWithPhoneOtp"
+	// UserServiceLoginWithGoogleProcedure is the fully-qualified name of the UserService's
+	// LoginWithGoogle RPC.
+	UserServiceLoginWithGoogleProcedure = "/proto.UserService/LoginWithGoogle"
+	// UserServiceRequestEmailChangeProcedure is the fully-qualified name of the UserService's
+	// RequestEmailChange RPC.
+	UserServiceRequestEmailChangeProcedure = "/proto.UserService/RequestEmailChange"
+	// UserServiceConfirmEmailChangeProcedure is the fully-qualified name of the UserService's
+	// ConfirmEmailChange RPC.
+	UserServiceConfirmEmailChangeProcedure = "/proto.UserService/ConfirmEmailChange"
+	// UserServiceEnrollMfaProcedure is the fully-qualified name of the UserService's EnrollMfa RPC.
+	UserServiceEnrollMfaProcedure = "/proto.UserService/EnrollMfa"
+	// UserServiceConfirmMfaProcedure is the fully-qualified name of the UserService's ConfirmMfa RPC.
+	UserServiceConfirmMfaProcedure = "/proto.UserService/ConfirmMfa"
+	// UserServiceVerifyMfaProcedure is the fully-qualified name of the UserService's VerifyMfa RPC.
+	UserServiceVerifyMfaProcedure = "/proto.UserService/VerifyMfa"
+	// UserServiceRefreshTokenProcedure is the fully-qualified name of the UserService's RefreshToken
+	// RPC.
+	UserServiceRefreshTokenProcedure = "/proto.UserService/RefreshToken"
+	// UserServiceIntrospectTokenProcedure is the fully-qualified name of the UserService's
+	// IntrospectToken RPC.
+	UserServiceIntrospectTokenProcedure = "/proto.UserService/IntrospectToken"
+	// UserServiceListSessionsProcedure is the fully-qualified name of the UserService's ListSessions
+	// RPC.
+	UserServiceListSessionsProcedure = "/proto.UserService/ListSessions"
+	// UserServiceRevokeSessionProcedure is the fully-qualified name of the UserService's RevokeSession
+	// RPC.
+	UserServiceRevokeSessionProcedure = "/proto.UserService/RevokeSession"
+	// UserServiceDeactivateUserProcedure is the fully-qualified name of the UserService's
+	// DeactivateUser RPC.
+	UserServiceDeactivateUserProcedure = "/proto.UserService/DeactivateUser"
+	// UserServiceReactivateUserProcedure is the fully-qualified name of the UserService's
+	// ReactivateUser RPC.
+	UserServiceReactivateUserProcedure = "/proto.UserService/ReactivateUser"
+	// UserServiceSetUserRolesProcedure is the fully-qualified name of the UserService's SetUserRoles
+	// RPC.
+	UserServiceSetUserRolesProcedure = "/proto.UserService/SetUserRoles"
+	// UserServiceDeleteAccountProcedure is the fully-qualified name of the UserService's DeleteAccount
+	// RPC.
+	UserServiceDeleteAccountProcedure = "/proto.UserService/DeleteAccount"
+	// UserServiceExportMyDataProcedure is the fully-qualified name of the UserService's ExportMyData
+	// RPC.
+	UserServiceExportMyDataProcedure = "/proto.UserService/ExportMyData"
+	// UserServiceExportUserDataProcedure is the fully-qualified name of the UserService's
+	// ExportUserData RPC.
+	UserServiceExportUserDataProcedure = "/proto.UserService/ExportUserData"
+	// UserServiceListAuditEventsProcedure is the fully-qualified name of the UserService's
+	// ListAuditEvents RPC.
+	UserServiceListAuditEventsProcedure = "/proto.UserService/ListAuditEvents"
+	// UserServiceGetMyLoginHistoryProcedure is the fully-qualified name of the UserService's
+	// GetMyLoginHistory RPC.
+	UserServiceGetMyLoginHistoryProcedure = "/proto.UserService/GetMyLoginHistory"
+	// UserServiceCreateOAuthClientProcedure is the fully-qualified name of the UserService's
+	// CreateOAuthClient RPC.
+	UserServiceCreateOAuthClientProcedure = "/proto.UserService/CreateOAuthClient"
+	// UserServiceCreateServiceClientProcedure is the fully-qualified name of the UserService's
+	// CreateServiceClient RPC.
+	UserServiceCreateServiceClientProcedure = "/proto.UserService/CreateServiceClient"
+	// UserServiceListServiceClientsProcedure is the fully-qualified name of the UserService's
+	// ListServiceClients RPC.
+	UserServiceListServiceClientsProcedure = "/proto.UserService/ListServiceClients"
+	// UserServiceRevokeServiceClientProcedure is the fully-qualified name of the UserService's
+	// RevokeServiceClient RPC.
+	UserServiceRevokeServiceClientProcedure = "/proto.UserService/RevokeServiceClient"
+	// UserServiceImpersonateUserProcedure is the fully-qualified name of the UserService's
+	// ImpersonateUser RPC.
+	UserServiceImpersonateUserProcedure = "/proto.UserService/ImpersonateUser"
+	// UserServiceStopImpersonationProcedure is the fully-qualified name of the UserService's
+	// StopImpersonation RPC.
+	UserServiceStopImpersonationProcedure = "/proto.UserService/StopImpersonation"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	userServiceServiceDescriptor              = user.File_proto_user_user_proto.Services().ByName("UserService")
-	userServiceGetUsersMethodDescriptor       = userServiceServiceDescriptor.Methods().ByName("GetUsers")
-	userServiceLoginMethodDescriptor          = userServiceServiceDescriptor.Methods().ByName("Login")
-	userServiceRegisterMethodDescriptor       = userServiceServiceDescriptor.Methods().ByName("Register")
-	userServiceCreateRoleMethodDescriptor     = userServiceServiceDescriptor.Methods().ByName("CreateRole")
-	userServiceGetRoleMethodDescriptor        = userServiceServiceDescriptor.Methods().ByName("GetRole")
-	userServiceVerifyOtpMethodDescriptor      = userServiceServiceDescriptor.Methods().ByName("VerifyOtp")
-	userServiceResendOtpMethodDescriptor      = userServiceServiceDescriptor.Methods().ByName("ResendOtp")
-	userServiceGetOwnDataMethodDescriptor     = userServiceServiceDescriptor.Methods().ByName("GetOwnData")
-	userServiceChangePasswordMethodDescriptor = userServiceServiceDescriptor.Methods().ByName("ChangePassword")
+	userServiceServiceDescriptor                        = user.File_proto_user_user_proto.Services().ByName("UserService")
+	userServiceGetUsersMethodDescriptor                 = userServiceServiceDescriptor.Methods().ByName("GetUsers")
+	userServiceGetUsersByIdsMethodDescriptor            = userServiceServiceDescriptor.Methods().ByName("GetUsersByIds")
+	userServiceGetUserByEmailMethodDescriptor           = userServiceServiceDescriptor.Methods().ByName("GetUserByEmail")
+	userServiceLoginMethodDescriptor                    = userServiceServiceDescriptor.Methods().ByName("Login")
+	userServiceRegisterMethodDescriptor                 = userServiceServiceDescriptor.Methods().ByName("Register")
+	userServiceCreateRoleMethodDescriptor               = userServiceServiceDescriptor.Methods().ByName("CreateRole")
+	userServiceGetRoleMethodDescriptor                  = userServiceServiceDescriptor.Methods().ByName("GetRole")
+	userServiceVerifyOtpMethodDescriptor                = userServiceServiceDescriptor.Methods().ByName("VerifyOtp")
+	userServiceResendOtpMethodDescriptor                = userServiceServiceDescriptor.Methods().ByName("ResendOtp")
+	userServiceGetOwnDataMethodDescriptor               = userServiceServiceDescriptor.Methods().ByName("GetOwnData")
+	userServiceChangePasswordMethodDescriptor           = userServiceServiceDescriptor.Methods().ByName("ChangePassword")
+	userServiceSendPhoneVerificationOtpMethodDescriptor = userServiceServiceDescriptor.Methods().ByName("SendPhoneVerificationOtp")
+	userServiceVerifyPhoneMethodDescriptor              = userServiceServiceDescriptor.Methods().ByName("VerifyPhone")
+	userServiceRequestPhoneLoginOtpMethodDescriptor     = userServiceServiceDescriptor.Methods().ByName("RequestPhoneLoginOtp")
+	userServiceLoginWithPhoneOtpMethodDescriptor        = userServiceServiceDescriptor.Methods().ByName("LoginWithPhoneOtp")
+	userServiceLoginWithGoogleMethodDescriptor          = userServiceServiceDescriptor.Methods().ByName("LoginWithGoogle")
+	userServiceRequestEmailChangeMethodDescriptor       = userServiceServiceDescriptor.Methods().ByName("RequestEmailChange")
+	userServiceConfirmEmailChangeMethodDescriptor       = userServiceServiceDescriptor.Methods().ByName("ConfirmEmailChange")
+	userServiceEnrollMfaMethodDescriptor                = userServiceServiceDescriptor.Methods().ByName("EnrollMfa")
+	userServiceConfirmMfaMethodDescriptor               = userServiceServiceDescriptor.Methods().ByName("ConfirmMfa")
+	userServiceVerifyMfaMethodDescriptor                = userServiceServiceDescriptor.Methods().ByName("VerifyMfa")
+	userServiceRefreshTokenMethodDescriptor             = userServiceServiceDescriptor.Methods().ByName("RefreshToken")
+	userServiceIntrospectTokenMethodDescriptor          = userServiceServiceDescriptor.Methods().ByName("IntrospectToken")
+	userServiceListSessionsMethodDescriptor             = userServiceServiceDescriptor.Methods().ByName("ListSessions")
+	userServiceRevokeSessionMethodDescriptor            = userServiceServiceDescriptor.Methods().ByName("RevokeSession")
+	userServiceDeactivateUserMethodDescriptor           = userServiceServiceDescriptor.Methods().ByName("DeactivateUser")
+	userServiceReactivateUserMethodDescriptor           = userServiceServiceDescriptor.Methods().ByName("ReactivateUser")
+	userServiceSetUserRolesMethodDescriptor             = userServiceServiceDescriptor.Methods().ByName("SetUserRoles")
+	userServiceDeleteAccountMethodDescriptor            = userServiceServiceDescriptor.Methods().ByName("DeleteAccount")
+	userServiceExportMyDataMethodDescriptor             = userServiceServiceDescriptor.Methods().ByName("ExportMyData")
+	userServiceExportUserDataMethodDescriptor           = userServiceServiceDescriptor.Methods().ByName("ExportUserData")
+	userServiceListAuditEventsMethodDescriptor          = userServiceServiceDescriptor.Methods().ByName("ListAuditEvents")
+	userServiceGetMyLoginHistoryMethodDescriptor        = userServiceServiceDescriptor.Methods().ByName("GetMyLoginHistory")
+	userServiceCreateOAuthClientMethodDescriptor        = userServiceServiceDescriptor.Methods().ByName("CreateOAuthClient")
+	userServiceCreateServiceClientMethodDescriptor      = userServiceServiceDescriptor.Methods().ByName("CreateServiceClient")
+	userServiceListServiceClientsMethodDescriptor       = userServiceServiceDescriptor.Methods().ByName("ListServiceClients")
+	userServiceRevokeServiceClientMethodDescriptor      = userServiceServiceDescriptor.Methods().ByName("RevokeServiceClient")
+	userServiceImpersonateUserMethodDescriptor          = userServiceServiceDescriptor.Methods().ByName("ImpersonateUser")
+	userServiceStopImpersonationMethodDescriptor        = userServiceServiceDescriptor.Methods().ByName("StopImpersonation")
 )
 
 // UserServiceClient is a client for the proto.UserService service.
 type UserServiceClient interface {
 	GetUsers(context.Context, *connect.Request[user.GetUsersRequest]) (*connect.Response[user.GetUsersResponse], error)
+	// GetUsersByIds and GetUserByEmail are internal lookups for the other services, served on grpc only
+	GetUsersByIds(context.Context, *connect.Request[user.GetUsersByIdsRequest]) (*connect.Response[user.GetUsersByIdsResponse], error)
+	GetUserByEmail(context.Context, *connect.Request[user.GetUserByEmailRequest]) (*connect.Response[user.GetUserByEmailResponse], error)
 	Login(context.Context, *connect.Request[user.UserLoginRequest]) (*connect.Response[user.SuccessResponse], error)
 	Register(context.Context, *connect.Request[user.UserRegisterRequest]) (*connect.Response[user.SuccessResponse], error)
 	CreateRole(context.Context, *connect.Request[user.Role]) (*connect.Response[user.SuccessResponse], error)
@@ -80,6 +200,43 @@ type UserServiceClient interface {
 	ResendOtp(context.Context, *connect.Request[user.ResendOTPRequest]) (*connect.Response[user.SuccessResponse], error)
 	GetOwnData(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error)
 	ChangePassword(context.Context, *connect.Request[user.ChangePasswordRequest]) (*connect.Response[user.SuccessResponse], error)
+	SendPhoneVerificationOtp(context.Context, *connect.Request[user.SendPhoneVerificationOtpRequest]) (*connect.Response[user.SuccessResponse], error)
+	VerifyPhone(context.Context, *connect.Request[user.VerifyPhoneRequest]) (*connect.Response[user.SuccessResponse], error)
+	RequestPhoneLoginOtp(context.Context, *connect.Request[user.RequestPhoneLoginOtpRequest]) (*connect.Response[user.SuccessResponse], error)
+	LoginWithPhoneOtp(context.Context, *connect.Request[user.LoginWithPhoneOtpRequest]) (*connect.Response[user.SuccessResponse], error)
+	LoginWithGoogle(context.Context, *connect.Request[user.LoginWithGoogleRequest]) (*connect.Response[user.SuccessResponse], error)
+	RequestEmailChange(context.Context, *connect.Request[user.RequestEmailChangeRequest]) (*connect.Response[user.SuccessResponse], error)
+	ConfirmEmailChange(context.Context, *connect.Request[user.ConfirmEmailChangeRequest]) (*connect.Response[user.SuccessResponse], error)
+	EnrollMfa(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error)
+	ConfirmMfa(context.Context, *connect.Request[user.ConfirmMfaRequest]) (*connect.Response[user.SuccessResponse], error)
+	VerifyMfa(context.Context, *connect.Request[user.VerifyMfaRequest]) (*connect.Response[user.SuccessResponse], error)
+	RefreshToken(context.Context, *connect.Request[user.RefreshTokenRequest]) (*connect.Response[user.SuccessResponse], error)
+	// IntrospectToken lets the other services check an access token, including the revocation
+	// of its session and the deactivation of its user
+	IntrospectToken(context.Context, *connect.Request[user.IntrospectTokenRequest]) (*connect.Response[user.IntrospectTokenResponse], error)
+	ListSessions(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.ListSessionsResponse], error)
+	RevokeSession(context.Context, *connect.Request[user.RevokeSessionRequest]) (*connect.Response[user.SuccessResponse], error)
+	DeactivateUser(context.Context, *connect.Request[user.DeactivateUserRequest]) (*connect.Response[user.SuccessResponse], error)
+	ReactivateUser(context.Context, *connect.Request[user.ReactivateUserRequest]) (*connect.Response[user.SuccessResponse], error)
+	SetUserRoles(context.Context, *connect.Request[user.SetUserRolesRequest]) (*connect.Response[user.SuccessResponse], error)
+	DeleteAccount(context.Context, *connect.Request[user.DeleteAccountRequest]) (*connect.Response[user.SuccessResponse], error)
+	// ExportMyData streams a json archive of the data held about the user
+	ExportMyData(context.Context, *connect.Request[emptypb.Empty]) (*connect.ServerStreamForClient[httpbody.HttpBody], error)
+	ExportUserData(context.Context, *connect.Request[user.ExportUserDataRequest]) (*connect.ServerStreamForClient[httpbody.HttpBody], error)
+	// ListAuditEvents returns the audit trail matching the filter, admins only
+	ListAuditEvents(context.Context, *connect.Request[user.ListAuditEventsRequest]) (*connect.Response[user.ListAuditEventsResponse], error)
+	// GetMyLoginHistory returns the recent sign-ins and failed attempts on the account of the user
+	GetMyLoginHistory(context.Context, *connect.Request[user.GetMyLoginHistoryRequest]) (*connect.Response[user.GetMyLoginHistoryResponse], error)
+	// CreateOAuthClient registers an app signing its users in through the openid connect provider, admins only
+	CreateOAuthClient(context.Context, *connect.Request[user.CreateOAuthClientRequest]) (*connect.Response[user.CreateOAuthClientResponse], error)
+	// service clients call the protected rpcs with an api key instead of a user token, admins only
+	CreateServiceClient(context.Context, *connect.Request[user.CreateServiceClientRequest]) (*connect.Response[user.CreateServiceClientResponse], error)
+	ListServiceClients(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.ListServiceClientsResponse], error)
+	RevokeServiceClient(context.Context, *connect.Request[user.RevokeServiceClientRequest]) (*connect.Response[user.SuccessResponse], error)
+	// ImpersonateUser lets an admin see the app as a user for a few minutes, admins only
+	ImpersonateUser(context.Context, *connect.Request[user.ImpersonateUserRequest]) (*connect.Response[user.ImpersonateUserResponse], error)
+	// StopImpersonation ends the impersonation the token used for the request was issued for
+	StopImpersonation(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error)
 }
 
 // NewUserServiceClient constructs a client for the proto.UserService service. By default, it uses
@@ -98,6 +255,18 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceGetUsersMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getUsersByIds: connect.NewClient[user.GetUsersByIdsRequest, user.GetUsersByIdsResponse](
+			httpClient,
+			baseURL+UserServiceGetUsersByIdsProcedure,
+			connect.WithSchema(userServiceGetUsersByIdsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getUserByEmail: connect.NewClient[user.GetUserByEmailRequest, user.GetUserByEmailResponse](
+			httpClient,
+			baseURL+UserServiceGetUserByEmailProcedure,
+			connect.WithSchema(userServiceGetUserByEmailMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		login: connect.NewClient[user.UserLoginRequest, user.SuccessResponse](
 			httpClient,
 			baseURL+UserServiceLoginProcedure,
@@ -146,20 +315,218 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceChangePasswordMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		sendPhoneVerificationOtp: connect.NewClient[user.SendPhoneVerificationOtpRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceSendPhoneVerificationOtpProcedure,
+			connect.WithSchema(userServiceSendPhoneVerificationOtpMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		verifyPhone: connect.NewClient[user.VerifyPhoneRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceVerifyPhoneProcedure,
+			connect.WithSchema(userServiceVerifyPhoneMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		requestPhoneLoginOtp: connect.NewClient[user.RequestPhoneLoginOtpRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceRequestPhoneLoginOtpProcedure,
+			connect.WithSchema(userServiceRequestPhoneLoginOtpMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		loginWithPhoneOtp: connect.NewClient[user.LoginWithPhoneOtpRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceLoginWithPhoneOtpProcedure,
+			connect.WithSchema(userServiceLoginWithPhoneOtpMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		loginWithGoogle: connect.NewClient[user.LoginWithGoogleRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceLoginWithGoogleProcedure,
+			connect.WithSchema(userServiceLoginWithGoogleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		requestEmailChange: connect.NewClient[user.RequestEmailChangeRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceRequestEmailChangeProcedure,
+			connect.WithSchema(userServiceRequestEmailChangeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		confirmEmailChange: connect.NewClient[user.ConfirmEmailChangeRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceConfirmEmailChangeProcedure,
+			connect.WithSchema(userServiceConfirmEmailChangeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		enrollMfa: connect.NewClient[emptypb.Empty, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceEnrollMfaProcedure,
+			connect.WithSchema(userServiceEnrollMfaMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		confirmMfa: connect.NewClient[user.ConfirmMfaRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceConfirmMfaProcedure,
+			connect.WithSchema(userServiceConfirmMfaMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		verifyMfa: connect.NewClient[user.VerifyMfaRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceVerifyMfaProcedure,
+			connect.WithSchema(userServiceVerifyMfaMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		refreshToken: connect.NewClient[user.RefreshTokenRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceRefreshTokenProcedure,
+			connect.WithSchema(userServiceRefreshTokenMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		introspectToken: connect.NewClient[user.IntrospectTokenRequest, user.IntrospectTokenResponse](
+			httpClient,
+			baseURL+UserServiceIntrospectTokenProcedure,
+			connect.WithSchema(userServiceIntrospectTokenMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listSessions: connect.NewClient[emptypb.Empty, user.ListSessionsResponse](
+			httpClient,
+			baseURL+UserServiceListSessionsProcedure,
+			connect.WithSchema(userServiceListSessionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		revokeSession: connect.NewClient[user.RevokeSessionRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceRevokeSessionProcedure,
+			connect.WithSchema(userServiceRevokeSessionMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deactivateUser: connect.NewClient[user.DeactivateUserRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceDeactivateUserProcedure,
+			connect.WithSchema(userServiceDeactivateUserMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		reactivateUser: connect.NewClient[user.ReactivateUserRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceReactivateUserProcedure,
+			connect.WithSchema(userServiceReactivateUserMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setUserRoles: connect.NewClient[user.SetUserRolesRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceSetUserRolesProcedure,
+			connect.WithSchema(userServiceSetUserRolesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAccount: connect.NewClient[user.DeleteAccountRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceDeleteAccountProcedure,
+			connect.WithSchema(userServiceDeleteAccountMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		exportMyData: connect.NewClient[emptypb.Empty, httpbody.HttpBody](
+			httpClient,
+			baseURL+UserServiceExportMyDataProcedure,
+			connect.WithSchema(userServiceExportMyDataMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		exportUserData: connect.NewClient[user.ExportUserDataRequest, httpbody.HttpBody](
+			httpClient,
+			baseURL+UserServiceExportUserDataProcedure,
+			connect.WithSchema(userServiceExportUserDataMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[user.ListAuditEventsRequest, user.ListAuditEventsResponse](
+			httpClient,
+			baseURL+UserServiceListAuditEventsProcedure,
+			connect.WithSchema(userServiceListAuditEventsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getMyLoginHistory: connect.NewClient[user.GetMyLoginHistoryRequest, user.GetMyLoginHistoryResponse](
+			httpClient,
+			baseURL+UserServiceGetMyLoginHistoryProcedure,
+			connect.WithSchema(userServiceGetMyLoginHistoryMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createOAuthClient: connect.NewClient[user.CreateOAuthClientRequest, user.CreateOAuthClientResponse](
+			httpClient,
+			baseURL+UserServiceCreateOAuthClientProcedure,
+			connect.WithSchema(userServiceCreateOAuthClientMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createServiceClient: connect.NewClient[user.CreateServiceClientRequest, user.CreateServiceClientResponse](
+			httpClient,
+			baseURL+UserServiceCreateServiceClientProcedure,
+			connect.WithSchema(userServiceCreateServiceClientMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listServiceClients: connect.NewClient[emptypb.Empty, user.ListServiceClientsResponse](
+			httpClient,
+			baseURL+UserServiceListServiceClientsProcedure,
+			connect.WithSchema(userServiceListServiceClientsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		revokeServiceClient: connect.NewClient[user.RevokeServiceClientRequest, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceRevokeServiceClientProcedure,
+			connect.WithSchema(userServiceRevokeServiceClientMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		impersonateUser: connect.NewClient[user.ImpersonateUserRequest, user.ImpersonateUserResponse](
+			httpClient,
+			baseURL+UserServiceImpersonateUserProcedure,
+			connect.WithSchema(userServiceImpersonateUserMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		stopImpersonation: connect.NewClient[emptypb.Empty, user.SuccessResponse](
+			httpClient,
+			baseURL+UserServiceStopImpersonationProcedure,
+			connect.WithSchema(userServiceStopImpersonationMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // userServiceClient implements UserServiceClient.
 type userServiceClient struct {
-	getUsers       *connect.Client[user.GetUsersRequest, user.GetUsersResponse]
-	login          *connect.Client[user.UserLoginRequest, user.SuccessResponse]
-	register       *connect.Client[user.UserRegisterRequest, user.SuccessResponse]
-	createRole     *connect.Client[user.Role, user.SuccessResponse]
-	getRole        *connect.Client[emptypb.Empty, user.SuccessResponse]
-	verifyOtp      *connect.Client[user.VerifyOTPRequest, user.SuccessResponse]
-	resendOtp      *connect.Client[user.ResendOTPRequest, user.SuccessResponse]
-	getOwnData     *connect.Client[emptypb.Empty, user.SuccessResponse]
-	changePassword *connect.Client[user.ChangePasswordRequest, user.SuccessResponse]
+	getUsers                 *connect.Client[user.GetUsersRequest, user.GetUsersResponse]
+	getUsersByIds            *connect.Client[user.GetUsersByIdsRequest, user.GetUsersByIdsResponse]
+	getUserByEmail           *connect.Client[user.GetUserByEmailRequest, user.GetUserByEmailResponse]
+	login                    *connect.Client[user.UserLoginRequest, user.SuccessResponse]
+	register                 *connect.Client[user.UserRegisterRequest, user.SuccessResponse]
+	createRole               *connect.Client[user.Role, user.SuccessResponse]
+	getRole                  *connect.Client[emptypb.Empty, user.SuccessResponse]
+	verifyOtp                *connect.Client[user.VerifyOTPRequest, user.SuccessResponse]
+	resendOtp                *connect.Client[user.ResendOTPRequest, user.SuccessResponse]
+	getOwnData               *connect.Client[emptypb.Empty, user.SuccessResponse]
+	changePassword           *connect.Client[user.ChangePasswordRequest, user.SuccessResponse]
+	sendPhoneVerificationOtp *connect.Client[user.SendPhoneVerificationOtpRequest, user.SuccessResponse]
+	verifyPhone              *connect.Client[user.VerifyPhoneRequest, user.SuccessResponse]
+	requestPhoneLoginOtp     *connect.Client[user.RequestPhoneLoginOtpRequest, user.SuccessResponse]
+	loginWithPhoneOtp        *connect.Client[user.LoginWithPhoneOtpRequest, user.SuccessResponse]
+	loginWithGoogle          *connect.Client[user.LoginWithGoogleRequest, user.SuccessResponse]
+	requestEmailChange       *connect.Client[user.RequestEmailChangeRequest, user.SuccessResponse]
+	confirmEmailChange       *connect.Client[user.ConfirmEmailChangeRequest, user.SuccessResponse]
+	enrollMfa                *connect.Client[emptypb.Empty, user.SuccessResponse]
+	confirmMfa               *connect.Client[user.ConfirmMfaRequest, user.SuccessResponse]
+	verifyMfa                *connect.Client[user.VerifyMfaRequest, user.SuccessResponse]
+	refreshToken             *connect.Client[user.RefreshTokenRequest, user.SuccessResponse]
+	introspectToken          *connect.Client[user.IntrospectTokenRequest, user.IntrospectTokenResponse]
+	listSessions             *connect.Client[emptypb.Empty, user.ListSessionsResponse]
+	revokeSession            *connect.Client[user.RevokeSessionRequest, user.SuccessResponse]
+	deactivateUser           *connect.Client[user.DeactivateUserRequest, user.SuccessResponse]
+	reactivateUser           *connect.Client[user.ReactivateUserRequest, user.SuccessResponse]
+	setUserRoles             *connect.Client[user.SetUserRolesRequest, user.SuccessResponse]
+	deleteAccount            *connect.Client[user.DeleteAccountRequest, user.SuccessResponse]
+	exportMyData             *connect.Client[emptypb.Empty, httpbody.HttpBody]
+	exportUserData           *connect.Client[user.ExportUserDataRequest, httpbody.HttpBody]
+	listAuditEvents          *connect.Client[user.ListAuditEventsRequest, user.ListAuditEventsResponse]
+	getMyLoginHistory        *connect.Client[user.GetMyLoginHistoryRequest, user.GetMyLoginHistoryResponse]
+	createOAuthClient        *connect.Client[user.CreateOAuthClientRequest, user.CreateOAuthClientResponse]
+	createServiceClient      *connect.Client[user.CreateServiceClientRequest, user.CreateServiceClientResponse]
+	listServiceClients       *connect.Client[emptypb.Empty, user.ListServiceClientsResponse]
+	revokeServiceClient      *connect.Client[user.RevokeServiceClientRequest, user.SuccessResponse]
+	impersonateUser          *connect.Client[user.ImpersonateUserRequest, user.ImpersonateUserResponse]
+	stopImpersonation        *connect.Client[emptypb.Empty, user.SuccessResponse]
 }
 
 // GetUsers calls proto.UserService.GetUsers.
@@ -167,6 +534,16 @@ func (c *userServiceClient) GetUsers(ctx context.Context, req *connect.Request[u
 	return c.getUsers.CallUnary(ctx, req)
 }
 
+// GetUsersByIds calls proto.UserService.GetUsersByIds.
+func (c *userServiceClient) GetUsersByIds(ctx context.Context, req *connect.Request[user.GetUsersByIdsRequest]) (*connect.Response[user.GetUsersByIdsResponse], error) {
+	return c.getUsersByIds.CallUnary(ctx, req)
+}
+
+// GetUserByEmail calls proto.UserService.GetUserByEmail.
+func (c *userServiceClient) GetUserByEmail(ctx context.Context, req *connect.Request[user.GetUserByEmailRequest]) (*connect.Response[user.GetUserByEmailResponse], error) {
+	return c.getUserByEmail.CallUnary(ctx, req)
+}
+
 // Login calls proto.UserService.Login.
 func (c *userServiceClient) Login(ctx context.Context, req *connect.Request[user.UserLoginRequest]) (*connect.Response[user.SuccessResponse], error) {
 	return c.login.CallUnary(ctx, req)
@@ -207,9 +584,152 @@ func (c *userServiceClient) ChangePassword(ctx context.Context, req *connect.Req
 	return c.changePassword.CallUnary(ctx, req)
 }
 
+// SendPhoneVerificationOtp calls proto.UserService.SendPhoneVerificationOtp.
+func (c *userServiceClient) SendPhoneVerificationOtp(ctx context.Context, req *connect.Request[user.SendPhoneVerificationOtpRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.sendPhoneVerificationOtp.CallUnary(ctx, req)
+}
+
+// VerifyPhone calls proto.UserService.VerifyPhone.
+func (c *userServiceClient) VerifyPhone(ctx context.Context, req *connect.Request[user.VerifyPhoneRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.verifyPhone.CallUnary(ctx, req)
+}
+
+// RequestPhoneLoginOtp calls proto.UserService.RequestPhoneLoginOtp.
+func (c *userServiceClient) RequestPhoneLoginOtp(ctx context.Context, req *connect.Request[user.RequestPhoneLoginOtpRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.requestPhoneLoginOtp.CallUnary(ctx, req)
+}
+
+// LoginWithPhoneOtp calls proto.UserService.LoginWithPhoneOtp.
+func (c *userServiceClient) LoginWithPhoneOtp(ctx context.Context, req *connect.Request[user.LoginWithPhoneOtpRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.loginWithPhoneOtp.CallUnary(ctx, req)
+}
+
+// LoginWithGoogle calls proto.UserService.LoginWithGoogle.
+func (c *userServiceClient) LoginWithGoogle(ctx context.Context, req *connect.Request[user.LoginWithGoogleRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.loginWithGoogle.CallUnary(ctx, req)
+}
+
+// RequestEmailChange calls proto.UserService.RequestEmailChange.
+func (c *userServiceClient) RequestEmailChange(ctx context.Context, req *connect.Request[user.RequestEmailChangeRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.requestEmailChange.CallUnary(ctx, req)
+}
+
+// ConfirmEmailChange calls proto.UserService.ConfirmEmailChange.
+func (c *userServiceClient) ConfirmEmailChange(ctx context.Context, req *connect.Request[user.ConfirmEmailChangeRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.confirmEmailChange.CallUnary(ctx, req)
+}
+
+// EnrollMfa calls proto.UserService.EnrollMfa.
+func (c *userServiceClient) EnrollMfa(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error) {
+	return c.enrollMfa.CallUnary(ctx, req)
+}
+
+// ConfirmMfa calls proto.UserService.ConfirmMfa.
+func (c *userServiceClient) ConfirmMfa(ctx context.Context, req *connect.Request[user.ConfirmMfaRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.confirmMfa.CallUnary(ctx, req)
+}
+
+// VerifyMfa calls proto.UserService.VerifyMfa.
+func (c *userServiceClient) VerifyMfa(ctx context.Context, req *connect.Request[user.VerifyMfaRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.verifyMfa.CallUnary(ctx, req)
+}
+
+// RefreshToken calls proto.UserService.RefreshToken.
+func (c *userServiceClient) RefreshToken(ctx context.Context, req *connect.Request[user.RefreshTokenRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.refreshToken.CallUnary(ctx, req)
+}
+
+// IntrospectToken calls proto.UserService.IntrospectToken.
+func (c *userServiceClient) IntrospectToken(ctx context.Context, req *connect.Request[user.IntrospectTokenRequest]) (*connect.Response[user.IntrospectTokenResponse], error) {
+	return c.introspectToken.CallUnary(ctx, req)
+}
+
+// ListSessions calls proto.UserService.ListSessions.
+func (c *userServiceClient) ListSessions(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[user.ListSessionsResponse], error) {
+	return c.listSessions.CallUnary(ctx, req)
+}
+
+// RevokeSession calls proto.UserService.RevokeSession.
+func (c *userServiceClient) RevokeSession(ctx context.Context, req *connect.Request[user.RevokeSessionRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.revokeSession.CallUnary(ctx, req)
+}
+
+// DeactivateUser calls proto.UserService.DeactivateUser.
+func (c *userServiceClient) DeactivateUser(ctx context.Context, req *connect.Request[user.DeactivateUserRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.deactivateUser.CallUnary(ctx, req)
+}
+
+// ReactivateUser calls proto.UserService.ReactivateUser.
+func (c *userServiceClient) ReactivateUser(ctx context.Context, req *connect.Request[user.ReactivateUserRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.reactivateUser.CallUnary(ctx, req)
+}
+
+// SetUserRoles calls proto.UserService.SetUserRoles.
+func (c *userServiceClient) SetUserRoles(ctx context.Context, req *connect.Request[user.SetUserRolesRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.setUserRoles.CallUnary(ctx, req)
+}
+
+// DeleteAccount calls proto.UserService.DeleteAccount.
+func (c *userServiceClient) DeleteAccount(ctx context.Context, req *connect.Request[user.DeleteAccountRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.deleteAccount.CallUnary(ctx, req)
+}
+
+// ExportMyData calls proto.UserService.ExportMyData.
+func (c *userServiceClient) ExportMyData(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.ServerStreamForClient[httpbody.HttpBody], error) {
+	return c.exportMyData.CallServerStream(ctx, req)
+}
+
+// ExportUserData calls proto.UserService.ExportUserData.
+func (c *userServiceClient) ExportUserData(ctx context.Context, req *connect.Request[user.ExportUserDataRequest]) (*connect.ServerStreamForClient[httpbody.HttpBody], error) {
+	return c.exportUserData.CallServerStream(ctx, req)
+}
+
+// ListAuditEvents calls proto.UserService.ListAuditEvents.
+func (c *userServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[user.ListAuditEventsRequest]) (*connect.Response[user.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
+// GetMyLoginHistory calls proto.UserService.GetMyLoginHistory.
+func (c *userServiceClient) GetMyLoginHistory(ctx context.Context, req *connect.Request[user.GetMyLoginHistoryRequest]) (*connect.Response[user.GetMyLoginHistoryResponse], error) {
+	return c.getMyLoginHistory.CallUnary(ctx, req)
+}
+
+// CreateOAuthClient calls proto.UserService.CreateOAuthClient.
+func (c *userServiceClient) CreateOAuthClient(ctx context.Context, req *connect.Request[user.CreateOAuthClientRequest]) (*connect.Response[user.CreateOAuthClientResponse], error) {
+	return c.createOAuthClient.CallUnary(ctx, req)
+}
+
+// CreateServiceClient calls proto.UserService.CreateServiceClient.
+func (c *userServiceClient) CreateServiceClient(ctx context.Context, req *connect.Request[user.CreateServiceClientRequest]) (*connect.Response[user.CreateServiceClientResponse], error) {
+	return c.createServiceClient.CallUnary(ctx, req)
+}
+
+// ListServiceClients calls proto.UserService.ListServiceClients.
+func (c *userServiceClient) ListServiceClients(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[user.ListServiceClientsResponse], error) {
+	return c.listServiceClients.CallUnary(ctx, req)
+}
+
+// RevokeServiceClient calls proto.UserService.RevokeServiceClient.
+func (c *userServiceClient) RevokeServiceClient(ctx context.Context, req *connect.Request[user.RevokeServiceClientRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return c.revokeServiceClient.CallUnary(ctx, req)
+}
+
+// ImpersonateUser calls proto.UserService.ImpersonateUser.
+func (c *userServiceClient) ImpersonateUser(ctx context.Context, req *connect.Request[user.ImpersonateUserRequest]) (*connect.Response[user.ImpersonateUserResponse], error) {
+	return c.impersonateUser.CallUnary(ctx, req)
+}
+
+// StopImpersonation calls proto.UserService.StopImpersonation.
+func (c *userServiceClient) StopImpersonation(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error) {
+	return c.stopImpersonation.CallUnary(ctx, req)
+}
+
 // UserServiceHandler is an implementation of the proto.UserService service.
 type UserServiceHandler interface {
 	GetUsers(context.Context, *connect.Request[user.GetUsersRequest]) (*connect.Response[user.GetUsersResponse], error)
+	// GetUsersByIds and GetUserByEmail are internal lookups for the other services, served on grpc only
+	GetUsersByIds(context.Context, *connect.Request[user.GetUsersByIdsRequest]) (*connect.Response[user.GetUsersByIdsResponse], error)
+	GetUserByEmail(context.Context, *connect.Request[user.GetUserByEmailRequest]) (*connect.Response[user.GetUserByEmailResponse], error)
 	Login(context.Context, *connect.Request[user.UserLoginRequest]) (*connect.Response[user.SuccessResponse], error)
 	Register(context.Context, *connect.Request[user.UserRegisterRequest]) (*connect.Response[user.SuccessResponse], error)
 	CreateRole(context.Context, *connect.Request[user.Role]) (*connect.Response[user.SuccessResponse], error)
@@ -218,6 +738,43 @@ type UserServiceHandler interface {
 	ResendOtp(context.Context, *connect.Request[user.ResendOTPRequest]) (*connect.Response[user.SuccessResponse], error)
 	GetOwnData(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error)
 	ChangePassword(context.Context, *connect.Request[user.ChangePasswordRequest]) (*connect.Response[user.SuccessResponse], error)
+	SendPhoneVerificationOtp(context.Context, *connect.Request[user.SendPhoneVerificationOtpRequest]) (*connect.Response[user.SuccessResponse], error)
+	VerifyPhone(context.Context, *connect.Request[user.VerifyPhoneRequest]) (*connect.Response[user.SuccessResponse], error)
+	RequestPhoneLoginOtp(context.Context, *connect.Request[user.RequestPhoneLoginOtpRequest]) (*connect.Response[user.SuccessResponse], error)
+	LoginWithPhoneOtp(context.Context, *connect.Request[user.LoginWithPhoneOtpRequest]) (*connect.Response[user.SuccessResponse], error)
+	LoginWithGoogle(context.Context, *connect.Request[user.LoginWithGoogleRequest]) (*connect.Response[user.SuccessResponse], error)
+	RequestEmailChange(context.Context, *connect.Request[user.RequestEmailChangeRequest]) (*connect.Response[user.SuccessResponse], error)
+	ConfirmEmailChange(context.Context, *connect.Request[user.ConfirmEmailChangeRequest]) (*connect.Response[user.SuccessResponse], error)
+	EnrollMfa(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error)
+	ConfirmMfa(context.Context, *connect.Request[user.ConfirmMfaRequest]) (*connect.Response[user.SuccessResponse], error)
+	VerifyMfa(context.Context, *connect.Request[user.VerifyMfaRequest]) (*connect.Response[user.SuccessResponse], error)
+	RefreshToken(context.Context, *connect.Request[user.RefreshTokenRequest]) (*connect.Response[user.SuccessResponse], error)
+	// IntrospectToken lets the other services check an access token, including the revocation
+	// of its session and the deactivation of its user
+	IntrospectToken(context.Context, *connect.Request[user.IntrospectTokenRequest]) (*connect.Response[user.IntrospectTokenResponse], error)
+	ListSessions(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.ListSessionsResponse], error)
+	RevokeSession(context.Context, *connect.Request[user.RevokeSessionRequest]) (*connect.Response[user.SuccessResponse], error)
+	DeactivateUser(context.Context, *connect.Request[user.DeactivateUserRequest]) (*connect.Response[user.SuccessResponse], error)
+	ReactivateUser(context.Context, *connect.Request[user.ReactivateUserRequest]) (*connect.Response[user.SuccessResponse], error)
+	SetUserRoles(context.Context, *connect.Request[user.SetUserRolesRequest]) (*connect.Response[user.SuccessResponse], error)
+	DeleteAccount(context.Context, *connect.Request[user.DeleteAccountRequest]) (*connect.Response[user.SuccessResponse], error)
+	// ExportMyData streams a json archive of the data held about the user
+	ExportMyData(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[httpbody.HttpBody]) error
+	ExportUserData(context.Context, *connect.Request[user.ExportUserDataRequest], *connect.ServerStream[httpbody.HttpBody]) error
+	// ListAuditEvents returns the audit trail matching the filter, admins only
+	ListAuditEvents(context.Context, *connect.Request[user.ListAuditEventsRequest]) (*connect.Response[user.ListAuditEventsResponse], error)
+	// GetMyLoginHistory returns the recent sign-ins and failed attempts on the account of the user
+	GetMyLoginHistory(context.Context, *connect.Request[user.GetMyLoginHistoryRequest]) (*connect.Response[user.GetMyLoginHistoryResponse], error)
+	// CreateOAuthClient registers an app signing its users in through the openid connect provider, admins only
+	CreateOAuthClient(context.Context, *connect.Request[user.CreateOAuthClientRequest]) (*connect.Response[user.CreateOAuthClientResponse], error)
+	// service clients call the protected rpcs with an api key instead of a user token, admins only
+	CreateServiceClient(context.Context, *connect.Request[user.CreateServiceClientRequest]) (*connect.Response[user.CreateServiceClientResponse], error)
+	ListServiceClients(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.ListServiceClientsResponse], error)
+	RevokeServiceClient(context.Context, *connect.Request[user.RevokeServiceClientRequest]) (*connect.Response[user.SuccessResponse], error)
+	// ImpersonateUser lets an admin see the app as a user for a few minutes, admins only
+	ImpersonateUser(context.Context, *connect.Request[user.ImpersonateUserRequest]) (*connect.Response[user.ImpersonateUserResponse], error)
+	// StopImpersonation ends the impersonation the token used for the request was issued for
+	StopImpersonation(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error)
 }
 
 // NewUserServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -232,6 +789,18 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceGetUsersMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceGetUsersByIdsHandler := connect.NewUnaryHandler(
+		UserServiceGetUsersByIdsProcedure,
+		svc.GetUsersByIds,
+		connect.WithSchema(userServiceGetUsersByIdsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceGetUserByEmailHandler := connect.NewUnaryHandler(
+		UserServiceGetUserByEmailProcedure,
+		svc.GetUserByEmail,
+		connect.WithSchema(userServiceGetUserByEmailMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	userServiceLoginHandler := connect.NewUnaryHandler(
 		UserServiceLoginProcedure,
 		svc.Login,
@@ -280,10 +849,182 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceChangePasswordMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceSendPhoneVerificationOtpHandler := connect.NewUnaryHandler(
+		UserServiceSendPhoneVerificationOtpProcedure,
+		svc.SendPhoneVerificationOtp,
+		connect.WithSchema(userServiceSendPhoneVerificationOtpMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceVerifyPhoneHandler := connect.NewUnaryHandler(
+		UserServiceVerifyPhoneProcedure,
+		svc.VerifyPhone,
+		connect.WithSchema(userServiceVerifyPhoneMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRequestPhoneLoginOtpHandler := connect.NewUnaryHandler(
+		UserServiceRequestPhoneLoginOtpProcedure,
+		svc.RequestPhoneLoginOtp,
+		connect.WithSchema(userServiceRequestPhoneLoginOtpMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceLoginWithPhoneOtpHandler := connect.NewUnaryHandler(
+		UserServiceLoginWithPhoneOtpProcedure,
+		svc.LoginWithPhoneOtp,
+		connect.WithSchema(userServiceLoginWithPhoneOtpMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceLoginWithGoogleHandler := connect.NewUnaryHandler(
+		UserServiceLoginWithGoogleProcedure,
+		svc.LoginWithGoogle,
+		connect.WithSchema(userServiceLoginWithGoogleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRequestEmailChangeHandler := connect.NewUnaryHandler(
+		UserServiceRequestEmailChangeProcedure,
+		svc.RequestEmailChange,
+		connect.WithSchema(userServiceRequestEmailChangeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceConfirmEmailChangeHandler := connect.NewUnaryHandler(
+		UserServiceConfirmEmailChangeProcedure,
+		svc.ConfirmEmailChange,
+		connect.WithSchema(userServiceConfirmEmailChangeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceEnrollMfaHandler := connect.NewUnaryHandler(
+		UserServiceEnrollMfaProcedure,
+		svc.EnrollMfa,
+		connect.WithSchema(userServiceEnrollMfaMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceConfirmMfaHandler := connect.NewUnaryHandler(
+		UserServiceConfirmMfaProcedure,
+		svc.ConfirmMfa,
+		connect.WithSchema(userServiceConfirmMfaMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceVerifyMfaHandler := connect.NewUnaryHandler(
+		UserServiceVerifyMfaProcedure,
+		svc.VerifyMfa,
+		connect.WithSchema(userServiceVerifyMfaMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRefreshTokenHandler := connect.NewUnaryHandler(
+		UserServiceRefreshTokenProcedure,
+		svc.RefreshToken,
+		connect.WithSchema(userServiceRefreshTokenMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceIntrospectTokenHandler := connect.NewUnaryHandler(
+		UserServiceIntrospectTokenProcedure,
+		svc.IntrospectToken,
+		connect.WithSchema(userServiceIntrospectTokenMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListSessionsHandler := connect.NewUnaryHandler(
+		UserServiceListSessionsProcedure,
+		svc.ListSessions,
+		connect.WithSchema(userServiceListSessionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRevokeSessionHandler := connect.NewUnaryHandler(
+		UserServiceRevokeSessionProcedure,
+		svc.RevokeSession,
+		connect.WithSchema(userServiceRevokeSessionMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDeactivateUserHandler := connect.NewUnaryHandler(
+		UserServiceDeactivateUserProcedure,
+		svc.DeactivateUser,
+		connect.WithSchema(userServiceDeactivateUserMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceReactivateUserHandler := connect.NewUnaryHandler(
+		UserServiceReactivateUserProcedure,
+		svc.ReactivateUser,
+		connect.WithSchema(userServiceReactivateUserMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceSetUserRolesHandler := connect.NewUnaryHandler(
+		UserServiceSetUserRolesProcedure,
+		svc.SetUserRoles,
+		connect.WithSchema(userServiceSetUserRolesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDeleteAccountHandler := connect.NewUnaryHandler(
+		UserServiceDeleteAccountProcedure,
+		svc.DeleteAccount,
+		connect.WithSchema(userServiceDeleteAccountMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceExportMyDataHandler := connect.NewServerStreamHandler(
+		UserServiceExportMyDataProcedure,
+		svc.ExportMyData,
+		connect.WithSchema(userServiceExportMyDataMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceExportUserDataHandler := connect.NewServerStreamHandler(
+		UserServiceExportUserDataProcedure,
+		svc.ExportUserData,
+		connect.WithSchema(userServiceExportUserDataMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		UserServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(userServiceListAuditEventsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceGetMyLoginHistoryHandler := connect.NewUnaryHandler(
+		UserServiceGetMyLoginHistoryProcedure,
+		svc.GetMyLoginHistory,
+		connect.WithSchema(userServiceGetMyLoginHistoryMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceCreateOAuthClientHandler := connect.NewUnaryHandler(
+		UserServiceCreateOAuthClientProcedure,
+		svc.CreateOAuthClient,
+		connect.WithSchema(userServiceCreateOAuthClientMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceCreateServiceClientHandler := connect.NewUnaryHandler(
+		UserServiceCreateServiceClientProcedure,
+		svc.CreateServiceClient,
+		connect.WithSchema(userServiceCreateServiceClientMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListServiceClientsHandler := connect.NewUnaryHandler(
+		UserServiceListServiceClientsProcedure,
+		svc.ListServiceClients,
+		connect.WithSchema(userServiceListServiceClientsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRevokeServiceClientHandler := connect.NewUnaryHandler(
+		UserServiceRevokeServiceClientProcedure,
+		svc.RevokeServiceClient,
+		connect.WithSchema(userServiceRevokeServiceClientMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceImpersonateUserHandler := connect.NewUnaryHandler(
+		UserServiceImpersonateUserProcedure,
+		svc.ImpersonateUser,
+		connect.WithSchema(userServiceImpersonateUserMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceStopImpersonationHandler := connect.NewUnaryHandler(
+		UserServiceStopImpersonationProcedure,
+		svc.StopImpersonation,
+		connect.WithSchema(userServiceStopImpersonationMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/proto.UserService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case UserServiceGetUsersProcedure:
 			userServiceGetUsersHandler.ServeHTTP(w, r)
+		case UserServiceGetUsersByIdsProcedure:
+			userServiceGetUsersByIdsHandler.ServeHTTP(w, r)
+		case UserServiceGetUserByEmailProcedure:
+			userServiceGetUserByEmailHandler.ServeHTTP(w, r)
 		case UserServiceLoginProcedure:
 			userServiceLoginHandler.ServeHTTP(w, r)
 		case UserServiceRegisterProcedure:
@@ -300,6 +1041,62 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 			userServiceGetOwnDataHandler.ServeHTTP(w, r)
 		case UserServiceChangePasswordProcedure:
 			userServiceChangePasswordHandler.ServeHTTP(w, r)
+		case UserServiceSendPhoneVerificationOtpProcedure:
+			userServiceSendPhoneVerificationOtpHandler.ServeHTTP(w, r)
+		case UserServiceVerifyPhoneProcedure:
+			userServiceVerifyPhoneHandler.ServeHTTP(w, r)
+		case UserServiceRequestPhoneLoginOtpProcedure:
+			userServiceRequestPhoneLoginOtpHandler.ServeHTTP(w, r)
+		case UserServiceLoginWithPhoneOtpProcedure:
+			userServiceLoginWithPhoneOtpHandler.ServeHTTP(w, r)
+		case UserServiceLoginWithGoogleProcedure:
+			userServiceLoginWithGoogleHandler.ServeHTTP(w, r)
+		case UserServiceRequestEmailChangeProcedure:
+			userServiceRequestEmailChangeHandler.ServeHTTP(w, r)
+		case UserServiceConfirmEmailChangeProcedure:
+			userServiceConfirmEmailChangeHandler.ServeHTTP(w, r)
+		case UserServiceEnrollMfaProcedure:
+			userServiceEnrollMfaHandler.ServeHTTP(w, r)
+		case UserServiceConfirmMfaProcedure:
+			userServiceConfirmMfaHandler.ServeHTTP(w, r)
+		case UserServiceVerifyMfaProcedure:
+			userServiceVerifyMfaHandler.ServeHTTP(w, r)
+		case UserServiceRefreshTokenProcedure:
+			userServiceRefreshTokenHandler.ServeHTTP(w, r)
+		case UserServiceIntrospectTokenProcedure:
+			userServiceIntrospectTokenHandler.ServeHTTP(w, r)
+		case UserServiceListSessionsProcedure:
+			userServiceListSessionsHandler.ServeHTTP(w, r)
+		case UserServiceRevokeSessionProcedure:
+			userServiceRevokeSessionHandler.ServeHTTP(w, r)
+		case UserServiceDeactivateUserProcedure:
+			userServiceDeactivateUserHandler.ServeHTTP(w, r)
+		case UserServiceReactivateUserProcedure:
+			userServiceReactivateUserHandler.ServeHTTP(w, r)
+		case UserServiceSetUserRolesProcedure:
+			userServiceSetUserRolesHandler.ServeHTTP(w, r)
+		case UserServiceDeleteAccountProcedure:
+			userServiceDeleteAccountHandler.ServeHTTP(w, r)
+		case UserServiceExportMyDataProcedure:
+			userServiceExportMyDataHandler.ServeHTTP(w, r)
+		case UserServiceExportUserDataProcedure:
+			userServiceExportUserDataHandler.ServeHTTP(w, r)
+		case UserServiceListAuditEventsProcedure:
+			userServiceListAuditEventsHandler.ServeHTTP(w, r)
+		case UserServiceGetMyLoginHistoryProcedure:
+			userServiceGetMyLoginHistoryHandler.ServeHTTP(w, r)
+		case UserServiceCreateOAuthClientProcedure:
+			userServiceCreateOAuthClientHandler.ServeHTTP(w, r)
+		case UserServiceCreateServiceClientProcedure:
+			userServiceCreateServiceClientHandler.ServeHTTP(w, r)
+		case UserServiceListServiceClientsProcedure:
+			userServiceListServiceClientsHandler.ServeHTTP(w, r)
+		case UserServiceRevokeServiceClientProcedure:
+			userServiceRevokeServiceClientHandler.ServeHTTP(w, r)
+		case UserServiceImpersonateUserProcedure:
+			userServiceImpersonateUserHandler.ServeHTTP(w, r)
+		case UserServiceStopImpersonationProcedure:
+			userServiceStopImpersonationHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -313,6 +1110,14 @@ func (UnimplementedUserServiceHandler) GetUsers(context.Context, *connect.Reques
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.GetUsers is not implemented"))
 }
 
+func (UnimplementedUserServiceHandler) GetUsersByIds(context.Context, *connect.Request[user.GetUsersByIdsRequest]) (*connect.Response[user.GetUsersByIdsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.GetUsersByIds is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) GetUserByEmail(context.Context, *connect.Request[user.GetUserByEmailRequest]) (*connect.Response[user.GetUserByEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.GetUserByEmail is not implemented"))
+}
+
 func (UnimplementedUserServiceHandler) Login(context.Context, *connect.Request[user.UserLoginRequest]) (*connect.Response[user.SuccessResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.Login is not implemented"))
 }
@@ -344,3 +1149,115 @@ func (UnimplementedUserServiceHandler) GetOwnData(context.Context, *connect.Requ
 func (UnimplementedUserServiceHandler) ChangePassword(context.Context, *connect.Request[user.ChangePasswordRequest]) (*connect.Response[user.SuccessResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ChangePassword is not implemented"))
 }
+
+func (UnimplementedUserServiceHandler) SendPhoneVerificationOtp(context.Context, *connect.Request[user.SendPhoneVerificationOtpRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.SendPhoneVerificationOtp is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) VerifyPhone(context.Context, *connect.Request[user.VerifyPhoneRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.VerifyPhone is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RequestPhoneLoginOtp(context.Context, *connect.Request[user.RequestPhoneLoginOtpRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.RequestPhoneLoginOtp is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) LoginWithPhoneOtp(context.Context, *connect.Request[user.LoginWithPhoneOtpRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.LoginWithPhoneOtp is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) LoginWithGoogle(context.Context, *connect.Request[user.LoginWithGoogleRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.LoginWithGoogle is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RequestEmailChange(context.Context, *connect.Request[user.RequestEmailChangeRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.RequestEmailChange is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ConfirmEmailChange(context.Context, *connect.Request[user.ConfirmEmailChangeRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ConfirmEmailChange is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) EnrollMfa(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.EnrollMfa is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ConfirmMfa(context.Context, *connect.Request[user.ConfirmMfaRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ConfirmMfa is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) VerifyMfa(context.Context, *connect.Request[user.VerifyMfaRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.VerifyMfa is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RefreshToken(context.Context, *connect.Request[user.RefreshTokenRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.RefreshToken is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) IntrospectToken(context.Context, *connect.Request[user.IntrospectTokenRequest]) (*connect.Response[user.IntrospectTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.IntrospectToken is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListSessions(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.ListSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ListSessions is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RevokeSession(context.Context, *connect.Request[user.RevokeSessionRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.RevokeSession is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) DeactivateUser(context.Context, *connect.Request[user.DeactivateUserRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.DeactivateUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ReactivateUser(context.Context, *connect.Request[user.ReactivateUserRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ReactivateUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) SetUserRoles(context.Context, *connect.Request[user.SetUserRolesRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.SetUserRoles is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) DeleteAccount(context.Context, *connect.Request[user.DeleteAccountRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.DeleteAccount is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ExportMyData(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[httpbody.HttpBody]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ExportMyData is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ExportUserData(context.Context, *connect.Request[user.ExportUserDataRequest], *connect.ServerStream[httpbody.HttpBody]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ExportUserData is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListAuditEvents(context.Context, *connect.Request[user.ListAuditEventsRequest]) (*connect.Response[user.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ListAuditEvents is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) GetMyLoginHistory(context.Context, *connect.Request[user.GetMyLoginHistoryRequest]) (*connect.Response[user.GetMyLoginHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.GetMyLoginHistory is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) CreateOAuthClient(context.Context, *connect.Request[user.CreateOAuthClientRequest]) (*connect.Response[user.CreateOAuthClientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.CreateOAuthClient is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) CreateServiceClient(context.Context, *connect.Request[user.CreateServiceClientRequest]) (*connect.Response[user.CreateServiceClientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.CreateServiceClient is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListServiceClients(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.ListServiceClientsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ListServiceClients is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RevokeServiceClient(context.Context, *connect.Request[user.RevokeServiceClientRequest]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.RevokeServiceClient is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ImpersonateUser(context.Context, *connect.Request[user.ImpersonateUserRequest]) (*connect.Response[user.ImpersonateUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.ImpersonateUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) StopImpersonation(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[user.SuccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.UserService.StopImpersonation is not implemented"))
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/datatypes"
+)
+
+// Audit actions
+const (
+	AuditUserRegistered    = "user.registered"
+	AuditLoginSucceeded    = "login.succeeded"
+	AuditLoginFailed       = "login.failed"
+	AuditUserLockedOut     = "user.locked_out"
+	AuditOtpSent           = "otp.sent"
+	AuditOtpVerified       = "otp.verified"
+	AuditPasswordChanged   = "password.changed"
+	AuditRoleCreated       = "role.created"
+	AuditProfileChanged    = "profile.changed"
+	AuditUserDeactivated   = "user.deactivated"
+	AuditUserReactivated   = "user.reactivated"
+	AuditRolesChanged      = "user.roles_changed"
+	AuditDeletionRequested = "user.deletion_requested"
+	AuditIdentityLinked    = "identity.linked"
+	AuditClientCreated     = "oauth_client.created"
+	AuditApiKeyCreated     = "service_client.created"
+	AuditApiKeyRevoked     = "service_client.revoked"
+	AuditImpersonateStart  = "impersonation.started"
+	AuditImpersonateStop   = "impersonation.stopped"
+)
+
+// AuditEvent records a security relevant action. Events are append only and outlive the
+// anonymization of the users they reference, only their metadata and device details are scrubbed then.
+type AuditEvent struct {
+	Id           uuid.UUID      `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	Action       string         `gorm:"type:varchar(100);not null;index"`
+	ActorId      uuid.NullUUID  `gorm:"type:uuid;null;index"`
+	TargetUserId uuid.NullUUID  `gorm:"type:uuid;null;index"`
+	IpAddress    string         `gorm:"type:varchar(64)"`
+	UserAgent    string         `gorm:"type:varchar(512)"`
+	Metadata     datatypes.JSON `gorm:"null"`
+	CreatedAt    time.Time      `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP;index"`
+}
+
+// AuditFilter narrows the audit events listed, zero fields match everything
+type AuditFilter struct {
+	ActorId      uuid.NullUUID
+	TargetUserId uuid.NullUUID
+	Action       string
+	From         time.Time
+	To           time.Time
+	// Page starts at 1
+	Page     int
+	PageSize int
+}
+
+func (e *AuditEvent) ToProto() *pb.AuditEvent {
+	event := &pb.AuditEvent{
+		Id:        e.Id.String(),
+		Action:    e.Action,
+		IpAddress: e.IpAddress,
+		UserAgent: e.UserAgent,
+		Metadata:  e.MetadataMap(),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.ActorId.Valid {
+		event.ActorId = e.ActorId.UUID.String()
+	}
+	if e.TargetUserId.Valid {
+		event.TargetUserId = e.TargetUserId.UUID.String()
+	}
+	return event
+}
+
+// MetadataMap returns the metadata of the event, nil when it has none or it cannot be read
+func (e *AuditEvent) MetadataMap() map[string]string {
+	if len(e.Metadata) == 0 {
+		return nil
+	}
+	var metadata map[string]string
+	if err := json.Unmarshal(e.Metadata, &metadata); err != nil {
+		return nil
+	}
+	return metadata
+}
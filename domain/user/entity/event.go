@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// Reasons of UserDeactivated events
+const (
+	DeactivationReasonAdmin             = "admin"
+	DeactivationReasonDeletionRequested = "deletion_requested"
+)
+
+// DomainEvent is a protobuf encoded event about a user, queued in the outbox and
+// published to the other services once the change it announces is committed
+type DomainEvent struct {
+	Id uuid.UUID
+	// Type is the full name of the protobuf message in Payload
+	Type       string
+	UserId     uuid.UUID
+	Payload    []byte
+	OccurredAt time.Time
+}
+
+func NewDomainEvent(userId uuid.UUID, event proto.Message) (*DomainEvent, error) {
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &DomainEvent{
+		Id:         uuid.New(),
+		Type:       string(event.ProtoReflect().Descriptor().FullName()),
+		UserId:     userId,
+		Payload:    payload,
+		OccurredAt: time.Now().UTC(),
+	}, nil
+}
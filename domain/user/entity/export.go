@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataExport is the archive of the personal data held about a user, returned on
+// data subject access requests. Credentials and secrets are never part of it.
+type DataExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Profile    ProfileExport   `json:"profile"`
+	Roles      []string        `json:"roles"`
+	Sessions   []SessionExport `json:"sessions"`
+	// LoginHistory lists the sign-ins and failed attempts on the account
+	LoginHistory []LoginAttemptExport `json:"login_history"`
+	// Activity is the audit trail of the actions on the account
+	Activity []AuditEventExport `json:"activity"`
+}
+
+type ProfileExport struct {
+	Id                  uuid.UUID  `json:"id"`
+	Username            string     `json:"username"`
+	Email               string     `json:"email"`
+	PhoneNumber         string     `json:"phone_number"`
+	Name                string     `json:"name"`
+	Address             string     `json:"address"`
+	AvatarImageId       *uuid.UUID `json:"avatar_image_id,omitempty"`
+	IsActive            bool       `json:"is_active"`
+	IsVerified          bool       `json:"is_verified"`
+	IsPhoneVerified     bool       `json:"is_phone_verified"`
+	IsMfaEnabled        bool       `json:"is_mfa_enabled"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at,omitempty"`
+}
+
+type SessionExport struct {
+	Id         uuid.UUID  `json:"id"`
+	DeviceName string     `json:"device_name"`
+	UserAgent  string     `json:"user_agent"`
+	IpAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type LoginAttemptExport struct {
+	Method        string    `json:"method"`
+	Succeeded     bool      `json:"succeeded"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	DeviceName    string    `json:"device_name"`
+	UserAgent     string    `json:"user_agent"`
+	IpAddress     string    `json:"ip_address"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type AuditEventExport struct {
+	Action    string            `json:"action"`
+	IpAddress string            `json:"ip_address"`
+	UserAgent string            `json:"user_agent"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func (u *User) ToProfileExport() ProfileExport {
+	profile := ProfileExport{
+		Id:                  u.Id,
+		Username:            u.Username,
+		Email:               u.Email,
+		PhoneNumber:         u.PhoneNumber,
+		Name:                u.Name,
+		Address:             u.Address,
+		IsActive:            u.IsActive,
+		IsVerified:          u.IsVerified,
+		IsPhoneVerified:     u.IsPhoneVerified,
+		IsMfaEnabled:        u.IsMfaEnabled,
+		CreatedAt:           u.CreatedAt,
+		UpdatedAt:           u.UpdatedAt,
+		DeletionRequestedAt: u.DeletionRequestedAt,
+	}
+	if u.AvatarImageId.Valid {
+		profile.AvatarImageId = &u.AvatarImageId.UUID
+	}
+	return profile
+}
+
+func (s *Session) ToExport() SessionExport {
+	return SessionExport{
+		Id:         s.Id,
+		DeviceName: s.DeviceName,
+		UserAgent:  s.UserAgent,
+		IpAddress:  s.IpAddress,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		RevokedAt:  s.RevokedAt,
+	}
+}
+
+func (e *AuditEvent) ToExport() AuditEventExport {
+	return AuditEventExport{
+		Action:    e.Action,
+		IpAddress: e.IpAddress,
+		UserAgent: e.UserAgent,
+		Metadata:  e.MetadataMap(),
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func (a *LoginAttempt) ToExport() LoginAttemptExport {
+	return LoginAttemptExport{
+		Method:        a.Method,
+		Succeeded:     a.Succeeded,
+		FailureReason: a.FailureReason,
+		DeviceName:    a.DeviceName,
+		UserAgent:     a.UserAgent,
+		IpAddress:     a.IpAddress,
+		CreatedAt:     a.CreatedAt,
+	}
+}
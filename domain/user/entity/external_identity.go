@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// External identity providers
+const (
+	IdentityProviderGoogle = "google"
+)
+
+// ExternalIdentity links a user to an account at an external identity provider,
+// a user can be linked to the accounts of several providers
+type ExternalIdentity struct {
+	Id       uuid.UUID `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserId   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Provider string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_external_identities_provider_subject,priority:1"`
+	// Subject identifies the account at the provider, unlike its email it is never reassigned
+	Subject   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_external_identities_provider_subject,priority:2"`
+	Email     string    `gorm:"type:varchar(255)"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+}
+
+// ExternalClaims are the claims of a verified id token about the account it was issued to
+type ExternalClaims struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Login methods
+const (
+	LoginMethodPassword    = "password"
+	LoginMethodPasswordMfa = "password_mfa"
+	LoginMethodPhoneOtp    = "phone_otp"
+	LoginMethodGoogle      = "google"
+)
+
+// LoginAttempt is an entry of the login history users see about their own account
+type LoginAttempt struct {
+	Id        uuid.UUID `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserId    uuid.UUID `gorm:"type:uuid;not null;index:idx_login_attempts_user_created,priority:1;index:idx_login_attempts_user_device,priority:1"`
+	Method    string    `gorm:"type:varchar(20);not null"`
+	Succeeded bool      `gorm:"type:bool;not null"`
+	// FailureReason is the login outcome of failed attempts, e.g. wrong_password
+	FailureReason     string    `gorm:"type:varchar(50)"`
+	DeviceName        string    `gorm:"type:varchar(255)"`
+	UserAgent         string    `gorm:"type:varchar(512)"`
+	IpAddress         string    `gorm:"type:varchar(64)"`
+	DeviceFingerprint string    `gorm:"type:varchar(64);not null;index:idx_login_attempts_user_device,priority:2"`
+	CreatedAt         time.Time `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP;index:idx_login_attempts_user_created,priority:2"`
+}
+
+func (a *LoginAttempt) ToProto() *pb.LoginAttempt {
+	return &pb.LoginAttempt{
+		Id:            a.Id.String(),
+		Method:        a.Method,
+		Succeeded:     a.Succeeded,
+		FailureReason: a.FailureReason,
+		DeviceName:    a.DeviceName,
+		UserAgent:     a.UserAgent,
+		IpAddress:     a.IpAddress,
+		CreatedAt:     timestamppb.New(a.CreatedAt),
+	}
+}
+
+// Fingerprint identifies the device across logins. The ip address is left out
+// as it changes whenever a phone switches networks.
+func (d Device) Fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(d.Name)) + "\n" + d.UserAgent))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserMfa holds the totp secret of a user, encrypted with the key configured in MFA_ENCRYPTION_KEY.
+// The secret is pending until the user confirms it, see User.IsMfaEnabled.
+type UserMfa struct {
+	UserId uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Secret string    `gorm:"type:text;not null"`
+	// time step of the last totp code accepted, codes of this step and older ones are refused
+	LastTotpStep int64     `gorm:"not null;default:0"`
+	CreatedAt    time.Time `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+}
+
+func (UserMfa) TableName() string {
+	return "user_mfa"
+}
+
+// MfaRecoveryCode replaces a totp code once when the authenticator is lost.
+// Only the sha256 of the code is stored.
+type MfaRecoveryCode struct {
+	Id        uuid.UUID  `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserId    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CodeHash  string     `gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time `gorm:"type:timestamptz;null"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+}
+
+// MfaEnrollment is shown once to the user to add the secret to an authenticator app
+type MfaEnrollment struct {
+	Secret string
+	Uri    string
+}
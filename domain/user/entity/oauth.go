@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// OAuth scopes supported by the openid connect provider
+const (
+	ScopeOpenId  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+	ScopePhone   = "phone"
+)
+
+// OAuthClient is an app signing its users in through the openid connect provider
+type OAuthClient struct {
+	Id   string `gorm:"primaryKey;type:varchar(64)"`
+	Name string `gorm:"type:varchar(255);not null"`
+	// SecretHash is the sha256 of the secret of confidential clients, empty for public clients
+	// like mobile apps and single page apps which cannot keep a secret
+	SecretHash string `gorm:"type:varchar(64)"`
+	// RedirectUris are the uris codes are sent to, matched exactly
+	RedirectUris datatypes.JSONSlice[string] `gorm:"not null"`
+	IsActive     bool                        `gorm:"type:bool;not null;default:TRUE"`
+	CreatedAt    time.Time                   `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+	CreatedBy    uuid.UUID                   `gorm:"type:uuid;not null"`
+}
+
+// IsConfidential reports whether the client authenticates with a secret
+func (c *OAuthClient) IsConfidential() bool {
+	return c.SecretHash != ""
+}
+
+// AllowsRedirectUri reports whether uri is registered for the client
+func (c *OAuthClient) AllowsRedirectUri(uri string) bool {
+	return slices.Contains(c.RedirectUris, uri)
+}
+
+func (c *OAuthClient) ToProto() *pb.OAuthClient {
+	return &pb.OAuthClient{
+		ClientId:     c.Id,
+		Name:         c.Name,
+		RedirectUris: c.RedirectUris,
+		Confidential: c.IsConfidential(),
+	}
+}
+
+// AuthorizationCode is issued to a client once its user signed in, the client exchanges it
+// for tokens along with the verifier of CodeChallenge (PKCE, S256 only)
+type AuthorizationCode struct {
+	ClientId      string    `json:"client_id"`
+	RedirectUri   string    `json:"redirect_uri"`
+	UserId        uuid.UUID `json:"user_id"`
+	Scope         string    `json:"scope"`
+	Nonce         string    `json:"nonce,omitempty"`
+	CodeChallenge string    `json:"code_challenge"`
+	AuthTime      time.Time `json:"auth_time"`
+}
+
+// HasScope reports whether scope was granted with the code
+func (c *AuthorizationCode) HasScope(scope string) bool {
+	return slices.Contains(strings.Fields(c.Scope), scope)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outbox topics
+const (
+	OutboxTopicOtp    = "otp"
+	OutboxTopicNotice = "notice"
+	OutboxTopicEvent  = "event"
+)
+
+// Outbox statuses
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusDead    = "dead"
+)
+
+// OutboxMessage is written in the same transaction as the change it announces
+// and delivered afterwards by the outbox dispatcher
+type OutboxMessage struct {
+	Id            uuid.UUID  `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	Topic         string     `gorm:"type:varchar(100);not null"`
+	Payload       []byte     `gorm:"type:jsonb;not null"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_messages_due,priority:1"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP;index:idx_outbox_messages_due,priority:2"`
+	LastError     string     `gorm:"type:text;null"`
+	CreatedAt     time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+	SentAt        *time.Time `gorm:"type:timestamptz;null"`
+}
+
+func NewOutboxMessage(topic string, payload interface{}) (*OutboxMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &OutboxMessage{
+		Topic:         topic,
+		Payload:       data,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
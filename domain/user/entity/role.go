@@ -12,6 +12,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// RoleAdmin is the role name allowed to manage other users
+const RoleAdmin = "admin"
+
 type Role struct {
 	gorm.Model
 	RoleName    string         `gorm:"type:varchar(50);not null"`
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/datatypes"
+)
+
+// Scopes of service clients, each grants the protected rpcs declaring it
+const (
+	ScopeUsersRead        = "users:read"
+	ScopeAuditRead        = "audit:read"
+	ScopeTokensIntrospect = "tokens:introspect"
+)
+
+// APIKeyPrefix starts every api key, telling them apart from the tokens of users
+const APIKeyPrefix = "mk_"
+
+// ServiceClient is an internal service, like the order service, calling the protected rpcs with an api key
+type ServiceClient struct {
+	Id   uuid.UUID `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	Name string    `gorm:"type:varchar(255);not null"`
+	// KeyPrefix identifies the api key, KeyHash is the sha256 of the whole key
+	KeyPrefix string                      `gorm:"type:varchar(32);not null;uniqueIndex"`
+	KeyHash   string                      `gorm:"type:varchar(64);not null"`
+	Scopes    datatypes.JSONSlice[string] `gorm:"not null"`
+	CreatedAt time.Time                   `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+	CreatedBy uuid.UUID                   `gorm:"type:uuid;not null"`
+	RevokedAt *time.Time                  `gorm:"type:timestamptz;null"`
+}
+
+func (c *ServiceClient) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+func (c *ServiceClient) IsRevoked() bool {
+	return c.RevokedAt != nil
+}
+
+func (c *ServiceClient) ToProto() *pb.ServiceClient {
+	return &pb.ServiceClient{
+		Id:        c.Id.String(),
+		Name:      c.Name,
+		Scopes:    c.Scopes,
+		KeyPrefix: APIKeyPrefix + c.KeyPrefix,
+		Revoked:   c.IsRevoked(),
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
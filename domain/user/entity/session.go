@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Session is created for every successful login. Refresh tokens carry the session id and
+// RefreshTokenId as jti, the latter rotates on every refresh so a replayed token is detected.
+type Session struct {
+	Id             uuid.UUID  `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserId         uuid.UUID  `gorm:"type:uuid;not null;index"`
+	DeviceName     string     `gorm:"type:varchar(255)"`
+	UserAgent      string     `gorm:"type:varchar(512)"`
+	IpAddress      string     `gorm:"type:varchar(64)"`
+	RefreshTokenId uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+	LastSeenAt     time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+	ExpiresAt      time.Time  `gorm:"type:timestamptz;not null"`
+	RevokedAt      *time.Time `gorm:"type:timestamptz;null"`
+	// ImpersonatorId is the admin who opened the session to see the app as the user
+	ImpersonatorId uuid.NullUUID `gorm:"type:uuid;null"`
+	// OAuthClientId is the client the session was opened for through the OpenID Connect provider,
+	// empty for the first party apps. Only that client refreshes the session.
+	OAuthClientId string `gorm:"type:varchar(64);not null;default:''"`
+}
+
+// IsActive reports whether the session can still be refreshed at now
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+func (s *Session) ToProto(current uuid.UUID) *pb.Session {
+	return &pb.Session{
+		Id:           s.Id.String(),
+		DeviceName:   s.DeviceName,
+		UserAgent:    s.UserAgent,
+		IpAddress:    s.IpAddress,
+		CreatedAt:    timestamppb.New(s.CreatedAt),
+		LastSeenAt:   timestamppb.New(s.LastSeenAt),
+		Current:      s.Id == current,
+		Impersonated: s.ImpersonatorId.Valid,
+	}
+}
+
+// Device describes the client a session is created from
+type Device struct {
+	Name      string
+	UserAgent string
+	IpAddress string
+}
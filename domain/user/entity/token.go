@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TokenIntrospection describes a token with the semantics of RFC 7662, only Active
+// is set for tokens that are invalid, expired or revoked
+type TokenIntrospection struct {
+	Active    bool
+	UserId    uuid.UUID
+	Roles     []string
+	SessionId uuid.UUID
+	TokenType string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	// ActorId is the admin impersonating the user, uuid.Nil for the tokens of the user
+	ActorId uuid.UUID
+}
+
+func (t *TokenIntrospection) ToProto() *pb.IntrospectTokenResponse {
+	if !t.Active {
+		return &pb.IntrospectTokenResponse{}
+	}
+	res := &pb.IntrospectTokenResponse{
+		Active:    true,
+		Sub:       t.UserId.String(),
+		Roles:     t.Roles,
+		TokenType: t.TokenType,
+		Iat:       timestamppb.New(t.IssuedAt),
+		Exp:       timestamppb.New(t.ExpiresAt),
+	}
+	if t.SessionId != uuid.Nil {
+		res.SessionId = t.SessionId.String()
+	}
+	if t.ActorId != uuid.Nil {
+		res.ActSub = t.ActorId.String()
+	}
+	return res
+}
@@ -12,7 +12,7 @@ type User struct {
 	Username             string        `gorm:"type:varchar(255);not null;unique"`
 	Password             string        `gorm:"type:varchar(255);not null"`
 	Email                string        `gorm:"type:varchar(255);not null;unique"`
-	PhoneNumber          string        `gorm:"type:varchar(50);not null;unique"`
+	PhoneNumber          string        `gorm:"type:varchar(50);unique;default:null"` // NULL for users created by a social login
 	AvatarImageId        uuid.NullUUID `gorm:"type:varchar(255);null"`
 	AccessToken          *string       `gorm:"type:varchar(255);null"`
 	IsActive             bool          `gorm:"type:bool;not null;default:TRUE"`
@@ -24,25 +24,65 @@ type User struct {
 	Roles                []Role        `gorm:"many2many:user_roles;"`
 	Address              string        `gorm:"type:varchar(255);null"`
 	IsVerified           bool          `gorm:"type:bool;not null;default:FALSE"`
+	IsPhoneVerified      bool          `gorm:"type:bool;not null;default:FALSE"`
+	IsMfaEnabled         bool          `gorm:"type:bool;not null;default:FALSE"`
 	WrongPasswordCounter uint
+	// DeletionRequestedAt is set when the user asks to delete the account, the personal data
+	// is scrubbed once the grace period has passed and AnonymizedAt is set
+	DeletionRequestedAt *time.Time `gorm:"type:timestamptz;null;index"`
+	AnonymizedAt        *time.Time `gorm:"type:timestamptz;null"`
 }
 
+// ToProto leaves the password out, its hash is never sent to the clients
 func (u *User) ToProto() *pb.User {
 	var avatarImageId string
 	if u.AvatarImageId.Valid {
 		avatarImageId = u.AvatarImageId.UUID.String()
 	}
 	return &pb.User{
-		Id:            u.Id.String(),
-		Username:      u.Username,
+		Id:              u.Id.String(),
+		Username:        u.Username,
+		Email:           u.Email,
+		PhoneNumber:     u.PhoneNumber,
+		IsActive:        u.IsActive,
+		IsVerified:      u.IsVerified,
+		AvatarImageId:   avatarImageId,
+		Name:            u.Name,
+		Address:         u.Address,
+		IsPhoneVerified: u.IsPhoneVerified,
+	}
+}
+
+// UserSummary is the projection of a user served to the other services
+type UserSummary struct {
+	Id            uuid.UUID
+	Name          string
+	Email         string
+	AvatarImageId uuid.NullUUID
+	IsActive      bool
+}
+
+func (u *User) Summary() *UserSummary {
+	return &UserSummary{
+		Id:            u.Id,
+		Name:          u.Name,
 		Email:         u.Email,
-		PhoneNumber:   u.PhoneNumber,
-		Password:      u.Password,
+		AvatarImageId: u.AvatarImageId,
 		IsActive:      u.IsActive,
-		IsVerified:    u.IsVerified,
-		AvatarImageId: avatarImageId,
+	}
+}
+
+func (u *UserSummary) ToProto() *pb.UserSummary {
+	var avatarImageId string
+	if u.AvatarImageId.Valid {
+		avatarImageId = u.AvatarImageId.UUID.String()
+	}
+	return &pb.UserSummary{
+		Id:            u.Id.String(),
 		Name:          u.Name,
-		Address:       u.Address,
+		Email:         u.Email,
+		AvatarImageId: avatarImageId,
+		IsActive:      u.IsActive,
 	}
 }
 
@@ -56,8 +96,47 @@ type LoginResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-type OtpMailReq struct {
-	Name    string
-	Email   string
-	OtpCode int
+// Channels delivering otp codes
+const (
+	OtpChannelEmail    = "email"
+	OtpChannelSms      = "sms"
+	OtpChannelWhatsapp = "whatsapp"
+)
+
+// OtpChannelFromProto returns the channel chosen in a request, email when unset
+func OtpChannelFromProto(channel pb.OtpChannel) string {
+	switch channel {
+	case pb.OtpChannel_OTP_CHANNEL_SMS:
+		return OtpChannelSms
+	case pb.OtpChannel_OTP_CHANNEL_WHATSAPP:
+		return OtpChannelWhatsapp
+	}
+	return OtpChannelEmail
+}
+
+// PhoneOtpChannelFromProto returns the channel used to send an otp to a phone number,
+// sms unless whatsapp is asked for
+func PhoneOtpChannelFromProto(channel pb.OtpChannel) string {
+	if channel == pb.OtpChannel_OTP_CHANNEL_WHATSAPP {
+		return OtpChannelWhatsapp
+	}
+	return OtpChannelSms
+}
+
+type OtpNotification struct {
+	Channel     string
+	Name        string
+	Email       string
+	PhoneNumber string
+	OtpCode     int
+}
+
+// Notice tells a user about a change made to their account
+type Notice struct {
+	Channel     string
+	Name        string
+	Email       string
+	PhoneNumber string
+	Subject     string
+	Message     string
 }
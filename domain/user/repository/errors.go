@@ -0,0 +1,22 @@
+package repository
+
+import "errors"
+
+// Errors returned by the repository implementations, independent of the underlying database.
+// Callers should compare with errors.Is.
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrRoleNotFound    = errors.New("role not found")
+	ErrDuplicateEmail  = errors.New("email already registered")
+	ErrDuplicatePhone  = errors.New("phone number already registered")
+	ErrMfaNotFound     = errors.New("mfa not enrolled")
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrExternalIdentityNotFound is returned for accounts of a provider not linked to any user
+	ErrExternalIdentityNotFound = errors.New("external identity not found")
+	ErrOAuthClientNotFound      = errors.New("oauth client not found")
+	ErrServiceClientNotFound    = errors.New("service client not found")
+	// returned for unknown and already used recovery codes alike
+	ErrRecoveryCodeInvalid = errors.New("invalid recovery code")
+	// returned for a totp code of a step not newer than the last one accepted
+	ErrTotpReplayed = errors.New("totp code already used")
+)
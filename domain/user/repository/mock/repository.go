@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	uuid "github.com/google/uuid"
@@ -41,6 +42,20 @@ func (m *MockUser) EXPECT() *MockUserMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUser) Anonymize(ctx context.Context, ID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserMockRecorder) Anonymize(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUser)(nil).Anonymize), ctx, ID)
+}
+
 // Create mocks base method.
 func (m *MockUser) Create(ctx context.Context, user *entity.User, roleIds []string) error {
 	m.ctrl.T.Helper()
@@ -55,6 +70,20 @@ func (mr *MockUserMockRecorder) Create(ctx, user, roleIds any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), ctx, user, roleIds)
 }
 
+// EnableMfa mocks base method.
+func (m *MockUser) EnableMfa(ctx context.Context, ID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMfa", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMfa indicates an expected call of EnableMfa.
+func (mr *MockUserMockRecorder) EnableMfa(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMfa", reflect.TypeOf((*MockUser)(nil).EnableMfa), ctx, ID)
+}
+
 // GetAll mocks base method.
 func (m *MockUser) GetAll(ctx context.Context) ([]*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +129,79 @@ func (mr *MockUserMockRecorder) GetByID(ctx, ID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUser)(nil).GetByID), ctx, ID)
 }
 
+// GetByPhoneNumber mocks base method.
+func (m *MockUser) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPhoneNumber", ctx, phoneNumber)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPhoneNumber indicates an expected call of GetByPhoneNumber.
+func (mr *MockUserMockRecorder) GetByPhoneNumber(ctx, phoneNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPhoneNumber", reflect.TypeOf((*MockUser)(nil).GetByPhoneNumber), ctx, phoneNumber)
+}
+
+// GetSummariesByIDs mocks base method.
+func (m *MockUser) GetSummariesByIDs(ctx context.Context, IDs []uuid.UUID) ([]*entity.UserSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummariesByIDs", ctx, IDs)
+	ret0, _ := ret[0].([]*entity.UserSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummariesByIDs indicates an expected call of GetSummariesByIDs.
+func (mr *MockUserMockRecorder) GetSummariesByIDs(ctx, IDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummariesByIDs", reflect.TypeOf((*MockUser)(nil).GetSummariesByIDs), ctx, IDs)
+}
+
+// ListDeletionDue mocks base method.
+func (m *MockUser) ListDeletionDue(ctx context.Context, requestedBefore time.Time, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletionDue", ctx, requestedBefore, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletionDue indicates an expected call of ListDeletionDue.
+func (mr *MockUserMockRecorder) ListDeletionDue(ctx, requestedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletionDue", reflect.TypeOf((*MockUser)(nil).ListDeletionDue), ctx, requestedBefore, limit)
+}
+
+// ReplaceRoles mocks base method.
+func (m *MockUser) ReplaceRoles(ctx context.Context, ID uuid.UUID, roleIds []string, updatedBy uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRoles", ctx, ID, roleIds, updatedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRoles indicates an expected call of ReplaceRoles.
+func (mr *MockUserMockRecorder) ReplaceRoles(ctx, ID, roleIds, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRoles", reflect.TypeOf((*MockUser)(nil).ReplaceRoles), ctx, ID, roleIds, updatedBy)
+}
+
+// RequestDeletion mocks base method.
+func (m *MockUser) RequestDeletion(ctx context.Context, ID uuid.UUID, requestedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDeletion", ctx, ID, requestedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestDeletion indicates an expected call of RequestDeletion.
+func (mr *MockUserMockRecorder) RequestDeletion(ctx, ID, requestedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDeletion", reflect.TypeOf((*MockUser)(nil).RequestDeletion), ctx, ID, requestedAt)
+}
+
 // Save mocks base method.
 func (m *MockUser) Save(ctx context.Context, user *entity.User) error {
 	m.ctrl.T.Helper()
@@ -114,6 +216,48 @@ func (mr *MockUserMockRecorder) Save(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUser)(nil).Save), ctx, user)
 }
 
+// SetActive mocks base method.
+func (m *MockUser) SetActive(ctx context.Context, ID uuid.UUID, active bool, updatedBy uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActive", ctx, ID, active, updatedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActive indicates an expected call of SetActive.
+func (mr *MockUserMockRecorder) SetActive(ctx, ID, active, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActive", reflect.TypeOf((*MockUser)(nil).SetActive), ctx, ID, active, updatedBy)
+}
+
+// UpdateEmail mocks base method.
+func (m *MockUser) UpdateEmail(ctx context.Context, ID uuid.UUID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, ID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockUserMockRecorder) UpdateEmail(ctx, ID, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockUser)(nil).UpdateEmail), ctx, ID, email)
+}
+
+// VerifyPhoneNumber mocks base method.
+func (m *MockUser) VerifyPhoneNumber(ctx context.Context, ID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPhoneNumber", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyPhoneNumber indicates an expected call of VerifyPhoneNumber.
+func (mr *MockUserMockRecorder) VerifyPhoneNumber(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPhoneNumber", reflect.TypeOf((*MockUser)(nil).VerifyPhoneNumber), ctx, ID)
+}
+
 // VerifyUserByEmail mocks base method.
 func (m *MockUser) VerifyUserByEmail(ctx context.Context, email string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -180,3 +324,714 @@ func (mr *MockRoleMockRecorder) GetRole(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockRole)(nil).GetRole), ctx)
 }
+
+// MockMfa is a mock of Mfa interface.
+type MockMfa struct {
+	ctrl     *gomock.Controller
+	recorder *MockMfaMockRecorder
+}
+
+// MockMfaMockRecorder is the mock recorder for MockMfa.
+type MockMfaMockRecorder struct {
+	mock *MockMfa
+}
+
+// NewMockMfa creates a new mock instance.
+func NewMockMfa(ctrl *gomock.Controller) *MockMfa {
+	mock := &MockMfa{ctrl: ctrl}
+	mock.recorder = &MockMfaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMfa) EXPECT() *MockMfaMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockMfa) DeleteByUserID(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockMfaMockRecorder) DeleteByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockMfa)(nil).DeleteByUserID), ctx, userId)
+}
+
+// GetByUserID mocks base method.
+func (m *MockMfa) GetByUserID(ctx context.Context, userId uuid.UUID) (*entity.UserMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userId)
+	ret0, _ := ret[0].(*entity.UserMfa)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockMfaMockRecorder) GetByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockMfa)(nil).GetByUserID), ctx, userId)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockMfa) ReplaceRecoveryCodes(ctx context.Context, userId uuid.UUID, codes []*entity.MfaRecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", ctx, userId, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockMfaMockRecorder) ReplaceRecoveryCodes(ctx, userId, codes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockMfa)(nil).ReplaceRecoveryCodes), ctx, userId, codes)
+}
+
+// Save mocks base method.
+func (m *MockMfa) Save(ctx context.Context, mfa *entity.UserMfa) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, mfa)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMfaMockRecorder) Save(ctx, mfa any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMfa)(nil).Save), ctx, mfa)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMfa) UseRecoveryCode(ctx context.Context, userId uuid.UUID, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userId, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMfaMockRecorder) UseRecoveryCode(ctx, userId, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMfa)(nil).UseRecoveryCode), ctx, userId, codeHash)
+}
+
+// UseTotpStep mocks base method.
+func (m *MockMfa) UseTotpStep(ctx context.Context, userId uuid.UUID, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTotpStep", ctx, userId, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTotpStep indicates an expected call of UseTotpStep.
+func (mr *MockMfaMockRecorder) UseTotpStep(ctx, userId, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTotpStep", reflect.TypeOf((*MockMfa)(nil).UseTotpStep), ctx, userId, step)
+}
+
+// MockSession is a mock of Session interface.
+type MockSession struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionMockRecorder
+}
+
+// MockSessionMockRecorder is the mock recorder for MockSession.
+type MockSessionMockRecorder struct {
+	mock *MockSession
+}
+
+// NewMockSession creates a new mock instance.
+func NewMockSession(ctrl *gomock.Controller) *MockSession {
+	mock := &MockSession{ctrl: ctrl}
+	mock.recorder = &MockSessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSession) EXPECT() *MockSessionMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSession) Create(ctx context.Context, session *entity.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionMockRecorder) Create(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSession)(nil).Create), ctx, session)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockSession) DeleteByUserID(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockSessionMockRecorder) DeleteByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockSession)(nil).DeleteByUserID), ctx, userId)
+}
+
+// GetByID mocks base method.
+func (m *MockSession) GetByID(ctx context.Context, id uuid.UUID) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSessionMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSession)(nil).GetByID), ctx, id)
+}
+
+// ListActiveByUserID mocks base method.
+func (m *MockSession) ListActiveByUserID(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveByUserID", ctx, userId)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveByUserID indicates an expected call of ListActiveByUserID.
+func (mr *MockSessionMockRecorder) ListActiveByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveByUserID", reflect.TypeOf((*MockSession)(nil).ListActiveByUserID), ctx, userId)
+}
+
+// ListByUserID mocks base method.
+func (m *MockSession) ListByUserID(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userId)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockSessionMockRecorder) ListByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockSession)(nil).ListByUserID), ctx, userId)
+}
+
+// Revoke mocks base method.
+func (m *MockSession) Revoke(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionMockRecorder) Revoke(ctx, userId, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSession)(nil).Revoke), ctx, userId, id)
+}
+
+// RevokeAllByUserID mocks base method.
+func (m *MockSession) RevokeAllByUserID(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllByUserID", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllByUserID indicates an expected call of RevokeAllByUserID.
+func (mr *MockSessionMockRecorder) RevokeAllByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllByUserID", reflect.TypeOf((*MockSession)(nil).RevokeAllByUserID), ctx, userId)
+}
+
+// Rotate mocks base method.
+func (m *MockSession) Rotate(ctx context.Context, session *entity.Session, previousRefreshTokenId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, session, previousRefreshTokenId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionMockRecorder) Rotate(ctx, session, previousRefreshTokenId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSession)(nil).Rotate), ctx, session, previousRefreshTokenId)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockOutbox) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entity.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, limit, lease)
+	ret0, _ := ret[0].([]*entity.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockOutboxMockRecorder) ClaimDue(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockOutbox)(nil).ClaimDue), ctx, limit, lease)
+}
+
+// Create mocks base method.
+func (m *MockOutbox) Create(ctx context.Context, message *entity.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxMockRecorder) Create(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutbox)(nil).Create), ctx, message)
+}
+
+// DeleteByUser mocks base method.
+func (m *MockOutbox) DeleteByUser(ctx context.Context, userId uuid.UUID, email, phoneNumber string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUser", ctx, userId, email, phoneNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser.
+func (mr *MockOutboxMockRecorder) DeleteByUser(ctx, userId, email, phoneNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockOutbox)(nil).DeleteByUser), ctx, userId, email, phoneNumber)
+}
+
+// Save mocks base method.
+func (m *MockOutbox) Save(ctx context.Context, message *entity.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOutboxMockRecorder) Save(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOutbox)(nil).Save), ctx, message)
+}
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAudit) Create(ctx context.Context, event *entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditMockRecorder) Create(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAudit)(nil).Create), ctx, event)
+}
+
+// List mocks base method.
+func (m *MockAudit) List(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.AuditEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAudit)(nil).List), ctx, filter)
+}
+
+// ScrubByUserID mocks base method.
+func (m *MockAudit) ScrubByUserID(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScrubByUserID", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScrubByUserID indicates an expected call of ScrubByUserID.
+func (mr *MockAuditMockRecorder) ScrubByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScrubByUserID", reflect.TypeOf((*MockAudit)(nil).ScrubByUserID), ctx, userId)
+}
+
+// MockLoginHistory is a mock of LoginHistory interface.
+type MockLoginHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginHistoryMockRecorder
+}
+
+// MockLoginHistoryMockRecorder is the mock recorder for MockLoginHistory.
+type MockLoginHistoryMockRecorder struct {
+	mock *MockLoginHistory
+}
+
+// NewMockLoginHistory creates a new mock instance.
+func NewMockLoginHistory(ctrl *gomock.Controller) *MockLoginHistory {
+	mock := &MockLoginHistory{ctrl: ctrl}
+	mock.recorder = &MockLoginHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginHistory) EXPECT() *MockLoginHistoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLoginHistory) Create(ctx context.Context, attempt *entity.LoginAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoginHistoryMockRecorder) Create(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoginHistory)(nil).Create), ctx, attempt)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockLoginHistory) DeleteByUserID(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockLoginHistoryMockRecorder) DeleteByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockLoginHistory)(nil).DeleteByUserID), ctx, userId)
+}
+
+// HasSucceeded mocks base method.
+func (m *MockLoginHistory) HasSucceeded(ctx context.Context, userId uuid.UUID, fingerprint string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSucceeded", ctx, userId, fingerprint)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasSucceeded indicates an expected call of HasSucceeded.
+func (mr *MockLoginHistoryMockRecorder) HasSucceeded(ctx, userId, fingerprint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSucceeded", reflect.TypeOf((*MockLoginHistory)(nil).HasSucceeded), ctx, userId, fingerprint)
+}
+
+// ListByUserID mocks base method.
+func (m *MockLoginHistory) ListByUserID(ctx context.Context, userId uuid.UUID, page, pageSize int) ([]*entity.LoginAttempt, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userId, page, pageSize)
+	ret0, _ := ret[0].([]*entity.LoginAttempt)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockLoginHistoryMockRecorder) ListByUserID(ctx, userId, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockLoginHistory)(nil).ListByUserID), ctx, userId, page, pageSize)
+}
+
+// MockExternalIdentity is a mock of ExternalIdentity interface.
+type MockExternalIdentity struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalIdentityMockRecorder
+}
+
+// MockExternalIdentityMockRecorder is the mock recorder for MockExternalIdentity.
+type MockExternalIdentityMockRecorder struct {
+	mock *MockExternalIdentity
+}
+
+// NewMockExternalIdentity creates a new mock instance.
+func NewMockExternalIdentity(ctrl *gomock.Controller) *MockExternalIdentity {
+	mock := &MockExternalIdentity{ctrl: ctrl}
+	mock.recorder = &MockExternalIdentityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalIdentity) EXPECT() *MockExternalIdentityMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockExternalIdentity) Create(ctx context.Context, identity *entity.ExternalIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExternalIdentityMockRecorder) Create(ctx, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExternalIdentity)(nil).Create), ctx, identity)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockExternalIdentity) DeleteByUserID(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockExternalIdentityMockRecorder) DeleteByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockExternalIdentity)(nil).DeleteByUserID), ctx, userId)
+}
+
+// GetByProviderSubject mocks base method.
+func (m *MockExternalIdentity) GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProviderSubject", ctx, provider, subject)
+	ret0, _ := ret[0].(*entity.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProviderSubject indicates an expected call of GetByProviderSubject.
+func (mr *MockExternalIdentityMockRecorder) GetByProviderSubject(ctx, provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProviderSubject", reflect.TypeOf((*MockExternalIdentity)(nil).GetByProviderSubject), ctx, provider, subject)
+}
+
+// MockOAuthClient is a mock of OAuthClient interface.
+type MockOAuthClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAuthClientMockRecorder
+}
+
+// MockOAuthClientMockRecorder is the mock recorder for MockOAuthClient.
+type MockOAuthClientMockRecorder struct {
+	mock *MockOAuthClient
+}
+
+// NewMockOAuthClient creates a new mock instance.
+func NewMockOAuthClient(ctrl *gomock.Controller) *MockOAuthClient {
+	mock := &MockOAuthClient{ctrl: ctrl}
+	mock.recorder = &MockOAuthClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuthClient) EXPECT() *MockOAuthClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOAuthClient) Create(ctx context.Context, client *entity.OAuthClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOAuthClientMockRecorder) Create(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOAuthClient)(nil).Create), ctx, client)
+}
+
+// GetByID mocks base method.
+func (m *MockOAuthClient) GetByID(ctx context.Context, id string) (*entity.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOAuthClientMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOAuthClient)(nil).GetByID), ctx, id)
+}
+
+// MockServiceClient is a mock of ServiceClient interface.
+type MockServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceClientMockRecorder
+}
+
+// MockServiceClientMockRecorder is the mock recorder for MockServiceClient.
+type MockServiceClientMockRecorder struct {
+	mock *MockServiceClient
+}
+
+// NewMockServiceClient creates a new mock instance.
+func NewMockServiceClient(ctrl *gomock.Controller) *MockServiceClient {
+	mock := &MockServiceClient{ctrl: ctrl}
+	mock.recorder = &MockServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceClient) EXPECT() *MockServiceClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockServiceClient) Create(ctx context.Context, client *entity.ServiceClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceClientMockRecorder) Create(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceClient)(nil).Create), ctx, client)
+}
+
+// GetByKeyPrefix mocks base method.
+func (m *MockServiceClient) GetByKeyPrefix(ctx context.Context, prefix string) (*entity.ServiceClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKeyPrefix", ctx, prefix)
+	ret0, _ := ret[0].(*entity.ServiceClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKeyPrefix indicates an expected call of GetByKeyPrefix.
+func (mr *MockServiceClientMockRecorder) GetByKeyPrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKeyPrefix", reflect.TypeOf((*MockServiceClient)(nil).GetByKeyPrefix), ctx, prefix)
+}
+
+// List mocks base method.
+func (m *MockServiceClient) List(ctx context.Context) ([]*entity.ServiceClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entity.ServiceClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceClientMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockServiceClient)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockServiceClient) Revoke(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockServiceClientMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockServiceClient)(nil).Revoke), ctx, id)
+}
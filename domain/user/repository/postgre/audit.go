@@ -0,0 +1,66 @@
+package postgre
+
+import (
+	"context"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type auditRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditRepoImpl(db *gorm.DB) repository.Audit {
+	return &auditRepoImpl{
+		db: db,
+	}
+}
+
+func (a *auditRepoImpl) Create(ctx context.Context, event *entity.AuditEvent) error {
+	return conn(ctx, a.db).Create(event).Error
+}
+
+func (a *auditRepoImpl) List(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEvent, int64, error) {
+	query := conn(ctx, a.db).Model(&entity.AuditEvent{})
+	if filter.ActorId.Valid {
+		query = query.Where("actor_id = ?", filter.ActorId.UUID)
+	}
+	if filter.TargetUserId.Valid {
+		query = query.Where("target_user_id = ?", filter.TargetUserId.UUID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	var total int64
+	// counted on a copy so the query can be reused for the page
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var events []*entity.AuditEvent
+	err := query.Order("created_at DESC").Limit(filter.PageSize).Offset((filter.Page - 1) * filter.PageSize).Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// ScrubByUserID keeps the action, actors and time of the events, which the trail is about
+func (a *auditRepoImpl) ScrubByUserID(ctx context.Context, userId uuid.UUID) error {
+	return conn(ctx, a.db).Model(&entity.AuditEvent{}).
+		Where("target_user_id = ? OR actor_id = ?", userId, userId).
+		Updates(map[string]interface{}{
+			"metadata":   nil,
+			"ip_address": "",
+			"user_agent": "",
+		}).Error
+}
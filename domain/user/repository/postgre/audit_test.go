@@ -0,0 +1,128 @@
+package postgre
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func Test_auditRepoImpl_List(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	a := &auditRepoImpl{
+		db: db,
+	}
+	actorId := uuid.New()
+	targetId := uuid.New()
+	now := time.Now()
+	events := []*entity.AuditEvent{
+		{Action: entity.AuditLoginFailed, TargetUserId: uuid.NullUUID{UUID: targetId, Valid: true}, CreatedAt: now.Add(-2 * time.Hour)},
+		{Action: entity.AuditLoginSucceeded, TargetUserId: uuid.NullUUID{UUID: targetId, Valid: true}, CreatedAt: now.Add(-time.Hour)},
+		{Action: entity.AuditUserDeactivated, ActorId: uuid.NullUUID{UUID: actorId, Valid: true}, TargetUserId: uuid.NullUUID{UUID: targetId, Valid: true}, CreatedAt: now},
+		{Action: entity.AuditLoginFailed, TargetUserId: uuid.NullUUID{UUID: uuid.New(), Valid: true}, CreatedAt: now},
+	}
+	for _, event := range events {
+		if err := a.Create(context.Background(), event); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+	tests := []struct {
+		name      string
+		filter    entity.AuditFilter
+		want      []uuid.UUID
+		wantTotal int64
+	}{
+		{
+			name:      "by target newest first",
+			filter:    entity.AuditFilter{TargetUserId: uuid.NullUUID{UUID: targetId, Valid: true}, Page: 1, PageSize: 10},
+			want:      []uuid.UUID{events[2].Id, events[1].Id, events[0].Id},
+			wantTotal: 3,
+		},
+		{
+			name:      "by actor",
+			filter:    entity.AuditFilter{ActorId: uuid.NullUUID{UUID: actorId, Valid: true}, Page: 1, PageSize: 10},
+			want:      []uuid.UUID{events[2].Id},
+			wantTotal: 1,
+		},
+		{
+			name:      "by action and time range",
+			filter:    entity.AuditFilter{TargetUserId: uuid.NullUUID{UUID: targetId, Valid: true}, Action: entity.AuditLoginFailed, From: now.Add(-3 * time.Hour), To: now.Add(-90 * time.Minute), Page: 1, PageSize: 10},
+			want:      []uuid.UUID{events[0].Id},
+			wantTotal: 1,
+		},
+		{
+			name:      "second page",
+			filter:    entity.AuditFilter{TargetUserId: uuid.NullUUID{UUID: targetId, Valid: true}, Page: 2, PageSize: 2},
+			want:      []uuid.UUID{events[0].Id},
+			wantTotal: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := a.List(context.Background(), tt.filter)
+			if err != nil {
+				t.Errorf("auditRepoImpl.List() error = %v", err)
+				return
+			}
+			if total != tt.wantTotal {
+				t.Errorf("auditRepoImpl.List() total = %v, want %v", total, tt.wantTotal)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("auditRepoImpl.List() = %v events, want %v", len(got), len(tt.want))
+				return
+			}
+			for i := range got {
+				if got[i].Id != tt.want[i] {
+					t.Errorf("auditRepoImpl.List()[%d] = %v, want %v", i, got[i].Id, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_auditRepoImpl_ScrubByUserID(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	a := &auditRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	metadata := datatypes.JSON(`{"email":"deleted@mail.com"}`)
+	own := &entity.AuditEvent{Action: entity.AuditProfileChanged, TargetUserId: uuid.NullUUID{UUID: userId, Valid: true}, IpAddress: "203.0.113.7", UserAgent: "okhttp", Metadata: metadata}
+	acted := &entity.AuditEvent{Action: entity.AuditUserDeactivated, ActorId: uuid.NullUUID{UUID: userId, Valid: true}, IpAddress: "203.0.113.7", Metadata: metadata}
+	other := &entity.AuditEvent{Action: entity.AuditProfileChanged, TargetUserId: uuid.NullUUID{UUID: uuid.New(), Valid: true}, IpAddress: "198.51.100.1", Metadata: metadata}
+	for _, event := range []*entity.AuditEvent{own, acted, other} {
+		if err := a.Create(context.Background(), event); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
+	if err := a.ScrubByUserID(context.Background(), userId); err != nil {
+		t.Fatalf("auditRepoImpl.ScrubByUserID() error = %v", err)
+	}
+	for _, tt := range []struct {
+		event     *entity.AuditEvent
+		wantScrub bool
+	}{
+		{event: own, wantScrub: true},
+		{event: acted, wantScrub: true},
+		{event: other},
+	} {
+		var got entity.AuditEvent
+		if err := db.First(&got, "id = ?", tt.event.Id).Error; err != nil {
+			t.Fatalf("auditRepoImpl.ScrubByUserID() dropped event %v: %v", tt.event.Id, err)
+		}
+		if scrubbed := got.Metadata == nil && got.IpAddress == "" && got.UserAgent == ""; scrubbed != tt.wantScrub || got.Action != tt.event.Action {
+			t.Errorf("auditRepoImpl.ScrubByUserID() event = %+v, want scrubbed %v", got, tt.wantScrub)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package postgre
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgInvalidTextValue    = "22P02"
+)
+
+// translateUserError maps gorm and postgres errors raised by queries on users
+// to the errors exposed by the repository package
+func translateUserError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return repository.ErrUserNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgUniqueViolation {
+		return err
+	}
+	switch {
+	case violates(pgErr, "phone_number"):
+		return repository.ErrDuplicatePhone
+	case violates(pgErr, "email"), violates(pgErr, "username"):
+		// username is filled with the email on registration
+		return repository.ErrDuplicateEmail
+	}
+	return err
+}
+
+// translateUserRoleError maps errors raised when linking a role to a user.
+// Role ids are sent as strings, an id that is not a number fails the cast
+// the same way an unknown id fails the foreign key.
+func translateUserRoleError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == pgForeignKeyViolation || pgErr.Code == pgInvalidTextValue) {
+		return repository.ErrRoleNotFound
+	}
+	return err
+}
+
+// violates reports whether the constraint or the detail of pgErr refers to column
+func violates(pgErr *pgconn.PgError, column string) bool {
+	return strings.Contains(pgErr.ConstraintName, column) ||
+		strings.Contains(pgErr.Detail, "("+column+")")
+}
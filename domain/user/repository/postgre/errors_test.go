@@ -0,0 +1,99 @@
+package postgre
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func Test_translateUserError(t *testing.T) {
+	otherErr := errors.New("connection refused")
+	pkeyErr := &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "users_pkey"}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "record not found",
+			err:  gorm.ErrRecordNotFound,
+			want: repository.ErrUserNotFound,
+		},
+		{
+			name: "duplicate email",
+			err:  &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "users_email_key"},
+			want: repository.ErrDuplicateEmail,
+		},
+		{
+			name: "duplicate username",
+			err:  &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "uni_users_username"},
+			want: repository.ErrDuplicateEmail,
+		},
+		{
+			name: "duplicate phone number",
+			err: fmt.Errorf("insert: %w", &pgconn.PgError{
+				Code:   pgUniqueViolation,
+				Detail: "Key (phone_number)=(08123456789) already exists.",
+			}),
+			want: repository.ErrDuplicatePhone,
+		},
+		{
+			name: "unknown unique violation",
+			err:  pkeyErr,
+			want: pkeyErr,
+		},
+		{
+			name: "other error",
+			err:  otherErr,
+			want: otherErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translateUserError(tt.err); got != tt.want {
+				t.Errorf("translateUserError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_translateUserRoleError(t *testing.T) {
+	otherErr := errors.New("connection refused")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "unknown role id",
+			err:  &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: "fk_user_roles_role"},
+			want: repository.ErrRoleNotFound,
+		},
+		{
+			name: "role id not a number",
+			err:  &pgconn.PgError{Code: pgInvalidTextValue},
+			want: repository.ErrRoleNotFound,
+		},
+		{
+			name: "other error",
+			err:  otherErr,
+			want: otherErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translateUserRoleError(tt.err); got != tt.want {
+				t.Errorf("translateUserRoleError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
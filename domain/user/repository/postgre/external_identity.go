@@ -0,0 +1,42 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type externalIdentityRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewExternalIdentityRepoImpl(db *gorm.DB) repository.ExternalIdentity {
+	return &externalIdentityRepoImpl{
+		db: db,
+	}
+}
+
+func (e *externalIdentityRepoImpl) GetByProviderSubject(ctx context.Context, provider string, subject string) (*entity.ExternalIdentity, error) {
+	var identity *entity.ExternalIdentity
+	err := conn(ctx, e.db).Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repository.ErrExternalIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (e *externalIdentityRepoImpl) Create(ctx context.Context, identity *entity.ExternalIdentity) error {
+	return conn(ctx, e.db).Create(identity).Error
+}
+
+func (e *externalIdentityRepoImpl) DeleteByUserID(ctx context.Context, userId uuid.UUID) error {
+	return conn(ctx, e.db).Where("user_id = ?", userId).Delete(&entity.ExternalIdentity{}).Error
+}
@@ -0,0 +1,46 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+)
+
+func Test_externalIdentityRepoImpl(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	e := &externalIdentityRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	identity := &entity.ExternalIdentity{UserId: userId, Provider: entity.IdentityProviderGoogle, Subject: "108123456789", Email: "test@gmail.com"}
+	if err := e.Create(context.Background(), identity); err != nil {
+		t.Fatalf("externalIdentityRepoImpl.Create() error = %v", err)
+	}
+	// an account is linked to a single user
+	if err := e.Create(context.Background(), &entity.ExternalIdentity{UserId: uuid.New(), Provider: identity.Provider, Subject: identity.Subject}); err == nil {
+		t.Errorf("externalIdentityRepoImpl.Create() error = nil, want duplicate account")
+	}
+
+	got, err := e.GetByProviderSubject(context.Background(), entity.IdentityProviderGoogle, "108123456789")
+	if err != nil || got.UserId != userId {
+		t.Errorf("externalIdentityRepoImpl.GetByProviderSubject() = %v, %v, want identity of %v", got, err, userId)
+	}
+	if _, err := e.GetByProviderSubject(context.Background(), "other", "108123456789"); !errors.Is(err, repository.ErrExternalIdentityNotFound) {
+		t.Errorf("externalIdentityRepoImpl.GetByProviderSubject() error = %v, want %v", err, repository.ErrExternalIdentityNotFound)
+	}
+
+	if err := e.DeleteByUserID(context.Background(), userId); err != nil {
+		t.Fatalf("externalIdentityRepoImpl.DeleteByUserID() error = %v", err)
+	}
+	if _, err := e.GetByProviderSubject(context.Background(), entity.IdentityProviderGoogle, "108123456789"); !errors.Is(err, repository.ErrExternalIdentityNotFound) {
+		t.Errorf("externalIdentityRepoImpl.GetByProviderSubject() error = %v, want %v", err, repository.ErrExternalIdentityNotFound)
+	}
+}
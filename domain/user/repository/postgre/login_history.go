@@ -0,0 +1,56 @@
+package postgre
+
+import (
+	"context"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type loginHistoryRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewLoginHistoryRepoImpl(db *gorm.DB) repository.LoginHistory {
+	return &loginHistoryRepoImpl{
+		db: db,
+	}
+}
+
+func (l *loginHistoryRepoImpl) Create(ctx context.Context, attempt *entity.LoginAttempt) error {
+	return conn(ctx, l.db).Create(attempt).Error
+}
+
+func (l *loginHistoryRepoImpl) ListByUserID(ctx context.Context, userId uuid.UUID, page int, pageSize int) ([]*entity.LoginAttempt, int64, error) {
+	query := conn(ctx, l.db).Model(&entity.LoginAttempt{}).Where("user_id = ?", userId)
+	var total int64
+	// counted on a copy so the query can be reused for the page
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var attempts []*entity.LoginAttempt
+	err := query.Order("created_at DESC").Limit(pageSize).Offset((page - 1) * pageSize).Find(&attempts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return attempts, total, nil
+}
+
+func (l *loginHistoryRepoImpl) HasSucceeded(ctx context.Context, userId uuid.UUID, fingerprint string) (bool, error) {
+	query := conn(ctx, l.db).Model(&entity.LoginAttempt{}).Where("user_id = ? AND succeeded", userId)
+	if fingerprint != "" {
+		query = query.Where("device_fingerprint = ?", fingerprint)
+	}
+	var found []uuid.UUID
+	if err := query.Limit(1).Pluck("id", &found).Error; err != nil {
+		return false, err
+	}
+	return len(found) > 0, nil
+}
+
+func (l *loginHistoryRepoImpl) DeleteByUserID(ctx context.Context, userId uuid.UUID) error {
+	return conn(ctx, l.db).Where("user_id = ?", userId).Delete(&entity.LoginAttempt{}).Error
+}
@@ -0,0 +1,82 @@
+package postgre
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/google/uuid"
+)
+
+func Test_loginHistoryRepoImpl_HasSucceeded(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	l := &loginHistoryRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	known := entity.Device{Name: "Pixel 8", UserAgent: "okhttp/4.12"}
+	failed := entity.Device{Name: "Unknown", UserAgent: "curl/8.0"}
+	attempts := []*entity.LoginAttempt{
+		{UserId: userId, Method: entity.LoginMethodPassword, Succeeded: true, DeviceFingerprint: known.Fingerprint()},
+		{UserId: userId, Method: entity.LoginMethodPassword, FailureReason: "wrong_password", DeviceFingerprint: failed.Fingerprint()},
+	}
+	for _, attempt := range attempts {
+		if err := l.Create(context.Background(), attempt); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+	tests := []struct {
+		name        string
+		userId      uuid.UUID
+		fingerprint string
+		want        bool
+	}{
+		{
+			name:   "any device",
+			userId: userId,
+			want:   true,
+		},
+		{
+			name:        "known device",
+			userId:      userId,
+			fingerprint: known.Fingerprint(),
+			want:        true,
+		},
+		{
+			name:        "device of a failed attempt only",
+			userId:      userId,
+			fingerprint: failed.Fingerprint(),
+			want:        false,
+		},
+		{
+			name:   "other user",
+			userId: uuid.New(),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.HasSucceeded(context.Background(), tt.userId, tt.fingerprint)
+			if err != nil {
+				t.Errorf("loginHistoryRepoImpl.HasSucceeded() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("loginHistoryRepoImpl.HasSucceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	got, total, err := l.ListByUserID(context.Background(), userId, 1, 1)
+	if err != nil {
+		t.Errorf("loginHistoryRepoImpl.ListByUserID() error = %v", err)
+		return
+	}
+	if total != 2 || len(got) != 1 {
+		t.Errorf("loginHistoryRepoImpl.ListByUserID() = %v of %v, want 1 of 2", len(got), total)
+	}
+}
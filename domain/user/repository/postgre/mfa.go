@@ -0,0 +1,86 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mfaRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewMfaRepoImpl(db *gorm.DB) repository.Mfa {
+	return &mfaRepoImpl{
+		db: db,
+	}
+}
+
+func (m *mfaRepoImpl) GetByUserID(ctx context.Context, userId uuid.UUID) (*entity.UserMfa, error) {
+	var mfa *entity.UserMfa
+	if err := conn(ctx, m.db).Where("user_id = ?", userId).First(&mfa).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrMfaNotFound
+		}
+		return nil, err
+	}
+	return mfa, nil
+}
+
+func (m *mfaRepoImpl) Save(ctx context.Context, mfa *entity.UserMfa) error {
+	mfa.UpdatedAt = time.Now()
+	return conn(ctx, m.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "last_totp_step", "updated_at"}),
+	}).Create(mfa).Error
+}
+
+func (m *mfaRepoImpl) ReplaceRecoveryCodes(ctx context.Context, userId uuid.UUID, codes []*entity.MfaRecoveryCode) error {
+	db := conn(ctx, m.db)
+	if err := db.Where("user_id = ?", userId).Delete(&entity.MfaRecoveryCode{}).Error; err != nil {
+		return err
+	}
+	return db.Create(codes).Error
+}
+
+func (m *mfaRepoImpl) UseRecoveryCode(ctx context.Context, userId uuid.UUID, codeHash string) error {
+	res := conn(ctx, m.db).Model(&entity.MfaRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrRecoveryCodeInvalid
+	}
+	return nil
+}
+
+func (m *mfaRepoImpl) UseTotpStep(ctx context.Context, userId uuid.UUID, step int64) error {
+	// conditional update so concurrent logins with the same code cannot both succeed
+	res := conn(ctx, m.db).Model(&entity.UserMfa{}).
+		Where("user_id = ? AND last_totp_step < ?", userId, step).
+		Update("last_totp_step", step)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrTotpReplayed
+	}
+	return nil
+}
+
+func (m *mfaRepoImpl) DeleteByUserID(ctx context.Context, userId uuid.UUID) error {
+	db := conn(ctx, m.db)
+	if err := db.Where("user_id = ?", userId).Delete(&entity.MfaRecoveryCode{}).Error; err != nil {
+		return err
+	}
+	return db.Where("user_id = ?", userId).Delete(&entity.UserMfa{}).Error
+}
@@ -0,0 +1,150 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+)
+
+func Test_mfaRepoImpl_Save(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	m := &mfaRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	if _, err := m.GetByUserID(context.Background(), userId); !errors.Is(err, repository.ErrMfaNotFound) {
+		t.Errorf("mfaRepoImpl.GetByUserID() error = %v, want %v", err, repository.ErrMfaNotFound)
+	}
+	for _, secret := range []string{"first", "second"} {
+		if err := m.Save(context.Background(), &entity.UserMfa{UserId: userId, Secret: secret}); err != nil {
+			t.Errorf("mfaRepoImpl.Save() error = %v", err)
+			return
+		}
+	}
+	got, err := m.GetByUserID(context.Background(), userId)
+	if err != nil {
+		t.Errorf("mfaRepoImpl.GetByUserID() error = %v", err)
+		return
+	}
+	if got.Secret != "second" {
+		t.Errorf("mfaRepoImpl.GetByUserID() secret = %v, want %v", got.Secret, "second")
+	}
+}
+
+func Test_mfaRepoImpl_UseRecoveryCode(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	m := &mfaRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	if err := m.ReplaceRecoveryCodes(context.Background(), userId, []*entity.MfaRecoveryCode{{UserId: userId, CodeHash: "old"}}); err != nil {
+		log.Fatal(err.Error())
+	}
+	if err := m.ReplaceRecoveryCodes(context.Background(), userId, []*entity.MfaRecoveryCode{{UserId: userId, CodeHash: "new"}}); err != nil {
+		log.Fatal(err.Error())
+	}
+	tests := []struct {
+		name     string
+		userId   uuid.UUID
+		codeHash string
+		wantErr  error
+	}{
+		{
+			name:     "replaced code",
+			userId:   userId,
+			codeHash: "old",
+			wantErr:  repository.ErrRecoveryCodeInvalid,
+		},
+		{
+			name:     "code of another user",
+			userId:   uuid.New(),
+			codeHash: "new",
+			wantErr:  repository.ErrRecoveryCodeInvalid,
+		},
+		{
+			name:     "success",
+			userId:   userId,
+			codeHash: "new",
+		},
+		{
+			name:     "code already used",
+			userId:   userId,
+			codeHash: "new",
+			wantErr:  repository.ErrRecoveryCodeInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := m.UseRecoveryCode(context.Background(), tt.userId, tt.codeHash); !errors.Is(err, tt.wantErr) {
+				t.Errorf("mfaRepoImpl.UseRecoveryCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_mfaRepoImpl_UseTotpStep(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	m := &mfaRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	if err := m.Save(context.Background(), &entity.UserMfa{UserId: userId, Secret: "secret"}); err != nil {
+		log.Fatal(err.Error())
+	}
+	tests := []struct {
+		name    string
+		userId  uuid.UUID
+		step    int64
+		wantErr error
+	}{
+		{
+			name:   "success",
+			userId: userId,
+			step:   100,
+		},
+		{
+			name:    "same step replayed",
+			userId:  userId,
+			step:    100,
+			wantErr: repository.ErrTotpReplayed,
+		},
+		{
+			name:    "older step",
+			userId:  userId,
+			step:    99,
+			wantErr: repository.ErrTotpReplayed,
+		},
+		{
+			name:    "user not enrolled",
+			userId:  uuid.New(),
+			step:    100,
+			wantErr: repository.ErrTotpReplayed,
+		},
+		{
+			name:   "next step",
+			userId: userId,
+			step:   101,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := m.UseTotpStep(context.Background(), tt.userId, tt.step); !errors.Is(err, tt.wantErr) {
+				t.Errorf("mfaRepoImpl.UseTotpStep() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+
+	"gorm.io/gorm"
+)
+
+type oauthClientRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepoImpl(db *gorm.DB) repository.OAuthClient {
+	return &oauthClientRepoImpl{
+		db: db,
+	}
+}
+
+func (o *oauthClientRepoImpl) GetByID(ctx context.Context, id string) (*entity.OAuthClient, error) {
+	var client *entity.OAuthClient
+	err := conn(ctx, o.db).Where("id = ? AND is_active", id).First(&client).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repository.ErrOAuthClientNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (o *oauthClientRepoImpl) Create(ctx context.Context, client *entity.OAuthClient) error {
+	return conn(ctx, o.db).Create(client).Error
+}
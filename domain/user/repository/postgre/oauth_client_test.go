@@ -0,0 +1,44 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+)
+
+func Test_oauthClientRepoImpl(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	o := &oauthClientRepoImpl{
+		db: db,
+	}
+	redirectUris := []string{"https://app.mitra.test/callback", "mitra://callback"}
+	client := &entity.OAuthClient{Id: uuid.NewString(), Name: "Mitra", RedirectUris: redirectUris, IsActive: true, CreatedBy: uuid.New()}
+	if err := o.Create(context.Background(), client); err != nil {
+		t.Fatalf("oauthClientRepoImpl.Create() error = %v", err)
+	}
+
+	got, err := o.GetByID(context.Background(), client.Id)
+	if err != nil || got.Name != client.Name || !reflect.DeepEqual([]string(got.RedirectUris), redirectUris) {
+		t.Errorf("oauthClientRepoImpl.GetByID() = %v, %v, want %v", got, err, client)
+	}
+	if _, err := o.GetByID(context.Background(), "unknown"); !errors.Is(err, repository.ErrOAuthClientNotFound) {
+		t.Errorf("oauthClientRepoImpl.GetByID() error = %v, want %v", err, repository.ErrOAuthClientNotFound)
+	}
+
+	// disabled clients cannot sign users in
+	if err := db.Model(client).Update("is_active", false).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.GetByID(context.Background(), client.Id); !errors.Is(err, repository.ErrOAuthClientNotFound) {
+		t.Errorf("oauthClientRepoImpl.GetByID() error = %v, want %v", err, repository.ErrOAuthClientNotFound)
+	}
+}
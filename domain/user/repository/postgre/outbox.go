@@ -0,0 +1,60 @@
+package postgre
+
+import (
+	"context"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type outboxRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepoImpl(db *gorm.DB) repository.Outbox {
+	return &outboxRepoImpl{
+		db: db,
+	}
+}
+
+func (o *outboxRepoImpl) Create(ctx context.Context, message *entity.OutboxMessage) error {
+	return conn(ctx, o.db).Create(message).Error
+}
+
+// ClaimDue pushes next_attempt_at of the claimed rows past the lease, rows locked by
+// another dispatcher are skipped so several replicas can poll the same table
+func (o *outboxRepoImpl) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entity.OutboxMessage, error) {
+	var messages []*entity.OutboxMessage
+	now := time.Now()
+	err := conn(ctx, o.db).Raw(`UPDATE outbox_messages SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox_messages
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED)
+		RETURNING *`,
+		now.Add(lease), entity.OutboxStatusPending, now, limit).Scan(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// DeleteByUser matches otps and notices on the recipient, the pending events of userId are kept
+// as they announce the deletion itself
+func (o *outboxRepoImpl) DeleteByUser(ctx context.Context, userId uuid.UUID, email string, phoneNumber string) error {
+	return conn(ctx, o.db).
+		Where("topic IN ? AND ((? <> '' AND payload->>'Email' = ?) OR (? <> '' AND payload->>'PhoneNumber' = ?))",
+			[]string{entity.OutboxTopicOtp, entity.OutboxTopicNotice}, email, email, phoneNumber, phoneNumber).
+		Or("topic = ? AND status <> ? AND payload->>'UserId' = ?", entity.OutboxTopicEvent, entity.OutboxStatusPending, userId.String()).
+		Delete(&entity.OutboxMessage{}).Error
+}
+
+func (o *outboxRepoImpl) Save(ctx context.Context, message *entity.OutboxMessage) error {
+	return conn(ctx, o.db).Save(message).Error
+}
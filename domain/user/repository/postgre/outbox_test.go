@@ -0,0 +1,149 @@
+package postgre
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/google/uuid"
+)
+
+func Test_outboxRepoImpl_ClaimDue(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	o := &outboxRepoImpl{
+		db: db,
+	}
+	due, err := entity.NewOutboxMessage(entity.OutboxTopicOtp, entity.OtpNotification{Email: "test1@mail.com"})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	later, err := entity.NewOutboxMessage(entity.OutboxTopicOtp, entity.OtpNotification{Email: "test2@mail.com"})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	later.NextAttemptAt = time.Now().Add(time.Hour)
+	for _, m := range []*entity.OutboxMessage{due, later} {
+		if err := o.Create(context.Background(), m); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
+	tests := []struct {
+		name      string
+		wantCount int
+	}{
+		{
+			name:      "claim due message",
+			wantCount: 1,
+		},
+		{
+			name:      "claimed message hidden for the lease",
+			wantCount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.ClaimDue(context.Background(), 10, time.Minute)
+			if err != nil {
+				t.Errorf("outboxRepoImpl.ClaimDue() error = %v", err)
+				return
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("outboxRepoImpl.ClaimDue() = %v messages, want %v", len(got), tt.wantCount)
+				return
+			}
+			for _, m := range got {
+				if m.Id != due.Id {
+					t.Errorf("outboxRepoImpl.ClaimDue() claimed %v, want %v", m.Id, due.Id)
+				}
+			}
+		})
+	}
+}
+
+func Test_transactor_WithinTransaction(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	tr := NewTransactor(db)
+	o := &outboxRepoImpl{
+		db: db,
+	}
+	message, err := entity.NewOutboxMessage(entity.OutboxTopicOtp, entity.OtpNotification{Email: "test1@mail.com"})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = tr.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := o.Create(ctx, message); err != nil {
+			return err
+		}
+		return context.Canceled
+	})
+	if err != context.Canceled {
+		t.Errorf("transactor.WithinTransaction() error = %v, want %v", err, context.Canceled)
+	}
+	var count int64
+	db.Model(&entity.OutboxMessage{}).Count(&count)
+	if count != 0 {
+		t.Errorf("transactor.WithinTransaction() kept %v messages after rollback, want 0", count)
+	}
+}
+
+func Test_outboxRepoImpl_DeleteByUser(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	o := &outboxRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	otherId := uuid.New()
+	newMessage := func(topic string, payload interface{}, status string) *entity.OutboxMessage {
+		m, err := entity.NewOutboxMessage(topic, payload)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		m.Status = status
+		if err := o.Create(context.Background(), m); err != nil {
+			log.Fatal(err.Error())
+		}
+		return m
+	}
+	otp := newMessage(entity.OutboxTopicOtp, entity.OtpNotification{Email: "deleted@mail.com", OtpCode: 1234}, entity.OutboxStatusSent)
+	sms := newMessage(entity.OutboxTopicOtp, entity.OtpNotification{PhoneNumber: "08123456789", OtpCode: 1234}, entity.OutboxStatusPending)
+	notice := newMessage(entity.OutboxTopicNotice, entity.Notice{Email: "deleted@mail.com"}, entity.OutboxStatusDead)
+	sent := newMessage(entity.OutboxTopicEvent, entity.DomainEvent{UserId: userId}, entity.OutboxStatusSent)
+	pending := newMessage(entity.OutboxTopicEvent, entity.DomainEvent{UserId: userId}, entity.OutboxStatusPending)
+	other := newMessage(entity.OutboxTopicOtp, entity.OtpNotification{Email: "other@mail.com"}, entity.OutboxStatusSent)
+	otherEvent := newMessage(entity.OutboxTopicEvent, entity.DomainEvent{UserId: otherId}, entity.OutboxStatusSent)
+
+	if err := o.DeleteByUser(context.Background(), userId, "deleted@mail.com", "08123456789"); err != nil {
+		t.Fatalf("outboxRepoImpl.DeleteByUser() error = %v", err)
+	}
+	for _, tt := range []struct {
+		message  *entity.OutboxMessage
+		wantKept bool
+	}{
+		{message: otp},
+		{message: sms},
+		{message: notice},
+		{message: sent},
+		{message: pending, wantKept: true},
+		{message: other, wantKept: true},
+		{message: otherEvent, wantKept: true},
+	} {
+		var count int64
+		db.Model(&entity.OutboxMessage{}).Where("id = ?", tt.message.Id).Count(&count)
+		if (count == 1) != tt.wantKept {
+			t.Errorf("outboxRepoImpl.DeleteByUser() kept %s %s message = %v, want %v", tt.message.Status, tt.message.Topic, count == 1, tt.wantKept)
+		}
+	}
+}
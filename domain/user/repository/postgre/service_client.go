@@ -0,0 +1,60 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type serviceClientRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewServiceClientRepoImpl(db *gorm.DB) repository.ServiceClient {
+	return &serviceClientRepoImpl{
+		db: db,
+	}
+}
+
+func (s *serviceClientRepoImpl) GetByKeyPrefix(ctx context.Context, prefix string) (*entity.ServiceClient, error) {
+	var client *entity.ServiceClient
+	err := conn(ctx, s.db).Where("key_prefix = ?", prefix).First(&client).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repository.ErrServiceClientNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (s *serviceClientRepoImpl) Create(ctx context.Context, client *entity.ServiceClient) error {
+	return conn(ctx, s.db).Create(client).Error
+}
+
+func (s *serviceClientRepoImpl) List(ctx context.Context) ([]*entity.ServiceClient, error) {
+	var clients []*entity.ServiceClient
+	if err := conn(ctx, s.db).Order("created_at DESC").Find(&clients).Error; err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+func (s *serviceClientRepoImpl) Revoke(ctx context.Context, id uuid.UUID) error {
+	res := conn(ctx, s.db).Model(&entity.ServiceClient{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrServiceClientNotFound
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+)
+
+func Test_serviceClientRepoImpl(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	s := &serviceClientRepoImpl{
+		db: db,
+	}
+	client := &entity.ServiceClient{Name: "order", KeyPrefix: "0123456789abcdef", KeyHash: "hash", Scopes: []string{entity.ScopeUsersRead}, CreatedBy: uuid.New()}
+	if err := s.Create(context.Background(), client); err != nil {
+		t.Fatalf("serviceClientRepoImpl.Create() error = %v", err)
+	}
+	if err := s.Create(context.Background(), &entity.ServiceClient{Name: "other", KeyPrefix: client.KeyPrefix, KeyHash: "hash", Scopes: client.Scopes, CreatedBy: uuid.New()}); err == nil {
+		t.Errorf("serviceClientRepoImpl.Create() error = nil, want duplicate key prefix")
+	}
+
+	got, err := s.GetByKeyPrefix(context.Background(), client.KeyPrefix)
+	if err != nil || got.Id != client.Id || !got.HasScope(entity.ScopeUsersRead) {
+		t.Errorf("serviceClientRepoImpl.GetByKeyPrefix() = %v, %v, want %v", got, err, client)
+	}
+	if _, err := s.GetByKeyPrefix(context.Background(), "unknown"); !errors.Is(err, repository.ErrServiceClientNotFound) {
+		t.Errorf("serviceClientRepoImpl.GetByKeyPrefix() error = %v, want %v", err, repository.ErrServiceClientNotFound)
+	}
+
+	if err := s.Revoke(context.Background(), client.Id); err != nil {
+		t.Fatalf("serviceClientRepoImpl.Revoke() error = %v", err)
+	}
+	if err := s.Revoke(context.Background(), client.Id); !errors.Is(err, repository.ErrServiceClientNotFound) {
+		t.Errorf("serviceClientRepoImpl.Revoke() error = %v, want %v", err, repository.ErrServiceClientNotFound)
+	}
+	clients, err := s.List(context.Background())
+	if err != nil || len(clients) == 0 || !clients[0].IsRevoked() {
+		t.Errorf("serviceClientRepoImpl.List() = %v, %v, want the revoked client", clients, err)
+	}
+}
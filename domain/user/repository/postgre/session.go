@@ -0,0 +1,98 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type sessionRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewSessionRepoImpl(db *gorm.DB) repository.Session {
+	return &sessionRepoImpl{
+		db: db,
+	}
+}
+
+func (s *sessionRepoImpl) Create(ctx context.Context, session *entity.Session) error {
+	return conn(ctx, s.db).Create(session).Error
+}
+
+func (s *sessionRepoImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.Session, error) {
+	var session *entity.Session
+	if err := conn(ctx, s.db).Where("id = ?", id).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *sessionRepoImpl) ListActiveByUserID(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	err := conn(ctx, s.db).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userId, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *sessionRepoImpl) ListByUserID(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	if err := conn(ctx, s.db).Where("user_id = ?", userId).Order("created_at DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *sessionRepoImpl) Rotate(ctx context.Context, session *entity.Session, previousRefreshTokenId uuid.UUID) error {
+	res := conn(ctx, s.db).Model(&entity.Session{}).
+		Where("id = ? AND refresh_token_id = ? AND revoked_at IS NULL", session.Id, previousRefreshTokenId).
+		Updates(map[string]interface{}{
+			"refresh_token_id": session.RefreshTokenId,
+			"last_seen_at":     session.LastSeenAt,
+			"expires_at":       session.ExpiresAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *sessionRepoImpl) Revoke(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	res := conn(ctx, s.db).Model(&entity.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userId).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *sessionRepoImpl) RevokeAllByUserID(ctx context.Context, userId uuid.UUID) error {
+	return conn(ctx, s.db).Model(&entity.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (s *sessionRepoImpl) DeleteByUserID(ctx context.Context, userId uuid.UUID) error {
+	return conn(ctx, s.db).Where("user_id = ?", userId).Delete(&entity.Session{}).Error
+}
@@ -0,0 +1,127 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
+)
+
+func newTestSession(userId uuid.UUID, expiresAt time.Time) *entity.Session {
+	return &entity.Session{
+		UserId:         userId,
+		DeviceName:     "test",
+		RefreshTokenId: uuid.New(),
+		ExpiresAt:      expiresAt,
+	}
+}
+
+func Test_sessionRepoImpl_ListActiveByUserID(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	s := &sessionRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	active := newTestSession(userId, time.Now().Add(time.Hour))
+	expired := newTestSession(userId, time.Now().Add(-time.Hour))
+	revoked := newTestSession(userId, time.Now().Add(time.Hour))
+	other := newTestSession(uuid.New(), time.Now().Add(time.Hour))
+	for _, session := range []*entity.Session{active, expired, revoked, other} {
+		if err := s.Create(context.Background(), session); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+	if err := s.Revoke(context.Background(), userId, revoked.Id); err != nil {
+		t.Errorf("sessionRepoImpl.Revoke() error = %v", err)
+	}
+
+	got, err := s.ListActiveByUserID(context.Background(), userId)
+	if err != nil {
+		t.Errorf("sessionRepoImpl.ListActiveByUserID() error = %v", err)
+		return
+	}
+	if len(got) != 1 || got[0].Id != active.Id {
+		t.Errorf("sessionRepoImpl.ListActiveByUserID() = %v, want only session %v", got, active.Id)
+	}
+}
+
+func Test_sessionRepoImpl_Revoke(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	s := &sessionRepoImpl{
+		db: db,
+	}
+	userId := uuid.New()
+	session := newTestSession(userId, time.Now().Add(time.Hour))
+	if err := s.Create(context.Background(), session); err != nil {
+		log.Fatal(err.Error())
+	}
+	tests := []struct {
+		name    string
+		userId  uuid.UUID
+		id      uuid.UUID
+		wantErr error
+	}{
+		{
+			name:    "session of another user",
+			userId:  uuid.New(),
+			id:      session.Id,
+			wantErr: repository.ErrSessionNotFound,
+		},
+		{
+			name:   "success",
+			userId: userId,
+			id:     session.Id,
+		},
+		{
+			name:    "already revoked",
+			userId:  userId,
+			id:      session.Id,
+			wantErr: repository.ErrSessionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Revoke(context.Background(), tt.userId, tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("sessionRepoImpl.Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_sessionRepoImpl_Rotate(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	s := &sessionRepoImpl{
+		db: db,
+	}
+	session := newTestSession(uuid.New(), time.Now().Add(time.Hour))
+	if err := s.Create(context.Background(), session); err != nil {
+		log.Fatal(err.Error())
+	}
+	previous := session.RefreshTokenId
+	session.RefreshTokenId = uuid.New()
+	if err := s.Rotate(context.Background(), session, previous); err != nil {
+		t.Errorf("sessionRepoImpl.Rotate() error = %v", err)
+	}
+	// the same refresh token cannot be rotated twice
+	if err := s.Rotate(context.Background(), session, previous); !errors.Is(err, repository.ErrSessionNotFound) {
+		t.Errorf("sessionRepoImpl.Rotate() error = %v, want %v", err, repository.ErrSessionNotFound)
+	}
+	got, err := s.GetByID(context.Background(), session.Id)
+	if err != nil || got.RefreshTokenId != session.RefreshTokenId {
+		t.Errorf("sessionRepoImpl.GetByID() = %v, %v, want refresh token id %v", got, err, session.RefreshTokenId)
+	}
+}
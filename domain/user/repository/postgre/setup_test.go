@@ -47,8 +47,8 @@ func LocalDatabase() (*gorm.DB, error) {
 
 	// db.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";")
 
-	db.Migrator().DropTable("user_roles", &entity.Role{}, &entity.User{})
-	db.AutoMigrate(&entity.User{}, &entity.Role{})
+	db.Migrator().DropTable("user_roles", &entity.Role{}, &entity.User{}, &entity.OutboxMessage{}, &entity.UserMfa{}, &entity.MfaRecoveryCode{}, &entity.Session{}, &entity.AuditEvent{}, &entity.LoginAttempt{}, &entity.ExternalIdentity{}, &entity.OAuthClient{}, &entity.ServiceClient{})
+	db.AutoMigrate(&entity.User{}, &entity.Role{}, &entity.OutboxMessage{}, &entity.UserMfa{}, &entity.MfaRecoveryCode{}, &entity.Session{}, &entity.AuditEvent{}, &entity.LoginAttempt{}, &entity.ExternalIdentity{}, &entity.OAuthClient{}, &entity.ServiceClient{})
 
 	return db, nil
 }
@@ -56,9 +56,10 @@ func LocalDatabase() (*gorm.DB, error) {
 func seedUser(db *gorm.DB) ([]*entity.User, error) {
 	var users []*entity.User
 	user := &entity.User{
-		Name:     "name1",
-		Email:    "test1@mail.com",
-		Password: "password1",
+		Name:        "name1",
+		Email:       "test1@mail.com",
+		Password:    "password1",
+		PhoneNumber: "081234567891",
 	}
 	err := db.Create(user).Error
 	if err != nil {
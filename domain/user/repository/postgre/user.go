@@ -2,7 +2,7 @@ package postgre
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
@@ -23,7 +23,7 @@ func NewUserRepoImpl(db *gorm.DB) repository.User {
 
 func (p *userRepoImpl) GetAll(ctx context.Context) ([]*entity.User, error) {
 	var accounts []*entity.User
-	if err := p.db.Order("created_at DESC").Find(&accounts).Error; err != nil {
+	if err := conn(ctx, p.db).Order("created_at DESC").Find(&accounts).Error; err != nil {
 		return nil, err
 	}
 	return accounts, nil
@@ -31,30 +31,49 @@ func (p *userRepoImpl) GetAll(ctx context.Context) ([]*entity.User, error) {
 
 func (p *userRepoImpl) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user *entity.User
-	if err := p.db.Preload("Roles").Where("email = ?", email).First(&user).Error; err != nil {
-		return nil, err
+	if err := conn(ctx, p.db).Preload("Roles").Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, translateUserError(err)
 	}
 	return user, nil
 }
 
 func (p *userRepoImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	var user *entity.User
-	if err := p.db.Preload("Roles").Where("id = ?", id).First(&user).Error; err != nil {
-		return nil, err
+	if err := conn(ctx, p.db).Preload("Roles").Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, translateUserError(err)
+	}
+	return user, nil
+}
+
+func (p *userRepoImpl) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error) {
+	var user *entity.User
+	if err := conn(ctx, p.db).Preload("Roles").Where("phone_number = ?", phoneNumber).First(&user).Error; err != nil {
+		return nil, translateUserError(err)
 	}
 	return user, nil
 }
 
+func (p *userRepoImpl) GetSummariesByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.UserSummary, error) {
+	var summaries []*entity.UserSummary
+	err := conn(ctx, p.db).Model(&entity.User{}).
+		Select("id", "name", "email", "avatar_image_id", "is_active").
+		Where("id IN ?", ids).
+		Find(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
 func (p *userRepoImpl) Create(ctx context.Context, user *entity.User, roleIds []string) error {
-	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, p.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(user).Error; err != nil {
-			return err
+			return translateUserError(err)
 		}
 		for _, roleId := range roleIds {
-			fmt.Println(roleId)
 			if err := tx.Exec("Insert into user_roles (user_id,role_id) values (?,?)",
 				user.Id, roleId).Error; err != nil {
-				return err
+				return translateUserRoleError(err)
 			}
 		}
 		return nil
@@ -62,18 +81,162 @@ func (p *userRepoImpl) Create(ctx context.Context, user *entity.User, roleIds []
 	return err
 }
 
+func (p *userRepoImpl) ReplaceRoles(ctx context.Context, id uuid.UUID, roleIds []string, updatedBy uuid.UUID) error {
+	return conn(ctx, p.db).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.User{}).Where("id = ? AND anonymized_at IS NULL", id).Updates(map[string]interface{}{
+			"updated_at": time.Now(),
+			"updated_by": uuid.NullUUID{UUID: updatedBy, Valid: true},
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return repository.ErrUserNotFound
+		}
+		if err := tx.Exec("Delete from user_roles where user_id = ?", id).Error; err != nil {
+			return err
+		}
+		for _, roleId := range roleIds {
+			if err := tx.Exec("Insert into user_roles (user_id,role_id) values (?,?)",
+				id, roleId).Error; err != nil {
+				return translateUserRoleError(err)
+			}
+		}
+		return nil
+	})
+}
+
 func (p *userRepoImpl) Save(ctx context.Context, user *entity.User) error {
-	return p.db.WithContext(ctx).Save(user).Error
+	db := conn(ctx, p.db)
+	if user.PhoneNumber == "" {
+		// kept NULL, an empty phone number would collide with the other users having none
+		db = db.Omit("phone_number")
+	}
+	return translateUserError(db.Save(user).Error)
 }
 
 func (p *userRepoImpl) VerifyUserByEmail(ctx context.Context, email string) (bool, error) {
 	var user *entity.User
 	updatedFields := map[string]interface{}{
 		"is_verified": true,
+		"updated_at":  time.Now(),
+		// verified by the owner of the email through the otp
+		"updated_by": gorm.Expr("id"),
 	}
-	res := p.db.Model(user).Where("email = ?", email).Updates(updatedFields)
+	res := conn(ctx, p.db).Model(user).Where("email = ?", email).Updates(updatedFields)
 	if res.Error != nil {
 		return false, res.Error
 	}
 	return true, nil
 }
+
+func (p *userRepoImpl) VerifyPhoneNumber(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, p.db).Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"is_phone_verified": true,
+		"updated_at":        time.Now(),
+		"updated_by":        uuid.NullUUID{UUID: id, Valid: true},
+	}).Error
+}
+
+func (p *userRepoImpl) UpdateEmail(ctx context.Context, id uuid.UUID, email string) error {
+	res := conn(ctx, p.db).Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email":      email,
+		"username":   email,
+		"updated_at": time.Now(),
+		"updated_by": uuid.NullUUID{UUID: id, Valid: true},
+	})
+	if res.Error != nil {
+		return translateUserError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
+func (p *userRepoImpl) EnableMfa(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, p.db).Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"is_mfa_enabled": true,
+		"updated_at":     time.Now(),
+		"updated_by":     uuid.NullUUID{UUID: id, Valid: true},
+	}).Error
+}
+
+func (p *userRepoImpl) SetActive(ctx context.Context, id uuid.UUID, active bool, updatedBy uuid.UUID) error {
+	fields := map[string]interface{}{
+		"is_active":  active,
+		"updated_at": time.Now(),
+		"updated_by": uuid.NullUUID{UUID: updatedBy, Valid: true},
+	}
+	if active {
+		fields["deletion_requested_at"] = nil
+	}
+	res := conn(ctx, p.db).Model(&entity.User{}).Where("id = ? AND anonymized_at IS NULL", id).Updates(fields)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
+func (p *userRepoImpl) RequestDeletion(ctx context.Context, id uuid.UUID, requestedAt time.Time) error {
+	res := conn(ctx, p.db).Model(&entity.User{}).Where("id = ? AND anonymized_at IS NULL", id).Updates(map[string]interface{}{
+		"is_active":             false,
+		"deletion_requested_at": requestedAt,
+		"updated_at":            time.Now(),
+		"updated_by":            uuid.NullUUID{UUID: id, Valid: true},
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
+func (p *userRepoImpl) ListDeletionDue(ctx context.Context, requestedBefore time.Time, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	err := conn(ctx, p.db).
+		Where("deletion_requested_at <= ? AND anonymized_at IS NULL", requestedBefore).
+		Order("deletion_requested_at").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (p *userRepoImpl) Anonymize(ctx context.Context, id uuid.UUID) error {
+	// unique columns get a placeholder derived from the id
+	placeholder := "deleted-" + id.String()
+	now := time.Now()
+	// a deletion cancelled meanwhile leaves the user untouched
+	res := conn(ctx, p.db).Model(&entity.User{}).Where("id = ? AND deletion_requested_at IS NOT NULL AND anonymized_at IS NULL", id).Updates(map[string]interface{}{
+		"username":              placeholder,
+		"email":                 placeholder + "@deleted.invalid",
+		"phone_number":          placeholder,
+		"password":              "",
+		"name":                  "Deleted user",
+		"address":               "",
+		"avatar_image_id":       nil,
+		"access_token":          nil,
+		"is_active":             false,
+		"is_verified":           false,
+		"is_phone_verified":     false,
+		"is_mfa_enabled":        false,
+		"deletion_requested_at": nil,
+		"anonymized_at":         now,
+		"updated_at":            now,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package postgre
 
 import (
 	"context"
+	"errors"
 	"log"
 	"reflect"
 	"testing"
@@ -378,3 +379,285 @@ func Test_userRepoImpl_VerifyUserByEmail(t *testing.T) {
 		})
 	}
 }
+
+func Test_userRepoImpl_GetByPhoneNumber(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	user, err := seedUser(db)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	type args struct {
+		ctx         context.Context
+		phoneNumber string
+	}
+	tests := []struct {
+		name    string
+		p       *userRepoImpl
+		args    args
+		want    *entity.User
+		wantErr error
+	}{
+		{
+			name: "error not found",
+			p: &userRepoImpl{
+				db: db,
+			},
+			args: args{
+				ctx:         context.Background(),
+				phoneNumber: "089999999999",
+			},
+			want:    nil,
+			wantErr: repository.ErrUserNotFound,
+		},
+		{
+			name: "success",
+			p: &userRepoImpl{
+				db: db,
+			},
+			args: args{
+				ctx:         context.Background(),
+				phoneNumber: user[0].PhoneNumber,
+			},
+			want:    user[0],
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.GetByPhoneNumber(tt.args.ctx, tt.args.phoneNumber)
+			if err != tt.wantErr {
+				t.Errorf("userRepoImpl.GetByPhoneNumber() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil && got.Id != tt.want.Id {
+				t.Errorf("userRepoImpl.GetByPhoneNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_userRepoImpl_VerifyPhoneNumber(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	user, err := seedUser(db)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	p := &userRepoImpl{
+		db: db,
+	}
+	if err := p.VerifyPhoneNumber(context.Background(), user[0].Id); err != nil {
+		t.Errorf("userRepoImpl.VerifyPhoneNumber() error = %v", err)
+		return
+	}
+	got, err := p.GetByID(context.Background(), user[0].Id)
+	if err != nil {
+		t.Errorf("userRepoImpl.GetByID() error = %v", err)
+		return
+	}
+	if !got.IsPhoneVerified {
+		t.Errorf("userRepoImpl.VerifyPhoneNumber() IsPhoneVerified = %v, want true", got.IsPhoneVerified)
+	}
+}
+
+func Test_userRepoImpl_GetSummariesByIDs(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	user, err := seedUser(db)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	p := &userRepoImpl{
+		db: db,
+	}
+	got, err := p.GetSummariesByIDs(context.Background(), []uuid.UUID{user[0].Id, uuid.New()})
+	if err != nil {
+		t.Fatalf("userRepoImpl.GetSummariesByIDs() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Id != user[0].Id || got[0].Name != user[0].Name || got[0].Email != user[0].Email || !got[0].IsActive {
+		t.Errorf("userRepoImpl.GetSummariesByIDs() = %v, want summary of %v", got, user[0].Id)
+	}
+}
+
+func Test_userRepoImpl_ReplaceRoles(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	user, err := seedUser(db)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	if _, err := seedRole(db); err != nil {
+		log.Fatal(err.Error())
+	}
+	p := &userRepoImpl{
+		db: db,
+	}
+	adminId := uuid.New()
+	if err := p.ReplaceRoles(context.Background(), user[0].Id, []string{"1", "2"}, adminId); err != nil {
+		t.Fatalf("userRepoImpl.ReplaceRoles() error = %v", err)
+	}
+	if err := p.ReplaceRoles(context.Background(), user[0].Id, []string{"2"}, adminId); err != nil {
+		t.Fatalf("userRepoImpl.ReplaceRoles() error = %v", err)
+	}
+	got, err := p.GetByID(context.Background(), user[0].Id)
+	if err != nil {
+		t.Fatalf("userRepoImpl.GetByID() error = %v", err)
+	}
+	if len(got.Roles) != 1 || got.Roles[0].ID != 2 || got.UpdatedBy.UUID != adminId {
+		t.Errorf("userRepoImpl.ReplaceRoles() left roles %v updated by %v, want role 2 updated by %v", got.Roles, got.UpdatedBy, adminId)
+	}
+	if err := p.ReplaceRoles(context.Background(), user[0].Id, []string{"99"}, adminId); !errors.Is(err, repository.ErrRoleNotFound) {
+		t.Errorf("userRepoImpl.ReplaceRoles() error = %v, want %v", err, repository.ErrRoleNotFound)
+	}
+	if err := p.ReplaceRoles(context.Background(), uuid.New(), []string{"1"}, adminId); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("userRepoImpl.ReplaceRoles() error = %v, want %v", err, repository.ErrUserNotFound)
+	}
+}
+
+func Test_userRepoImpl_UpdateEmail(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	user, err := seedUser(db)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	other := &entity.User{
+		Name:        "name2",
+		Username:    "test2@mail.com",
+		Email:       "test2@mail.com",
+		Password:    "password2",
+		PhoneNumber: "081234567892",
+	}
+	if err := db.Create(other).Error; err != nil {
+		log.Fatal(err.Error())
+	}
+	p := &userRepoImpl{
+		db: db,
+	}
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		email   string
+		wantErr error
+	}{
+		{
+			name:    "email used by another user",
+			id:      user[0].Id,
+			email:   other.Email,
+			wantErr: repository.ErrDuplicateEmail,
+		},
+		{
+			name:    "user not found",
+			id:      uuid.New(),
+			email:   "test3@mail.com",
+			wantErr: repository.ErrUserNotFound,
+		},
+		{
+			name:  "success",
+			id:    user[0].Id,
+			email: "test3@mail.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.UpdateEmail(context.Background(), tt.id, tt.email)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userRepoImpl.UpdateEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, err := p.GetByID(context.Background(), tt.id)
+			if err != nil {
+				t.Errorf("userRepoImpl.GetByID() error = %v", err)
+				return
+			}
+			if got.Email != tt.email || got.Username != tt.email || got.UpdatedBy.UUID != tt.id {
+				t.Errorf("userRepoImpl.UpdateEmail() = %v, want email and username %v", got, tt.email)
+			}
+		})
+	}
+}
+
+func Test_userRepoImpl_Anonymize(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	user := &entity.User{
+		Name:        "to delete",
+		Username:    "delete@mail.com",
+		Email:       "delete@mail.com",
+		Password:    "password",
+		PhoneNumber: "081234567899",
+	}
+	if err := db.Create(user).Error; err != nil {
+		log.Fatal(err.Error())
+	}
+	p := &userRepoImpl{
+		db: db,
+	}
+	// a user who did not ask for deletion is left untouched
+	if err := p.Anonymize(context.Background(), user.Id); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("userRepoImpl.Anonymize() error = %v, want %v", err, repository.ErrUserNotFound)
+	}
+	requestedAt := time.Now().Add(-time.Hour)
+	if err := p.RequestDeletion(context.Background(), user.Id, requestedAt); err != nil {
+		t.Fatalf("userRepoImpl.RequestDeletion() error = %v", err)
+	}
+	due, err := p.ListDeletionDue(context.Background(), time.Now(), 10)
+	if err != nil || len(due) != 1 || due[0].Id != user.Id || due[0].IsActive {
+		t.Errorf("userRepoImpl.ListDeletionDue() = %v, %v, want inactive user %v", due, err, user.Id)
+	}
+	if err := p.Anonymize(context.Background(), user.Id); err != nil {
+		t.Fatalf("userRepoImpl.Anonymize() error = %v", err)
+	}
+	got, err := p.GetByID(context.Background(), user.Id)
+	if err != nil {
+		t.Fatalf("userRepoImpl.GetByID() error = %v", err)
+	}
+	if got.Email == user.Email || got.PhoneNumber == user.PhoneNumber || got.Name == user.Name || got.AnonymizedAt == nil {
+		t.Errorf("userRepoImpl.Anonymize() left %v, want personal data scrubbed", got)
+	}
+	// an anonymized user cannot be reactivated
+	if err := p.SetActive(context.Background(), user.Id, true, uuid.New()); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("userRepoImpl.SetActive() error = %v, want %v", err, repository.ErrUserNotFound)
+	}
+}
+
+func Test_userRepoImpl_withoutPhoneNumber(t *testing.T) {
+	db, err := DBConn()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	p := &userRepoImpl{
+		db: db,
+	}
+	// users created by a social login have no phone number, several of them can exist
+	for _, email := range []string{"social1@gmail.com", "social2@gmail.com"} {
+		user := &entity.User{Name: "social", Username: email, Email: email}
+		if err := p.Create(context.Background(), user, nil); err != nil {
+			t.Fatalf("userRepoImpl.Create() error = %v", err)
+		}
+		user.WrongPasswordCounter = 1
+		if err := p.Save(context.Background(), user); err != nil {
+			t.Fatalf("userRepoImpl.Save() error = %v", err)
+		}
+		got, err := p.GetByID(context.Background(), user.Id)
+		if err != nil || got.PhoneNumber != "" || got.WrongPasswordCounter != 1 {
+			t.Errorf("userRepoImpl.GetByID() = %v, %v, want saved user without phone number", got, err)
+		}
+	}
+}
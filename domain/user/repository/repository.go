@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	"github.com/google/uuid"
@@ -12,12 +13,120 @@ type User interface {
 	GetAll(ctx context.Context) ([]*entity.User, error)
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
 	GetByID(ctx context.Context, ID uuid.UUID) (*entity.User, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error)
+	// GetSummariesByIDs returns the summaries of the users among IDs, unknown IDs are skipped
+	GetSummariesByIDs(ctx context.Context, IDs []uuid.UUID) ([]*entity.UserSummary, error)
 	Create(ctx context.Context, user *entity.User, roleIds []string) error
 	Save(ctx context.Context, user *entity.User) error
 	VerifyUserByEmail(ctx context.Context, email string) (bool, error)
+	VerifyPhoneNumber(ctx context.Context, ID uuid.UUID) error
+	// UpdateEmail changes the email of a user along with the username matching it
+	UpdateEmail(ctx context.Context, ID uuid.UUID, email string) error
+	EnableMfa(ctx context.Context, ID uuid.UUID) error
+	// SetActive activates or deactivates a user that is not anonymized,
+	// activating also cancels a requested deletion
+	SetActive(ctx context.Context, ID uuid.UUID, active bool, updatedBy uuid.UUID) error
+	// ReplaceRoles sets roleIds as the only roles of a user
+	ReplaceRoles(ctx context.Context, ID uuid.UUID, roleIds []string, updatedBy uuid.UUID) error
+	// RequestDeletion deactivates a user and records when the deletion was requested
+	RequestDeletion(ctx context.Context, ID uuid.UUID, requestedAt time.Time) error
+	// ListDeletionDue returns up to limit users not anonymized yet whose deletion was requested before requestedBefore
+	ListDeletionDue(ctx context.Context, requestedBefore time.Time, limit int) ([]*entity.User, error)
+	// Anonymize scrubs the personal data of a user, the row is kept for the records referencing it
+	Anonymize(ctx context.Context, ID uuid.UUID) error
 }
 
 type Role interface {
 	Create(ctx context.Context, role *entity.Role) error
 	GetRole(ctx context.Context) ([]entity.Role, error)
 }
+
+type Mfa interface {
+	GetByUserID(ctx context.Context, userId uuid.UUID) (*entity.UserMfa, error)
+	// Save creates or replaces the secret of mfa.UserId
+	Save(ctx context.Context, mfa *entity.UserMfa) error
+	// ReplaceRecoveryCodes drops the recovery codes of userId and stores codes instead
+	ReplaceRecoveryCodes(ctx context.Context, userId uuid.UUID, codes []*entity.MfaRecoveryCode) error
+	// UseRecoveryCode marks the unused code of userId matching codeHash as used
+	UseRecoveryCode(ctx context.Context, userId uuid.UUID, codeHash string) error
+	// UseTotpStep records step as the last totp step accepted for userId, it fails with
+	// ErrTotpReplayed when a step as recent was accepted already
+	UseTotpStep(ctx context.Context, userId uuid.UUID, step int64) error
+	// DeleteByUserID drops the secret and recovery codes of userId
+	DeleteByUserID(ctx context.Context, userId uuid.UUID) error
+}
+
+type Session interface {
+	Create(ctx context.Context, session *entity.Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Session, error)
+	// ListActiveByUserID returns the sessions of userId neither revoked nor expired, most recently used first
+	ListActiveByUserID(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error)
+	// ListByUserID returns all the sessions of userId including revoked and expired ones, newest first
+	ListByUserID(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error)
+	// Rotate saves session only if its refresh token id is still previousRefreshTokenId,
+	// otherwise another refresh won the race and ErrSessionNotFound is returned
+	Rotate(ctx context.Context, session *entity.Session, previousRefreshTokenId uuid.UUID) error
+	// Revoke revokes the active session id of userId
+	Revoke(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	RevokeAllByUserID(ctx context.Context, userId uuid.UUID) error
+	// DeleteByUserID drops the sessions of userId along with the device details they hold
+	DeleteByUserID(ctx context.Context, userId uuid.UUID) error
+}
+
+// Transactor runs fn in a database transaction. Repository calls made with the ctx
+// passed to fn join that transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Outbox interface {
+	Create(ctx context.Context, message *entity.OutboxMessage) error
+	// ClaimDue returns up to limit pending messages due for delivery and hides them
+	// from other dispatchers for lease
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entity.OutboxMessage, error)
+	Save(ctx context.Context, message *entity.OutboxMessage) error
+	// DeleteByUser drops the otps and notices sent to email or phoneNumber and the delivered events of userId
+	DeleteByUser(ctx context.Context, userId uuid.UUID, email string, phoneNumber string) error
+}
+
+// Audit is append only, events are never deleted and only updated to scrub an anonymized user
+type Audit interface {
+	Create(ctx context.Context, event *entity.AuditEvent) error
+	// List returns the page of events matching filter, newest first, along with the count of all matching events
+	List(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEvent, int64, error)
+	// ScrubByUserID empties the metadata and device details of the events userId took part in
+	ScrubByUserID(ctx context.Context, userId uuid.UUID) error
+}
+
+type LoginHistory interface {
+	Create(ctx context.Context, attempt *entity.LoginAttempt) error
+	// ListByUserID returns the page of login attempts of userId, newest first, along with the count of all of them
+	ListByUserID(ctx context.Context, userId uuid.UUID, page int, pageSize int) ([]*entity.LoginAttempt, int64, error)
+	// HasSucceeded reports whether userId ever signed in successfully, from the device
+	// with fingerprint when it is not empty
+	HasSucceeded(ctx context.Context, userId uuid.UUID, fingerprint string) (bool, error)
+	// DeleteByUserID drops the login history of userId along with the device details it holds
+	DeleteByUserID(ctx context.Context, userId uuid.UUID) error
+}
+
+type ExternalIdentity interface {
+	// GetByProviderSubject returns the identity of the account subject at provider
+	GetByProviderSubject(ctx context.Context, provider string, subject string) (*entity.ExternalIdentity, error)
+	Create(ctx context.Context, identity *entity.ExternalIdentity) error
+	DeleteByUserID(ctx context.Context, userId uuid.UUID) error
+}
+
+type OAuthClient interface {
+	// GetByID returns the active client id
+	GetByID(ctx context.Context, id string) (*entity.OAuthClient, error)
+	Create(ctx context.Context, client *entity.OAuthClient) error
+}
+
+type ServiceClient interface {
+	// GetByKeyPrefix returns the client of the api key starting with prefix, revoked or not
+	GetByKeyPrefix(ctx context.Context, prefix string) (*entity.ServiceClient, error)
+	Create(ctx context.Context, client *entity.ServiceClient) error
+	List(ctx context.Context) ([]*entity.ServiceClient, error)
+	// Revoke returns ErrServiceClientNotFound for unknown and already revoked clients
+	Revoke(ctx context.Context, id uuid.UUID) error
+}
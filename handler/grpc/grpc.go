@@ -3,31 +3,33 @@ package grpc
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
+	"github.com/Mitra-Apps/be-user-service/config/logger"
 	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	"github.com/Mitra-Apps/be-user-service/handler/middleware"
 	"github.com/Mitra-Apps/be-user-service/service"
-	utilPb "github.com/Mitra-Apps/be-utility-service/domain/proto/utility"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// exportChunkSize is the size of the messages streaming a data export
+const exportChunkSize = 64 * 1024
+
 type GrpcRoute struct {
-	service     service.ServiceInterface
-	auth        service.Authentication
-	utilService utilPb.MailServiceClient
+	service service.ServiceInterface
+	auth    service.Authentication
 	pb.UnimplementedUserServiceServer
 }
 
-func New(service service.ServiceInterface, auth service.Authentication, utilService utilPb.MailServiceClient) pb.UserServiceServer {
+func New(service service.ServiceInterface, auth service.Authentication) pb.UserServiceServer {
 	return &GrpcRoute{
-		service:     service,
-		auth:        auth,
-		utilService: utilService,
+		service: service,
+		auth:    auth,
 	}
 }
 
@@ -47,67 +49,86 @@ func (g *GrpcRoute) GetUsers(ctx context.Context, req *pb.GetUsersRequest) (*pb.
 	}, nil
 }
 
-func (g *GrpcRoute) Login(ctx context.Context, req *pb.UserLoginRequest) (*pb.SuccessResponse, error) {
+func (g *GrpcRoute) IntrospectToken(ctx context.Context, req *pb.IntrospectTokenRequest) (*pb.IntrospectTokenResponse, error) {
 	if err := req.ValidateAll(); err != nil {
 		return nil, err
 	}
-	loginRequest := entity.LoginRequest{
-		Email:    req.Email,
-		Password: req.Password,
-	}
-	user, err := g.service.Login(ctx, loginRequest)
+	introspection, err := g.service.IntrospectToken(ctx, req.Token)
 	if err != nil {
 		return nil, err
 	}
+	return introspection.ToProto(), nil
+}
 
-	accessToken, err := g.auth.GenerateToken(ctx, user, 60)
-	if err != nil {
+func (g *GrpcRoute) GetUsersByIds(ctx context.Context, req *pb.GetUsersByIdsRequest) (*pb.GetUsersByIdsResponse, error) {
+	if err := req.ValidateAll(); err != nil {
 		return nil, err
 	}
-	refreshToken, err := g.auth.GenerateToken(ctx, user, 43200)
+	ids := make([]uuid.UUID, len(req.Ids))
+	for i, id := range req.Ids {
+		ids[i] = uuid.MustParse(id)
+	}
+	summaries, err := g.service.GetUsersByIds(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
-
-	token := map[string]interface{}{
-		"access_token":  accessToken,
-		"refresh_token": refreshToken,
+	users := make([]*pb.UserSummary, len(summaries))
+	for i, summary := range summaries {
+		users[i] = summary.ToProto()
 	}
+	return &pb.GetUsersByIdsResponse{
+		Users: users,
+	}, nil
+}
 
-	data, err := structpb.NewStruct(token)
+func (g *GrpcRoute) GetUserByEmail(ctx context.Context, req *pb.GetUserByEmailRequest) (*pb.GetUserByEmailResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	summary, err := g.service.GetUserByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
-
-	return &pb.SuccessResponse{
-		Data: data,
+	return &pb.GetUserByEmailResponse{
+		User: summary.ToProto(),
 	}, nil
 }
 
-func (g *GrpcRoute) Register(ctx context.Context, req *pb.UserRegisterRequest) (*pb.SuccessResponse, error) {
+func (g *GrpcRoute) Login(ctx context.Context, req *pb.UserLoginRequest) (*pb.SuccessResponse, error) {
 	if err := req.ValidateAll(); err != nil {
 		return nil, err
 	}
-	otpReq, err := g.service.Register(ctx, req)
+	loginRequest := entity.LoginRequest{
+		Email:    req.Email,
+		Password: req.Password,
+	}
+	user, err := g.service.Login(ctx, loginRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	sendOtpReq := &utilPb.OtpMailReq{
-		Name:    otpReq.Name,
-		Email:   otpReq.Email,
-		OtpCode: int32(otpReq.OtpCode),
+	data, err := g.signInResponse(ctx, user)
+	if err != nil {
+		return nil, err
 	}
 
-	//send otp to email
-	res, err := g.utilService.SendOtpMail(ctx, sendOtpReq)
-	if err != nil {
+	return &pb.SuccessResponse{
+		Data: data,
+	}, nil
+}
+
+func (g *GrpcRoute) Register(ctx context.Context, req *pb.UserRegisterRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	if err := g.service.Register(ctx, req); err != nil {
 		return nil, err
 	}
 
+	//otp is delivered by the outbox dispatcher
 	return &pb.SuccessResponse{
-		Code:    res.Code,
-		Message: res.Message,
+		Code:    int32(codes.OK),
+		Message: otpSentMessage(entity.OtpChannelFromProto(req.OtpChannel)),
 	}, nil
 }
 
@@ -123,10 +144,9 @@ func (g *GrpcRoute) CreateRole(ctx context.Context, req *pb.Role) (*pb.SuccessRe
 }
 
 func (g *GrpcRoute) GetRole(ctx context.Context, req *emptypb.Empty) (*pb.SuccessResponse, error) {
-	fmt.Println("get role handler", middleware.GetUserIDValue(ctx))
 	roles, err := g.service.GetRole(ctx)
 	if err != nil {
-		fmt.Println("error 1", err.Error())
+		logger.FromContext(ctx).WithError(err).Error("failed to get roles")
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
@@ -141,18 +161,18 @@ func (g *GrpcRoute) GetRole(ctx context.Context, req *emptypb.Empty) (*pb.Succes
 
 	data, err := json.Marshal(rolesStruct)
 	if err != nil {
-		fmt.Println("error 3", err.Error())
+		logger.FromContext(ctx).WithError(err).Error("failed to marshal roles")
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
 	if err := json.Unmarshal(data, &rolesStruct); err != nil {
-		fmt.Println("error 4", err.Error())
+		logger.FromContext(ctx).WithError(err).Error("failed to unmarshal roles")
 		return nil, err
 	}
 
 	dataStruct, err := structpb.NewStruct(rolesStruct)
 	if err != nil {
-		fmt.Println("error 5", err.Error())
+		logger.FromContext(ctx).WithError(err).Error("failed to build roles struct")
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
@@ -169,82 +189,555 @@ func (g *GrpcRoute) VerifyOtp(ctx context.Context, req *pb.VerifyOTPRequest) (*p
 	if err != nil {
 		return nil, err
 	}
-	accessToken, err := g.auth.GenerateToken(ctx, user, 60)
+	data, err := g.tokenResponse(ctx, user)
 	if err != nil {
 		return nil, err
 	}
-	refreshToken, err := g.auth.GenerateToken(ctx, user, 43200)
+
+	return &pb.SuccessResponse{
+		Data: data,
+	}, nil
+}
+
+func (g *GrpcRoute) ResendOtp(ctx context.Context, req *pb.ResendOTPRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	channel := entity.OtpChannelFromProto(req.OtpChannel)
+	if err := g.service.ResendOTP(ctx, req.Email, channel); err != nil {
+		return nil, err
+	}
+
+	//otp is delivered by the outbox dispatcher
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: otpSentMessage(channel),
+	}, nil
+}
+
+func (g *GrpcRoute) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	user, err := g.service.ChangePassword(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.signInResponse(ctx, user)
 	if err != nil {
 		return nil, err
 	}
+	res := &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "Sandi berhasil diubah!",
+		Data:    data,
+	}
+	return res, nil
+}
 
-	token := map[string]interface{}{
-		"access_token":  accessToken,
-		"refresh_token": refreshToken,
+func (g *GrpcRoute) SendPhoneVerificationOtp(ctx context.Context, req *pb.SendPhoneVerificationOtpRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
 	}
+	channel := entity.PhoneOtpChannelFromProto(req.OtpChannel)
+	if err := g.service.SendPhoneVerificationOtp(ctx, middleware.GetUserIDValue(ctx), channel); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: otpSentMessage(channel),
+	}, nil
+}
 
-	data, err := structpb.NewStruct(token)
+func (g *GrpcRoute) VerifyPhone(ctx context.Context, req *pb.VerifyPhoneRequest) (*pb.SuccessResponse, error) {
+	if _, err := g.service.VerifyPhone(ctx, middleware.GetUserIDValue(ctx), int(req.OtpCode)); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "No. Telp berhasil diverifikasi",
+	}, nil
+}
+
+func (g *GrpcRoute) RequestPhoneLoginOtp(ctx context.Context, req *pb.RequestPhoneLoginOtpRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	channel := entity.PhoneOtpChannelFromProto(req.OtpChannel)
+	if err := g.service.RequestPhoneLoginOtp(ctx, req.PhoneNumber, channel); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: otpSentMessage(channel),
+	}, nil
+}
+
+func (g *GrpcRoute) LoginWithPhoneOtp(ctx context.Context, req *pb.LoginWithPhoneOtpRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	user, err := g.service.LoginWithPhoneOtp(ctx, req.PhoneNumber, int(req.OtpCode))
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
+	data, err := g.signInResponse(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Data: data,
+	}, nil
+}
 
+func (g *GrpcRoute) LoginWithGoogle(ctx context.Context, req *pb.LoginWithGoogleRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	user, err := g.service.LoginWithGoogle(ctx, req.IdToken)
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.signInResponse(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 	return &pb.SuccessResponse{
 		Data: data,
 	}, nil
 }
 
-func (g *GrpcRoute) ResendOtp(ctx context.Context, req *pb.ResendOTPRequest) (*pb.SuccessResponse, error) {
-	otpReq, err := g.service.ResendOTP(ctx, req.Email)
+func (g *GrpcRoute) RequestEmailChange(ctx context.Context, req *pb.RequestEmailChangeRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	if err := g.service.RequestEmailChange(ctx, middleware.GetUserIDValue(ctx), req.NewEmail); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "Kode OTP telah dikirim ke email baru anda",
+	}, nil
+}
+
+func (g *GrpcRoute) ConfirmEmailChange(ctx context.Context, req *pb.ConfirmEmailChangeRequest) (*pb.SuccessResponse, error) {
+	if _, err := g.service.ConfirmEmailChange(ctx, middleware.GetUserIDValue(ctx), int(req.OtpCode)); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "Email berhasil diubah",
+	}, nil
+}
+
+func (g *GrpcRoute) EnrollMfa(ctx context.Context, req *emptypb.Empty) (*pb.SuccessResponse, error) {
+	enrollment, err := g.service.EnrollMfa(ctx, middleware.GetUserIDValue(ctx))
+	if err != nil {
+		return nil, err
+	}
+	data, err := structpb.NewStruct(map[string]interface{}{
+		"secret":      enrollment.Secret,
+		"otpauth_uri": enrollment.Uri,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "Pindai kode QR dengan aplikasi authenticator lalu konfirmasi kodenya",
+		Data:    data,
+	}, nil
+}
+
+func (g *GrpcRoute) ConfirmMfa(ctx context.Context, req *pb.ConfirmMfaRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := g.service.ConfirmMfa(ctx, middleware.GetUserIDValue(ctx), req.Code)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		list[i] = code
+	}
+	data, err := structpb.NewStruct(map[string]interface{}{
+		"recovery_codes": list,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "2FA berhasil diaktifkan, simpan kode pemulihan di tempat yang aman",
+		Data:    data,
+	}, nil
+}
+
+func (g *GrpcRoute) VerifyMfa(ctx context.Context, req *pb.VerifyMfaRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	user, err := g.service.VerifyMfa(ctx, req.MfaToken, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.tokenResponse(ctx, user)
 	if err != nil {
 		return nil, err
 	}
+	return &pb.SuccessResponse{
+		Data: data,
+	}, nil
+}
 
-	sendOtpReq := &utilPb.OtpMailReq{
-		Name:    otpReq.Name,
-		Email:   otpReq.Email,
-		OtpCode: int32(otpReq.OtpCode),
+func (g *GrpcRoute) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	claims, err := g.auth.ValidateToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != service.TokenTypeRefresh {
+		return nil, status.Error(codes.Unauthenticated, "refresh token required")
+	}
+	sessionId, err := uuid.Parse(claims.SessionId)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "refresh token without session")
 	}
+	refreshTokenId, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "refresh token without id")
+	}
+	user, session, err := g.service.RefreshSession(ctx, sessionId, refreshTokenId, "")
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.sessionTokens(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Data: data,
+	}, nil
+}
 
-	//send otp to email
-	res, err := g.utilService.SendOtpMail(ctx, sendOtpReq)
+func (g *GrpcRoute) ListSessions(ctx context.Context, req *emptypb.Empty) (*pb.ListSessionsResponse, error) {
+	sessions, err := g.service.ListSessions(ctx, middleware.GetUserIDValue(ctx))
 	if err != nil {
 		return nil, err
 	}
+	current := middleware.GetSessionIDValue(ctx)
+	protoSessions := []*pb.Session{}
+	for _, session := range sessions {
+		protoSessions = append(protoSessions, session.ToProto(current))
+	}
+	return &pb.ListSessionsResponse{
+		Sessions: protoSessions,
+	}, nil
+}
 
+func (g *GrpcRoute) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	if err := g.service.RevokeSession(ctx, middleware.GetUserIDValue(ctx), uuid.MustParse(req.SessionId)); err != nil {
+		return nil, err
+	}
 	return &pb.SuccessResponse{
-		Code:    res.Code,
-		Message: res.Message,
+		Code:    int32(codes.OK),
+		Message: "Sesi berhasil dihapus",
 	}, nil
 }
 
-func (g *GrpcRoute) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.SuccessResponse, error) {
+func (g *GrpcRoute) DeactivateUser(ctx context.Context, req *pb.DeactivateUserRequest) (*pb.SuccessResponse, error) {
 	if err := req.ValidateAll(); err != nil {
 		return nil, err
 	}
-	user, err := g.service.ChangePassword(ctx, req)
+	if err := g.service.DeactivateUser(ctx, middleware.GetUserIDValue(ctx), uuid.MustParse(req.UserId)); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "User berhasil dinonaktifkan",
+	}, nil
+}
+
+func (g *GrpcRoute) SetUserRoles(ctx context.Context, req *pb.SetUserRolesRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	if err := g.service.SetUserRoles(ctx, middleware.GetUserIDValue(ctx), uuid.MustParse(req.UserId), req.RoleIds); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "Role user berhasil diubah",
+	}, nil
+}
+
+func (g *GrpcRoute) ReactivateUser(ctx context.Context, req *pb.ReactivateUserRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	if err := g.service.ReactivateUser(ctx, middleware.GetUserIDValue(ctx), uuid.MustParse(req.UserId)); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "User berhasil diaktifkan kembali",
+	}, nil
+}
+
+func (g *GrpcRoute) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	if err := g.service.DeleteAccount(ctx, middleware.GetUserIDValue(ctx), req.Password); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "Permintaan penghapusan akun diterima",
+	}, nil
+}
+
+func (g *GrpcRoute) ExportMyData(req *emptypb.Empty, stream pb.UserService_ExportMyDataServer) error {
+	ctx := stream.Context()
+	return g.streamDataExport(ctx, middleware.GetUserIDValue(ctx), stream.Send)
+}
+
+func (g *GrpcRoute) ExportUserData(req *pb.ExportUserDataRequest, stream pb.UserService_ExportUserDataServer) error {
+	if err := req.ValidateAll(); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	logger.FromContext(ctx).WithField("target_user_id", req.UserId).Info("user data exported by admin")
+	return g.streamDataExport(ctx, uuid.MustParse(req.UserId), stream.Send)
+}
+
+// streamDataExport sends the json archive of userId in chunks of exportChunkSize bytes
+func (g *GrpcRoute) streamDataExport(ctx context.Context, userId uuid.UUID, send func(*httpbody.HttpBody) error) error {
+	export, err := g.service.ExportUserData(ctx, userId)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return status.Errorf(codes.Internal, err.Error())
+	}
+	for start := 0; start < len(data); start += exportChunkSize {
+		end := min(start+exportChunkSize, len(data))
+		if err := send(&httpbody.HttpBody{
+			ContentType: "application/json",
+			Data:        data[start:end],
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// signInResponse returns the tokens of user, or an mfa challenge to complete with VerifyMfa
+// when the user enabled 2FA
+func (g *GrpcRoute) signInResponse(ctx context.Context, user *entity.User) (*structpb.Struct, error) {
+	if !user.IsMfaEnabled {
+		return g.tokenResponse(ctx, user)
+	}
+	mfaToken, err := g.service.CreateMfaChallenge(ctx, user.Id)
 	if err != nil {
 		return nil, err
 	}
-	accessToken, err := g.auth.GenerateToken(ctx, user, 60)
+	data, err := structpb.NewStruct(map[string]interface{}{
+		"mfa_required": true,
+		"mfa_token":    mfaToken,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return data, nil
+}
+
+// tokenResponse starts a session for user on the requesting device and returns its tokens
+func (g *GrpcRoute) tokenResponse(ctx context.Context, user *entity.User) (*structpb.Struct, error) {
+	session, err := g.service.CreateSession(ctx, user.Id, middleware.DeviceFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
-	refreshToken, err := g.auth.GenerateToken(ctx, user, 43200)
+	return g.sessionTokens(ctx, user, session)
+}
+
+// sessionTokens generates the access and refresh token pair of session
+func (g *GrpcRoute) sessionTokens(ctx context.Context, user *entity.User, session *entity.Session) (*structpb.Struct, error) {
+	accessToken, err := g.auth.GenerateToken(ctx, user, session, service.TokenTypeAccess, 60)
 	if err != nil {
 		return nil, err
 	}
+	refreshToken, err := g.auth.GenerateToken(ctx, user, session, service.TokenTypeRefresh, 43200)
+	if err != nil {
+		return nil, err
+	}
+
 	token := map[string]interface{}{
 		"access_token":  accessToken,
 		"refresh_token": refreshToken,
 	}
+
 	data, err := structpb.NewStruct(token)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
-	res := &pb.SuccessResponse{
+	return data, nil
+}
+
+func otpSentMessage(channel string) string {
+	switch channel {
+	case entity.OtpChannelSms:
+		return "Kode OTP telah dikirim melalui SMS"
+	case entity.OtpChannelWhatsapp:
+		return "Kode OTP telah dikirim melalui WhatsApp"
+	}
+	return "Kode OTP telah dikirim ke email anda"
+}
+
+func (g *GrpcRoute) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	filter := entity.AuditFilter{
+		Action:   req.Action,
+		Page:     int(req.Page),
+		PageSize: int(req.PageSize),
+	}
+	if req.ActorId != "" {
+		filter.ActorId = uuid.NullUUID{UUID: uuid.MustParse(req.ActorId), Valid: true}
+	}
+	if req.TargetUserId != "" {
+		filter.TargetUserId = uuid.NullUUID{UUID: uuid.MustParse(req.TargetUserId), Valid: true}
+	}
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+	events, total, err := g.service.ListAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	protoEvents := []*pb.AuditEvent{}
+	for _, event := range events {
+		protoEvents = append(protoEvents, event.ToProto())
+	}
+	return &pb.ListAuditEventsResponse{
+		Events: protoEvents,
+		Total:  total,
+	}, nil
+}
+
+func (g *GrpcRoute) GetMyLoginHistory(ctx context.Context, req *pb.GetMyLoginHistoryRequest) (*pb.GetMyLoginHistoryResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	attempts, total, err := g.service.GetMyLoginHistory(ctx, middleware.GetUserIDValue(ctx), int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	protoAttempts := []*pb.LoginAttempt{}
+	for _, attempt := range attempts {
+		protoAttempts = append(protoAttempts, attempt.ToProto())
+	}
+	return &pb.GetMyLoginHistoryResponse{
+		Attempts: protoAttempts,
+		Total:    total,
+	}, nil
+}
+
+func (g *GrpcRoute) CreateOAuthClient(ctx context.Context, req *pb.CreateOAuthClientRequest) (*pb.CreateOAuthClientResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	client, secret, err := g.service.CreateOAuthClient(ctx, middleware.GetUserIDValue(ctx), req.Name, req.RedirectUris, req.Confidential)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateOAuthClientResponse{
+		Client:       client.ToProto(),
+		ClientSecret: secret,
+	}, nil
+}
+
+func (g *GrpcRoute) CreateServiceClient(ctx context.Context, req *pb.CreateServiceClientRequest) (*pb.CreateServiceClientResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	client, apiKey, err := g.service.CreateServiceClient(ctx, middleware.GetUserIDValue(ctx), req.Name, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateServiceClientResponse{
+		Client: client.ToProto(),
+		ApiKey: apiKey,
+	}, nil
+}
+
+func (g *GrpcRoute) ListServiceClients(ctx context.Context, req *emptypb.Empty) (*pb.ListServiceClientsResponse, error) {
+	clients, err := g.service.ListServiceClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	protoClients := []*pb.ServiceClient{}
+	for _, client := range clients {
+		protoClients = append(protoClients, client.ToProto())
+	}
+	return &pb.ListServiceClientsResponse{
+		Clients: protoClients,
+	}, nil
+}
+
+func (g *GrpcRoute) RevokeServiceClient(ctx context.Context, req *pb.RevokeServiceClientRequest) (*pb.SuccessResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+	if err := g.service.RevokeServiceClient(ctx, middleware.GetUserIDValue(ctx), uuid.MustParse(req.ClientId)); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
 		Code:    int32(codes.OK),
-		Message: "Sandi berhasil diubah!",
-		Data:    data,
+		Message: "Service client berhasil dicabut",
+	}, nil
+}
+
+func (g *GrpcRoute) ImpersonateUser(ctx context.Context, req *pb.ImpersonateUserRequest) (*pb.ImpersonateUserResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
 	}
-	return res, nil
+	user, session, err := g.service.StartImpersonation(ctx, middleware.GetUserIDValue(ctx), uuid.MustParse(req.UserId), req.Reason, middleware.DeviceFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := g.auth.GenerateToken(ctx, user, session, service.TokenTypeAccess, int(service.ImpersonationLifetime.Minutes()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &pb.ImpersonateUserResponse{
+		AccessToken: accessToken,
+		ExpiresIn:   int32(service.ImpersonationLifetime.Seconds()),
+		SessionId:   session.Id.String(),
+	}, nil
+}
+
+func (g *GrpcRoute) StopImpersonation(ctx context.Context, req *emptypb.Empty) (*pb.SuccessResponse, error) {
+	actorId := middleware.GetActorIDValue(ctx)
+	if actorId == uuid.Nil {
+		return nil, status.Error(codes.FailedPrecondition, "impersonation token required")
+	}
+	if err := g.service.StopImpersonation(ctx, actorId, middleware.GetUserIDValue(ctx), middleware.GetSessionIDValue(ctx)); err != nil {
+		return nil, err
+	}
+	return &pb.SuccessResponse{
+		Code:    int32(codes.OK),
+		Message: "Impersonasi berhasil dihentikan",
+	}, nil
 }
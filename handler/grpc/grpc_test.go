@@ -7,23 +7,26 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Mitra-Apps/be-user-service/config/postgre"
 	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	userPostgreRepo "github.com/Mitra-Apps/be-user-service/domain/user/repository/postgre"
+	"github.com/Mitra-Apps/be-user-service/handler/middleware"
 	"github.com/Mitra-Apps/be-user-service/service"
 	"github.com/Mitra-Apps/be-user-service/service/mock"
-	utilPb "github.com/Mitra-Apps/be-utility-service/domain/proto/utility"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 )
 
@@ -38,9 +41,8 @@ func init() {
 
 func TestNew(t *testing.T) {
 	type args struct {
-		service     service.ServiceInterface
-		auth        service.Authentication
-		utilService utilPb.MailServiceClient
+		service service.ServiceInterface
+		auth    service.Authentication
 	}
 	ctrl := gomock.NewController(t)
 	mockSvc := mock.NewMockServiceInterface(ctrl)
@@ -65,7 +67,7 @@ func TestNew(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := New(tt.args.service, tt.args.auth, tt.args.utilService); !reflect.DeepEqual(got, tt.want) {
+			if got := New(tt.args.service, tt.args.auth); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("New() = %v, want %v", got, tt.want)
 			}
 		})
@@ -78,7 +80,8 @@ func TestGrpcRoute_GetUsers(t *testing.T) {
 	mockSvcRec := mockSvc.EXPECT()
 	users := []*entity.User{
 		{
-			Name: "test",
+			Name:     "test",
+			Password: "$2a$10$hash",
 		},
 	}
 
@@ -138,6 +141,11 @@ func TestGrpcRoute_GetUsers(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GrpcRoute.GetUsers() = %v, want %v", got, tt.want)
 			}
+			for _, user := range got.GetUsers() {
+				if user.Password != "" {
+					t.Errorf("GrpcRoute.GetUsers() returned the password hash of %v", user.Id)
+				}
+			}
 		})
 	}
 }
@@ -145,6 +153,7 @@ func TestGrpcRoute_GetUsers(t *testing.T) {
 func TestGrpcRoute_Login(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvc.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.Session{Id: uuid.New()}, nil).AnyTimes()
 	mockAuth := mock.NewMockAuthentication(ctrl)
 	mockSvcRecord := mockSvc.EXPECT()
 	mockAuthRecord := mockAuth.EXPECT()
@@ -155,7 +164,7 @@ func TestGrpcRoute_Login(t *testing.T) {
 	}
 	mockGenerateToken := func(token string, err error) func(m *mock.MockAuthentication) {
 		return func(m *mock.MockAuthentication) {
-			m.EXPECT().GenerateToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(token, err)
+			m.EXPECT().GenerateToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(token, err)
 		}
 	}
 	req := &pb.UserLoginRequest{
@@ -238,7 +247,7 @@ func TestGrpcRoute_Login(t *testing.T) {
 			wantErr: true,
 			mocks: []*gomock.Call{
 				mockSvcRecord.Login(gomock.Any(), gomock.Any()).Return(user, nil),
-				mockAuthRecord.GenerateToken(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("any errors")),
+				mockAuthRecord.GenerateToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("any errors")),
 				// 	mockLogin(user, nil)(mockSvc)
 				// 	mockGenerateToken("", errors.New("any error"))(mockAuth)
 			},
@@ -303,15 +312,6 @@ func TestGrpcRoute_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockSvc := mock.NewMockServiceInterface(ctrl)
 	mockSvcRec := mockSvc.EXPECT()
-	utilityGrpcConn, err := grpc.DialContext(context.Background(), os.Getenv("GRPC_UTILITY_HOST"), grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		log.Fatal("Cannot connect to utility grpc server ", err)
-	}
-	defer func() {
-		log.Println("Closing connection ...")
-		utilityGrpcConn.Close()
-	}()
-
 	type args struct {
 		ctx context.Context
 		req *pb.UserRegisterRequest
@@ -362,14 +362,13 @@ func TestGrpcRoute_Register(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			mocks: []*gomock.Call{
-				mockSvcRec.Register(gomock.Any(), gomock.Any()).Return(nil, errors.New("any error")),
+				mockSvcRec.Register(gomock.Any(), gomock.Any()).Return(errors.New("any error")),
 			},
 		},
 		{
-			name: "error send otp",
+			name: "success",
 			g: &GrpcRoute{
-				service:     mockSvc,
-				utilService: utilPb.NewMailServiceClient(utilityGrpcConn),
+				service: mockSvc,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -382,10 +381,13 @@ func TestGrpcRoute_Register(t *testing.T) {
 					RoleId:      []string{"1", "2"},
 				},
 			},
-			want:    nil,
-			wantErr: true,
+			want: &pb.SuccessResponse{
+				Code:    int32(codes.OK),
+				Message: "Kode OTP telah dikirim ke email anda",
+			},
+			wantErr: false,
 			mocks: []*gomock.Call{
-				mockSvcRec.Register(gomock.Any(), gomock.Any()).Return(&entity.OtpMailReq{}, nil),
+				mockSvcRec.Register(gomock.Any(), gomock.Any()).Return(nil),
 			},
 		},
 	}
@@ -593,7 +595,7 @@ func TestGrpcRoute_GetRole_E2E(t *testing.T) {
 	db := postgre.Connection()
 	usrRepo := userPostgreRepo.NewUserRepoImpl(db)
 	roleRepo := userPostgreRepo.NewRoleRepoImpl(db)
-	usrSvc := service.New(usrRepo, roleRepo, nil, nil, nil)
+	usrSvc := service.New(usrRepo, roleRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, service.SocialLogin{}, service.PasswordPolicy{})
 	permission := map[string]interface{}{
 		"store": "create store",
 	}
@@ -682,6 +684,7 @@ func TestGrpcRoute_GetRole_E2E(t *testing.T) {
 func TestGrpcRoute_VerifyOtp(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvc.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.Session{Id: uuid.New()}, nil).AnyTimes()
 	mockAuth := mock.NewMockAuthentication(ctrl)
 	mockVerifyOtp := func(user *entity.User, err error) func(m *mock.MockServiceInterface) {
 		return func(m *mock.MockServiceInterface) {
@@ -690,7 +693,7 @@ func TestGrpcRoute_VerifyOtp(t *testing.T) {
 	}
 	mockGenerateToken := func(token string, err error) func(m *mock.MockAuthentication) {
 		return func(m *mock.MockAuthentication) {
-			m.EXPECT().GenerateToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(token, err)
+			m.EXPECT().GenerateToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(token, err)
 		}
 	}
 
@@ -812,14 +815,6 @@ func TestGrpcRoute_ResendOtp(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockSvc := mock.NewMockServiceInterface(ctrl)
 	mockSvcRec := mockSvc.EXPECT()
-	utilityGrpcConn, err := grpc.DialContext(context.Background(), os.Getenv("GRPC_UTILITY_HOST"), grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		log.Fatal("Cannot connect to utility grpc server ", err)
-	}
-	defer func() {
-		log.Println("Closing connection ...")
-		utilityGrpcConn.Close()
-	}()
 	type args struct {
 		ctx context.Context
 		req *pb.ResendOTPRequest
@@ -845,13 +840,12 @@ func TestGrpcRoute_ResendOtp(t *testing.T) {
 			},
 			want:    nil,
 			wantErr: true,
-			mock:    mockSvcRec.ResendOTP(gomock.Any(), gomock.Any()).Return(nil, errors.New("any error")),
+			mock:    mockSvcRec.ResendOTP(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("any error")),
 		},
 		{
-			name: "error send otp mail",
+			name: "success",
 			g: &GrpcRoute{
-				service:     mockSvc,
-				utilService: utilPb.NewMailServiceClient(utilityGrpcConn),
+				service: mockSvc,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -859,9 +853,12 @@ func TestGrpcRoute_ResendOtp(t *testing.T) {
 					Email: "test@mail.com",
 				},
 			},
-			want:    nil,
-			wantErr: true,
-			mock:    mockSvcRec.ResendOTP(gomock.Any(), gomock.Any()).Return(&entity.OtpMailReq{}, nil),
+			want: &pb.SuccessResponse{
+				Code:    int32(codes.OK),
+				Message: "Kode OTP telah dikirim ke email anda",
+			},
+			wantErr: false,
+			mock:    mockSvcRec.ResendOTP(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
 		},
 	}
 	for _, tt := range tests {
@@ -881,6 +878,7 @@ func TestGrpcRoute_ResendOtp(t *testing.T) {
 func TestGrpcRoute_ChangePassword(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvc.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.Session{Id: uuid.New()}, nil).AnyTimes()
 	mockChangePassword := func(user *entity.User, err error) func(m *mock.MockServiceInterface) {
 		return func(m *mock.MockServiceInterface) {
 			m.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).Return(user, err)
@@ -889,7 +887,7 @@ func TestGrpcRoute_ChangePassword(t *testing.T) {
 	mockAuth := mock.NewMockAuthentication(ctrl)
 	mockGenerateToken := func(token string, err error) func(m *mock.MockAuthentication) {
 		return func(m *mock.MockAuthentication) {
-			m.EXPECT().GenerateToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(token, err)
+			m.EXPECT().GenerateToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(token, err)
 		}
 	}
 	req := &pb.ChangePasswordRequest{
@@ -1021,3 +1019,1116 @@ func TestGrpcRoute_ChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestGrpcRoute_SendPhoneVerificationOtp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvcRec := mockSvc.EXPECT()
+	userId := uuid.New()
+	ctx := middleware.SetUserIDKey(context.Background(), userId)
+	tests := []struct {
+		name    string
+		req     *pb.SendPhoneVerificationOtpRequest
+		want    *pb.SuccessResponse
+		wantErr bool
+		mock    *gomock.Call
+	}{
+		{
+			name:    "invalid channel",
+			req:     &pb.SendPhoneVerificationOtpRequest{OtpChannel: pb.OtpChannel(10)},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "fail send otp",
+			req:     &pb.SendPhoneVerificationOtpRequest{OtpChannel: pb.OtpChannel_OTP_CHANNEL_WHATSAPP},
+			want:    nil,
+			wantErr: true,
+			mock:    mockSvcRec.SendPhoneVerificationOtp(gomock.Any(), userId, entity.OtpChannelWhatsapp).Return(errors.New("any error")),
+		},
+		{
+			name: "success defaults to sms",
+			req:  &pb.SendPhoneVerificationOtpRequest{},
+			want: &pb.SuccessResponse{
+				Code:    int32(codes.OK),
+				Message: "Kode OTP telah dikirim melalui SMS",
+			},
+			wantErr: false,
+			mock:    mockSvcRec.SendPhoneVerificationOtp(gomock.Any(), userId, entity.OtpChannelSms).Return(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GrpcRoute{service: mockSvc}
+			got, err := g.SendPhoneVerificationOtp(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.SendPhoneVerificationOtp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.SendPhoneVerificationOtp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_LoginWithPhoneOtp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvc.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.Session{Id: uuid.New()}, nil).AnyTimes()
+	mockSvcRec := mockSvc.EXPECT()
+	mockAuth := mock.NewMockAuthentication(ctrl)
+	mockAuthRec := mockAuth.EXPECT()
+	user := &entity.User{
+		Id:              uuid.New(),
+		PhoneNumber:     "08123456789",
+		IsVerified:      true,
+		IsPhoneVerified: true,
+	}
+	req := &pb.LoginWithPhoneOtpRequest{
+		PhoneNumber: "08123456789",
+		OtpCode:     1234,
+	}
+	data, _ := structpb.NewStruct(map[string]interface{}{
+		"access_token":  "accessToken",
+		"refresh_token": "refreshToken",
+	})
+	tests := []struct {
+		name    string
+		req     *pb.LoginWithPhoneOtpRequest
+		want    *pb.SuccessResponse
+		wantErr bool
+		mocks   []*gomock.Call
+	}{
+		{
+			name:    "invalid phone number",
+			req:     &pb.LoginWithPhoneOtpRequest{PhoneNumber: "0812", OtpCode: 1234},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "fail login",
+			req:     req,
+			want:    nil,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockSvcRec.LoginWithPhoneOtp(gomock.Any(), req.PhoneNumber, 1234).Return(nil, errors.New("any error")),
+			},
+		},
+		{
+			name:    "fail generate token",
+			req:     req,
+			want:    nil,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockSvcRec.LoginWithPhoneOtp(gomock.Any(), req.PhoneNumber, 1234).Return(user, nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, gomock.Any(), service.TokenTypeAccess, 60).Return("", errors.New("any error")),
+			},
+		},
+		{
+			name: "success",
+			req:  req,
+			want: &pb.SuccessResponse{
+				Data: data,
+			},
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockSvcRec.LoginWithPhoneOtp(gomock.Any(), req.PhoneNumber, 1234).Return(user, nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, gomock.Any(), service.TokenTypeAccess, 60).Return("accessToken", nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, gomock.Any(), service.TokenTypeRefresh, 43200).Return("refreshToken", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GrpcRoute{service: mockSvc, auth: mockAuth}
+			got, err := g.LoginWithPhoneOtp(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.LoginWithPhoneOtp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.LoginWithPhoneOtp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_LoginWithGoogle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvc.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.Session{Id: uuid.New()}, nil).AnyTimes()
+	mockSvcRec := mockSvc.EXPECT()
+	mockAuth := mock.NewMockAuthentication(ctrl)
+	mockAuthRec := mockAuth.EXPECT()
+	user := &entity.User{
+		Id:         uuid.New(),
+		Email:      "test@gmail.com",
+		IsVerified: true,
+	}
+	req := &pb.LoginWithGoogleRequest{IdToken: "idToken"}
+	data, _ := structpb.NewStruct(map[string]interface{}{
+		"access_token":  "accessToken",
+		"refresh_token": "refreshToken",
+	})
+	tests := []struct {
+		name    string
+		req     *pb.LoginWithGoogleRequest
+		want    *pb.SuccessResponse
+		wantErr bool
+		mocks   []*gomock.Call
+	}{
+		{
+			name:    "missing id token",
+			req:     &pb.LoginWithGoogleRequest{},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "fail login",
+			req:     req,
+			want:    nil,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockSvcRec.LoginWithGoogle(gomock.Any(), "idToken").Return(nil, errors.New("any error")),
+			},
+		},
+		{
+			name: "success",
+			req:  req,
+			want: &pb.SuccessResponse{
+				Data: data,
+			},
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockSvcRec.LoginWithGoogle(gomock.Any(), "idToken").Return(user, nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, gomock.Any(), service.TokenTypeAccess, 60).Return("accessToken", nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, gomock.Any(), service.TokenTypeRefresh, 43200).Return("refreshToken", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GrpcRoute{service: mockSvc, auth: mockAuth}
+			got, err := g.LoginWithGoogle(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.LoginWithGoogle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.LoginWithGoogle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_RequestEmailChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvcRec := mockSvc.EXPECT()
+	userId := uuid.New()
+	ctx := middleware.SetUserIDKey(context.Background(), userId)
+	tests := []struct {
+		name    string
+		req     *pb.RequestEmailChangeRequest
+		want    *pb.SuccessResponse
+		wantErr bool
+		mock    *gomock.Call
+	}{
+		{
+			name:    "invalid email",
+			req:     &pb.RequestEmailChangeRequest{NewEmail: "not-an-email"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "fail request email change",
+			req:     &pb.RequestEmailChangeRequest{NewEmail: "new@mail.com"},
+			want:    nil,
+			wantErr: true,
+			mock:    mockSvcRec.RequestEmailChange(gomock.Any(), userId, "new@mail.com").Return(errors.New("any error")),
+		},
+		{
+			name: "success",
+			req:  &pb.RequestEmailChangeRequest{NewEmail: "new@mail.com"},
+			want: &pb.SuccessResponse{
+				Code:    int32(codes.OK),
+				Message: "Kode OTP telah dikirim ke email baru anda",
+			},
+			wantErr: false,
+			mock:    mockSvcRec.RequestEmailChange(gomock.Any(), userId, "new@mail.com").Return(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GrpcRoute{service: mockSvc}
+			got, err := g.RequestEmailChange(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.RequestEmailChange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.RequestEmailChange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_Login_mfaChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockAuth := mock.NewMockAuthentication(ctrl)
+	user := &entity.User{
+		Id:           uuid.New(),
+		Email:        "test@mail.com",
+		IsVerified:   true,
+		IsMfaEnabled: true,
+	}
+	mockSvc.EXPECT().Login(gomock.Any(), gomock.Any()).Return(user, nil)
+	mockSvc.EXPECT().CreateMfaChallenge(gomock.Any(), user.Id).Return("mfaToken", nil)
+	// no token may be issued before the second factor
+	mockAuth.EXPECT().GenerateToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	g := &GrpcRoute{service: mockSvc, auth: mockAuth}
+	got, err := g.Login(context.Background(), &pb.UserLoginRequest{Email: "test@mail.com", Password: "123456"})
+	if err != nil {
+		t.Fatalf("GrpcRoute.Login() error = %v", err)
+	}
+	want, _ := structpb.NewStruct(map[string]interface{}{
+		"mfa_required": true,
+		"mfa_token":    "mfaToken",
+	})
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Errorf("GrpcRoute.Login() = %v, want %v", got.Data, want)
+	}
+}
+
+func TestGrpcRoute_VerifyMfa(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvc.EXPECT().CreateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.Session{Id: uuid.New()}, nil).AnyTimes()
+	mockSvcRec := mockSvc.EXPECT()
+	mockAuth := mock.NewMockAuthentication(ctrl)
+	mockAuthRec := mockAuth.EXPECT()
+	user := &entity.User{
+		Id:           uuid.New(),
+		IsMfaEnabled: true,
+	}
+	req := &pb.VerifyMfaRequest{
+		MfaToken: "mfaToken",
+		Code:     "123456",
+	}
+	data, _ := structpb.NewStruct(map[string]interface{}{
+		"access_token":  "accessToken",
+		"refresh_token": "refreshToken",
+	})
+	tests := []struct {
+		name    string
+		req     *pb.VerifyMfaRequest
+		want    *pb.SuccessResponse
+		wantErr bool
+		mocks   []*gomock.Call
+	}{
+		{
+			name:    "missing code",
+			req:     &pb.VerifyMfaRequest{MfaToken: "mfaToken"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "fail verify mfa",
+			req:     req,
+			want:    nil,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockSvcRec.VerifyMfa(gomock.Any(), "mfaToken", "123456").Return(nil, errors.New("any error")),
+			},
+		},
+		{
+			name: "success",
+			req:  req,
+			want: &pb.SuccessResponse{
+				Data: data,
+			},
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockSvcRec.VerifyMfa(gomock.Any(), "mfaToken", "123456").Return(user, nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, gomock.Any(), service.TokenTypeAccess, 60).Return("accessToken", nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, gomock.Any(), service.TokenTypeRefresh, 43200).Return("refreshToken", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GrpcRoute{service: mockSvc, auth: mockAuth}
+			got, err := g.VerifyMfa(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.VerifyMfa() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.VerifyMfa() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockSvcRec := mockSvc.EXPECT()
+	mockAuth := mock.NewMockAuthentication(ctrl)
+	mockAuthRec := mockAuth.EXPECT()
+	user := &entity.User{Id: uuid.New()}
+	session := &entity.Session{Id: uuid.New(), RefreshTokenId: uuid.New()}
+	refreshClaims := &service.JwtCustomClaim{
+		SessionId: session.Id.String(),
+		TokenType: service.TokenTypeRefresh,
+	}
+	refreshClaims.ID = session.RefreshTokenId.String()
+	req := &pb.RefreshTokenRequest{RefreshToken: "refreshToken"}
+	data, _ := structpb.NewStruct(map[string]interface{}{
+		"access_token":  "newAccessToken",
+		"refresh_token": "newRefreshToken",
+	})
+	tests := []struct {
+		name    string
+		want    *pb.SuccessResponse
+		wantErr bool
+		mocks   []*gomock.Call
+	}{
+		{
+			name:    "invalid token",
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockAuthRec.ValidateToken(gomock.Any(), "refreshToken").Return(nil, errors.New("any error")),
+			},
+		},
+		{
+			name:    "access token",
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockAuthRec.ValidateToken(gomock.Any(), "refreshToken").Return(&service.JwtCustomClaim{
+					SessionId: session.Id.String(),
+					TokenType: service.TokenTypeAccess,
+				}, nil),
+			},
+		},
+		{
+			name:    "session invalid",
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockAuthRec.ValidateToken(gomock.Any(), "refreshToken").Return(refreshClaims, nil),
+				mockSvcRec.RefreshSession(gomock.Any(), session.Id, session.RefreshTokenId, "").Return(nil, nil, errors.New("any error")),
+			},
+		},
+		{
+			name: "success",
+			want: &pb.SuccessResponse{
+				Data: data,
+			},
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockAuthRec.ValidateToken(gomock.Any(), "refreshToken").Return(refreshClaims, nil),
+				mockSvcRec.RefreshSession(gomock.Any(), session.Id, session.RefreshTokenId, "").Return(user, session, nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, session, service.TokenTypeAccess, 60).Return("newAccessToken", nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, session, service.TokenTypeRefresh, 43200).Return("newRefreshToken", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GrpcRoute{service: mockSvc, auth: mockAuth}
+			got, err := g.RefreshToken(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.RefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.RefreshToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_ListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	userId := uuid.New()
+	current := &entity.Session{Id: uuid.New(), UserId: userId}
+	other := &entity.Session{Id: uuid.New(), UserId: userId}
+	mockSvc.EXPECT().ListSessions(gomock.Any(), userId).Return([]*entity.Session{current, other}, nil)
+
+	ctx := middleware.SetSessionIDKey(middleware.SetUserIDKey(context.Background(), userId), current.Id)
+	g := &GrpcRoute{service: mockSvc}
+	got, err := g.ListSessions(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("GrpcRoute.ListSessions() error = %v", err)
+	}
+	if len(got.Sessions) != 2 || !got.Sessions[0].Current || got.Sessions[1].Current {
+		t.Errorf("GrpcRoute.ListSessions() = %v, want only %v marked current", got.Sessions, current.Id)
+	}
+}
+
+func TestGrpcRoute_DeactivateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	adminId := uuid.New()
+	userId := uuid.New()
+	ctx := middleware.SetUserIDKey(context.Background(), adminId)
+	tests := []struct {
+		name    string
+		req     *pb.DeactivateUserRequest
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "invalid user id",
+			req:     &pb.DeactivateUserRequest{UserId: "abc"},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail deactivate",
+			req:     &pb.DeactivateUserRequest{UserId: userId.String()},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().DeactivateUser(gomock.Any(), adminId, userId).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			req:     &pb.DeactivateUserRequest{UserId: userId.String()},
+			wantErr: false,
+			mocks: func() {
+				mockSvc.EXPECT().DeactivateUser(gomock.Any(), adminId, userId).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			if _, err := g.DeactivateUser(ctx, tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.DeactivateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_IntrospectToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	userId := uuid.New()
+	tests := []struct {
+		name       string
+		req        *pb.IntrospectTokenRequest
+		wantActive bool
+		wantErr    bool
+		mocks      func()
+	}{
+		{
+			name:    "no token",
+			req:     &pb.IntrospectTokenRequest{},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail introspect",
+			req:     &pb.IntrospectTokenRequest{Token: "abc"},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().IntrospectToken(gomock.Any(), "abc").Return(nil, errors.New("any error"))
+			},
+		},
+		{
+			name: "inactive",
+			req:  &pb.IntrospectTokenRequest{Token: "abc"},
+			mocks: func() {
+				mockSvc.EXPECT().IntrospectToken(gomock.Any(), "abc").Return(&entity.TokenIntrospection{}, nil)
+			},
+		},
+		{
+			name:       "active",
+			req:        &pb.IntrospectTokenRequest{Token: "abc"},
+			wantActive: true,
+			mocks: func() {
+				mockSvc.EXPECT().IntrospectToken(gomock.Any(), "abc").Return(&entity.TokenIntrospection{Active: true, UserId: userId}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			got, err := g.IntrospectToken(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.IntrospectToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Active != tt.wantActive || tt.wantActive && got.Sub != userId.String()) {
+				t.Errorf("GrpcRoute.IntrospectToken() = %v, want active %v", got, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_GetUsersByIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	userId := uuid.New()
+	tests := []struct {
+		name    string
+		req     *pb.GetUsersByIdsRequest
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "invalid id",
+			req:     &pb.GetUsersByIdsRequest{Ids: []string{"abc"}},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "no id",
+			req:     &pb.GetUsersByIdsRequest{},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail lookup",
+			req:     &pb.GetUsersByIdsRequest{Ids: []string{userId.String()}},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().GetUsersByIds(gomock.Any(), []uuid.UUID{userId}).Return(nil, errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			req:     &pb.GetUsersByIdsRequest{Ids: []string{userId.String()}},
+			wantErr: false,
+			mocks: func() {
+				mockSvc.EXPECT().GetUsersByIds(gomock.Any(), []uuid.UUID{userId}).Return([]*entity.UserSummary{{Id: userId, Name: "test"}}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			got, err := g.GetUsersByIds(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.GetUsersByIds() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got.Users) != 1 || got.Users[0].Id != userId.String() || got.Users[0].Name != "test") {
+				t.Errorf("GrpcRoute.GetUsersByIds() = %v, want user %v", got.Users, userId)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_GetUserByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	userId := uuid.New()
+	tests := []struct {
+		name    string
+		req     *pb.GetUserByEmailRequest
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "invalid email",
+			req:     &pb.GetUserByEmailRequest{Email: "test"},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail lookup",
+			req:     &pb.GetUserByEmailRequest{Email: "test@mail.com"},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().GetUserByEmail(gomock.Any(), "test@mail.com").Return(nil, errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			req:     &pb.GetUserByEmailRequest{Email: "test@mail.com"},
+			wantErr: false,
+			mocks: func() {
+				mockSvc.EXPECT().GetUserByEmail(gomock.Any(), "test@mail.com").Return(&entity.UserSummary{Id: userId, Email: "test@mail.com"}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			got, err := g.GetUserByEmail(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.GetUserByEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.User.Id != userId.String() {
+				t.Errorf("GrpcRoute.GetUserByEmail() = %v, want user %v", got.User, userId)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_SetUserRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	adminId := uuid.New()
+	userId := uuid.New()
+	ctx := middleware.SetUserIDKey(context.Background(), adminId)
+	tests := []struct {
+		name    string
+		req     *pb.SetUserRolesRequest
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "no role",
+			req:     &pb.SetUserRolesRequest{UserId: userId.String()},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail set roles",
+			req:     &pb.SetUserRolesRequest{UserId: userId.String(), RoleIds: []string{"1"}},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().SetUserRoles(gomock.Any(), adminId, userId, []string{"1"}).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			req:     &pb.SetUserRolesRequest{UserId: userId.String(), RoleIds: []string{"1", "2"}},
+			wantErr: false,
+			mocks: func() {
+				mockSvc.EXPECT().SetUserRoles(gomock.Any(), adminId, userId, []string{"1", "2"}).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			if _, err := g.SetUserRoles(ctx, tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.SetUserRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type exportStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*httpbody.HttpBody
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportStream) Send(chunk *httpbody.HttpBody) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+func TestGrpcRoute_ExportMyData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	userId := uuid.New()
+	// an address large enough to be split across chunks
+	address := strings.Repeat("a", exportChunkSize)
+	mockSvc.EXPECT().ExportUserData(gomock.Any(), userId).Return(&entity.DataExport{
+		Profile: entity.ProfileExport{Id: userId, Address: address},
+	}, nil)
+
+	stream := &exportStream{ctx: middleware.SetUserIDKey(context.Background(), userId)}
+	g := &GrpcRoute{service: mockSvc}
+	if err := g.ExportMyData(&emptypb.Empty{}, stream); err != nil {
+		t.Fatalf("GrpcRoute.ExportMyData() error = %v", err)
+	}
+	if len(stream.chunks) != 2 {
+		t.Fatalf("GrpcRoute.ExportMyData() sent %v chunks, want 2", len(stream.chunks))
+	}
+	var data []byte
+	for _, chunk := range stream.chunks {
+		if chunk.ContentType != "application/json" {
+			t.Errorf("GrpcRoute.ExportMyData() content type = %v, want application/json", chunk.ContentType)
+		}
+		data = append(data, chunk.Data...)
+	}
+	var got entity.DataExport
+	if err := json.Unmarshal(data, &got); err != nil || got.Profile.Id != userId || got.Profile.Address != address {
+		t.Errorf("GrpcRoute.ExportMyData() archive = %v, %v, want export of %v", got.Profile.Id, err, userId)
+	}
+}
+
+func TestGrpcRoute_ListAuditEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	actorId := uuid.New()
+	from := time.Now().Add(-time.Hour).UTC()
+	event := &entity.AuditEvent{
+		Id:           uuid.New(),
+		Action:       entity.AuditUserDeactivated,
+		ActorId:      uuid.NullUUID{UUID: actorId, Valid: true},
+		TargetUserId: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+	}
+	tests := []struct {
+		name    string
+		req     *pb.ListAuditEventsRequest
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "invalid actor id",
+			req:     &pb.ListAuditEventsRequest{ActorId: "abc"},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail list",
+			req:     &pb.ListAuditEventsRequest{},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().ListAuditEvents(gomock.Any(), entity.AuditFilter{}).Return(nil, int64(0), errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			req:     &pb.ListAuditEventsRequest{ActorId: actorId.String(), From: timestamppb.New(from), Page: 2, PageSize: 20},
+			wantErr: false,
+			mocks: func() {
+				filter := entity.AuditFilter{ActorId: uuid.NullUUID{UUID: actorId, Valid: true}, From: from, Page: 2, PageSize: 20}
+				mockSvc.EXPECT().ListAuditEvents(gomock.Any(), filter).Return([]*entity.AuditEvent{event}, int64(21), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			got, err := g.ListAuditEvents(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.ListAuditEvents() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Total != 21 || len(got.Events) != 1 || got.Events[0].ActorId != actorId.String() {
+				t.Errorf("GrpcRoute.ListAuditEvents() = %v, want event %v of 21", got, event.Id)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_GetMyLoginHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	userId := uuid.New()
+	attempt := &entity.LoginAttempt{Id: uuid.New(), UserId: userId, Method: entity.LoginMethodPassword, FailureReason: "wrong_password"}
+	mockSvc.EXPECT().GetMyLoginHistory(gomock.Any(), userId, 2, 10).Return([]*entity.LoginAttempt{attempt}, int64(11), nil)
+
+	g := &GrpcRoute{service: mockSvc}
+	got, err := g.GetMyLoginHistory(middleware.SetUserIDKey(context.Background(), userId), &pb.GetMyLoginHistoryRequest{Page: 2, PageSize: 10})
+	if err != nil {
+		t.Fatalf("GrpcRoute.GetMyLoginHistory() error = %v", err)
+	}
+	if got.Total != 11 || len(got.Attempts) != 1 || got.Attempts[0].Succeeded || got.Attempts[0].FailureReason != "wrong_password" {
+		t.Errorf("GrpcRoute.GetMyLoginHistory() = %v, want the failed attempt %v of 11", got, attempt.Id)
+	}
+}
+
+func TestGrpcRoute_CreateOAuthClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	adminId := uuid.New()
+	ctx := middleware.SetUserIDKey(context.Background(), adminId)
+	redirectUris := []string{"https://app.mitra.test/callback"}
+	client := &entity.OAuthClient{Id: "client", Name: "Mitra", RedirectUris: redirectUris, SecretHash: "hash"}
+	tests := []struct {
+		name    string
+		req     *pb.CreateOAuthClientRequest
+		want    *pb.CreateOAuthClientResponse
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "no redirect uri",
+			req:     &pb.CreateOAuthClientRequest{Name: "Mitra"},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "invalid redirect uri",
+			req:     &pb.CreateOAuthClientRequest{Name: "Mitra", RedirectUris: []string{"callback"}},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail create client",
+			req:     &pb.CreateOAuthClientRequest{Name: "Mitra", RedirectUris: redirectUris},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().CreateOAuthClient(gomock.Any(), adminId, "Mitra", redirectUris, false).Return(nil, "", errors.New("any error"))
+			},
+		},
+		{
+			name: "success",
+			req:  &pb.CreateOAuthClientRequest{Name: "Mitra", RedirectUris: redirectUris, Confidential: true},
+			want: &pb.CreateOAuthClientResponse{
+				Client:       &pb.OAuthClient{ClientId: "client", Name: "Mitra", RedirectUris: redirectUris, Confidential: true},
+				ClientSecret: "secret",
+			},
+			mocks: func() {
+				mockSvc.EXPECT().CreateOAuthClient(gomock.Any(), adminId, "Mitra", redirectUris, true).Return(client, "secret", nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			got, err := g.CreateOAuthClient(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GrpcRoute.CreateOAuthClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.CreateOAuthClient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_CreateServiceClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	adminId := uuid.New()
+	ctx := middleware.SetUserIDKey(context.Background(), adminId)
+	scopes := []string{entity.ScopeUsersRead}
+	client := &entity.ServiceClient{Id: uuid.New(), Name: "order", KeyPrefix: "0123456789abcdef", Scopes: scopes}
+	tests := []struct {
+		name    string
+		req     *pb.CreateServiceClientRequest
+		want    *pb.CreateServiceClientResponse
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "no scope",
+			req:     &pb.CreateServiceClientRequest{Name: "order"},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "unknown scope",
+			req:     &pb.CreateServiceClientRequest{Name: "order", Scopes: []string{"users:write"}},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail create client",
+			req:     &pb.CreateServiceClientRequest{Name: "order", Scopes: scopes},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().CreateServiceClient(gomock.Any(), adminId, "order", scopes).Return(nil, "", errors.New("any error"))
+			},
+		},
+		{
+			name: "success",
+			req:  &pb.CreateServiceClientRequest{Name: "order", Scopes: scopes},
+			want: &pb.CreateServiceClientResponse{
+				Client: client.ToProto(),
+				ApiKey: "mk_0123456789abcdef_secret",
+			},
+			mocks: func() {
+				mockSvc.EXPECT().CreateServiceClient(gomock.Any(), adminId, "order", scopes).Return(client, "mk_0123456789abcdef_secret", nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			got, err := g.CreateServiceClient(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GrpcRoute.CreateServiceClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.CreateServiceClient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_RevokeServiceClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	adminId := uuid.New()
+	clientId := uuid.New()
+	ctx := middleware.SetUserIDKey(context.Background(), adminId)
+	tests := []struct {
+		name    string
+		req     *pb.RevokeServiceClientRequest
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "invalid client id",
+			req:     &pb.RevokeServiceClientRequest{ClientId: "order"},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail revoke",
+			req:     &pb.RevokeServiceClientRequest{ClientId: clientId.String()},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().RevokeServiceClient(gomock.Any(), adminId, clientId).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			req:     &pb.RevokeServiceClientRequest{ClientId: clientId.String()},
+			wantErr: false,
+			mocks: func() {
+				mockSvc.EXPECT().RevokeServiceClient(gomock.Any(), adminId, clientId).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			if _, err := g.RevokeServiceClient(ctx, tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.RevokeServiceClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_ImpersonateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockAuth := mock.NewMockAuthentication(ctrl)
+	adminId := uuid.New()
+	ctx := middleware.SetUserIDKey(context.Background(), adminId)
+	user := &entity.User{Id: uuid.New()}
+	session := &entity.Session{Id: uuid.New(), UserId: user.Id, ImpersonatorId: uuid.NullUUID{UUID: adminId, Valid: true}}
+	tests := []struct {
+		name    string
+		req     *pb.ImpersonateUserRequest
+		want    *pb.ImpersonateUserResponse
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "invalid user id",
+			req:     &pb.ImpersonateUserRequest{UserId: "customer", Reason: "ticket 42"},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "reason required",
+			req:     &pb.ImpersonateUserRequest{UserId: user.Id.String()},
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail start impersonation",
+			req:     &pb.ImpersonateUserRequest{UserId: user.Id.String(), Reason: "ticket 42"},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().StartImpersonation(gomock.Any(), adminId, user.Id, "ticket 42", gomock.Any()).Return(nil, nil, errors.New("any error"))
+			},
+		},
+		{
+			name:    "fail generate token",
+			req:     &pb.ImpersonateUserRequest{UserId: user.Id.String(), Reason: "ticket 42"},
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().StartImpersonation(gomock.Any(), adminId, user.Id, "ticket 42", gomock.Any()).Return(user, session, nil)
+				mockAuth.EXPECT().GenerateToken(gomock.Any(), user, session, service.TokenTypeAccess, 15).Return("", errors.New("any error"))
+			},
+		},
+		{
+			name: "success",
+			req:  &pb.ImpersonateUserRequest{UserId: user.Id.String(), Reason: "ticket 42"},
+			want: &pb.ImpersonateUserResponse{
+				AccessToken: "access",
+				ExpiresIn:   900,
+				SessionId:   session.Id.String(),
+			},
+			mocks: func() {
+				mockSvc.EXPECT().StartImpersonation(gomock.Any(), adminId, user.Id, "ticket 42", gomock.Any()).Return(user, session, nil)
+				mockAuth.EXPECT().GenerateToken(gomock.Any(), user, session, service.TokenTypeAccess, 15).Return("access", nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc, auth: mockAuth}
+			got, err := g.ImpersonateUser(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GrpcRoute.ImpersonateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrpcRoute.ImpersonateUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcRoute_StopImpersonation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	adminId := uuid.New()
+	userId := uuid.New()
+	sessionId := uuid.New()
+	ctx := middleware.SetSessionIDKey(middleware.SetUserIDKey(context.Background(), userId), sessionId)
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "not an impersonation",
+			ctx:     ctx,
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "fail stop",
+			ctx:     middleware.SetActorIDKey(ctx, adminId),
+			wantErr: true,
+			mocks: func() {
+				mockSvc.EXPECT().StopImpersonation(gomock.Any(), adminId, userId, sessionId).Return(errors.New("any error"))
+			},
+		},
+		{
+			name: "success",
+			ctx:  middleware.SetActorIDKey(ctx, adminId),
+			mocks: func() {
+				mockSvc.EXPECT().StopImpersonation(gomock.Any(), adminId, userId, sessionId).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			g := &GrpcRoute{service: mockSvc}
+			if _, err := g.StopImpersonation(tt.ctx, &emptypb.Empty{}); (err != nil) != tt.wantErr {
+				t.Errorf("GrpcRoute.StopImpersonation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
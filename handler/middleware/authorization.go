@@ -12,7 +12,19 @@ import (
 type contextKey uint
 
 const (
-	userIdKey contextKey = 1
+	userIdKey          contextKey = 1
+	sessionIdKey       contextKey = 2
+	principalTypeKey   contextKey = 3
+	serviceClientIdKey contextKey = 4
+	actorIdKey         contextKey = 5
+)
+
+// PrincipalType tells the users calling a protected rpc from the services calling it with an api key
+type PrincipalType string
+
+const (
+	PrincipalUser    PrincipalType = "user"
+	PrincipalService PrincipalType = "service"
 )
 
 func GetToken(ctx context.Context) (token string, err error) {
@@ -48,3 +60,64 @@ func GetUserIDValue(ctx context.Context) uuid.UUID {
 	}
 	return uuid.Nil
 }
+
+func SetSessionIDKey(ctx context.Context, sessionId uuid.UUID) context.Context {
+	return context.WithValue(ctx, sessionIdKey, sessionId)
+}
+
+// GetSessionIDValue returns the session of the access token used for the request
+func GetSessionIDValue(ctx context.Context) uuid.UUID {
+	if ctx == nil {
+		return uuid.Nil
+	}
+	if sessionId, ok := ctx.Value(sessionIdKey).(uuid.UUID); ok {
+		return sessionId
+	}
+	return uuid.Nil
+}
+
+func SetPrincipalTypeKey(ctx context.Context, principalType PrincipalType) context.Context {
+	return context.WithValue(ctx, principalTypeKey, principalType)
+}
+
+// GetPrincipalTypeValue returns who authenticated the request, empty for the public rpcs. Requests
+// of services carry no user, see GetServiceClientIDValue.
+func GetPrincipalTypeValue(ctx context.Context) PrincipalType {
+	if ctx == nil {
+		return ""
+	}
+	if principalType, ok := ctx.Value(principalTypeKey).(PrincipalType); ok {
+		return principalType
+	}
+	return ""
+}
+
+func SetServiceClientIDKey(ctx context.Context, clientId uuid.UUID) context.Context {
+	return context.WithValue(ctx, serviceClientIdKey, clientId)
+}
+
+// GetServiceClientIDValue returns the service client of the api key used for the request
+func GetServiceClientIDValue(ctx context.Context) uuid.UUID {
+	if ctx == nil {
+		return uuid.Nil
+	}
+	if clientId, ok := ctx.Value(serviceClientIdKey).(uuid.UUID); ok {
+		return clientId
+	}
+	return uuid.Nil
+}
+
+func SetActorIDKey(ctx context.Context, actorId uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorIdKey, actorId)
+}
+
+// GetActorIDValue returns the admin impersonating the user of the request, uuid.Nil otherwise
+func GetActorIDValue(ctx context.Context) uuid.UUID {
+	if ctx == nil {
+		return uuid.Nil
+	}
+	if actorId, ok := ctx.Value(actorIdKey).(uuid.UUID); ok {
+		return actorId
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPrincipal(t *testing.T) {
+	ctx := context.Background()
+	if got := GetPrincipalTypeValue(ctx); got != "" {
+		t.Errorf("GetPrincipalTypeValue() = %v, want none for public rpcs", got)
+	}
+
+	clientId := uuid.New()
+	ctx = SetServiceClientIDKey(SetPrincipalTypeKey(ctx, PrincipalService), clientId)
+	if got := GetPrincipalTypeValue(ctx); got != PrincipalService {
+		t.Errorf("GetPrincipalTypeValue() = %v, want %v", got, PrincipalService)
+	}
+	if got := GetServiceClientIDValue(ctx); got != clientId {
+		t.Errorf("GetServiceClientIDValue() = %v, want %v", got, clientId)
+	}
+	if got := GetUserIDValue(ctx); got != uuid.Nil {
+		t.Errorf("GetUserIDValue() = %v, want no user for services", got)
+	}
+}
+
+func TestActor(t *testing.T) {
+	userId := uuid.New()
+	ctx := SetUserIDKey(context.Background(), userId)
+	if got := GetActorIDValue(ctx); got != uuid.Nil {
+		t.Errorf("GetActorIDValue() = %v, want none outside impersonations", got)
+	}
+
+	actorId := uuid.New()
+	ctx = SetActorIDKey(ctx, actorId)
+	if got := GetActorIDValue(ctx); got != actorId {
+		t.Errorf("GetActorIDValue() = %v, want %v", got, actorId)
+	}
+	if got := GetUserIDValue(ctx); got != userId {
+		t.Errorf("GetUserIDValue() = %v, want the impersonated user %v", got, userId)
+	}
+}
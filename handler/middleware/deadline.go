@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Timeouts holds the deadline applied to each rpc, keyed by full method name
+type Timeouts struct {
+	Default   time.Duration
+	PerMethod map[string]time.Duration
+}
+
+func (t Timeouts) forMethod(method string) time.Duration {
+	if timeout, ok := t.PerMethod[method]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// DeadlineUnaryServerInterceptor bounds every rpc by its configured timeout unless the caller
+// already asked for a shorter deadline, so database and redis calls are cancelled with the request.
+// Handlers failing because the request ran out of time or was cancelled are reported
+// as DeadlineExceeded or Canceled instead of the internal error built by the service.
+func DeadlineUnaryServerInterceptor(timeouts Timeouts) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if timeout := timeouts.forMethod(info.FullMethod); timeout > 0 {
+			deadline := time.Now().Add(timeout)
+			if current, ok := ctx.Deadline(); !ok || deadline.Before(current) {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, deadline)
+				defer cancel()
+			}
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = contextError(ctx, err)
+		}
+		return resp, err
+	}
+}
+
+func contextError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request timed out")
+	case errors.Is(ctx.Err(), context.Canceled) || errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request cancelled")
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeadlineUnaryServerInterceptor(t *testing.T) {
+	timeouts := Timeouts{
+		Default: time.Second,
+		PerMethod: map[string]time.Duration{
+			"/proto.UserService/Register": 2 * time.Second,
+		},
+	}
+	tests := []struct {
+		name         string
+		method       string
+		callerCtx    func() (context.Context, context.CancelFunc)
+		handlerErr   func(ctx context.Context) error
+		wantDeadline time.Duration
+		wantCode     codes.Code
+	}{
+		{
+			name:         "default deadline applied",
+			method:       "/proto.UserService/Login",
+			callerCtx:    func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			handlerErr:   func(ctx context.Context) error { return nil },
+			wantDeadline: time.Second,
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "per method deadline applied",
+			method:       "/proto.UserService/Register",
+			callerCtx:    func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			handlerErr:   func(ctx context.Context) error { return nil },
+			wantDeadline: 2 * time.Second,
+			wantCode:     codes.OK,
+		},
+		{
+			name:   "shorter caller deadline kept",
+			method: "/proto.UserService/Login",
+			callerCtx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 100*time.Millisecond)
+			},
+			handlerErr:   func(ctx context.Context) error { return nil },
+			wantDeadline: 100 * time.Millisecond,
+			wantCode:     codes.OK,
+		},
+		{
+			name:   "timed out request mapped to deadline exceeded",
+			method: "/proto.UserService/Login",
+			callerCtx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Millisecond)
+			},
+			handlerErr: func(ctx context.Context) error {
+				<-ctx.Done()
+				return status.Error(codes.Internal, "query failed")
+			},
+			wantDeadline: time.Millisecond,
+			wantCode:     codes.DeadlineExceeded,
+		},
+		{
+			name:   "cancelled request mapped to canceled",
+			method: "/proto.UserService/Login",
+			callerCtx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			handlerErr: func(ctx context.Context) error {
+				return errors.New("any error")
+			},
+			wantDeadline: time.Second,
+			wantCode:     codes.Canceled,
+		},
+		{
+			name:         "other errors untouched",
+			method:       "/proto.UserService/Login",
+			callerCtx:    func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			handlerErr:   func(ctx context.Context) error { return status.Error(codes.NotFound, "not found") },
+			wantDeadline: time.Second,
+			wantCode:     codes.NotFound,
+		},
+	}
+	interceptor := DeadlineUnaryServerInterceptor(timeouts)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.callerCtx()
+			defer cancel()
+			start := time.Now()
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					deadline, ok := ctx.Deadline()
+					if !ok {
+						t.Errorf("DeadlineUnaryServerInterceptor() no deadline set")
+					} else if got := deadline.Sub(start); got > tt.wantDeadline+50*time.Millisecond {
+						t.Errorf("DeadlineUnaryServerInterceptor() deadline = %v, want %v", got, tt.wantDeadline)
+					}
+					return nil, tt.handlerErr(ctx)
+				})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("DeadlineUnaryServerInterceptor() code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// DeviceNameHeader lets clients name the device a session is created from, e.g. "Pixel 8"
+const DeviceNameHeader = "x-device-name"
+
+// TrustedProxies is the number of proxies in front of the gateway, each appending the address
+// it received the request from to x-forwarded-for
+var TrustedProxies = 0
+
+// DeviceFromContext describes the client of the request from its metadata. Requests through
+// the gateway carry the user agent as grpcgateway-user-agent and the client ip in x-forwarded-for.
+func DeviceFromContext(ctx context.Context) entity.Device {
+	var device entity.Device
+	md, _ := metadata.FromIncomingContext(ctx)
+	device.Name = firstValue(md, DeviceNameHeader)
+	device.UserAgent = firstValue(md, "grpcgateway-user-agent")
+	if device.UserAgent == "" {
+		device.UserAgent = firstValue(md, "user-agent")
+	}
+	device.IpAddress = clientIp(ctx, md)
+	return device
+}
+
+// clientIp reads x-forwarded-for from the right: the gateway appends the address it was called
+// from and each trusted proxy the one before, values further left are set by the client. The
+// header is only trusted from the gateway, calling in process or over loopback, other grpc
+// clients are identified by their own address.
+func clientIp(ctx context.Context, md metadata.MD) string {
+	var peerIp string
+	p, hasPeer := peer.FromContext(ctx)
+	if hasPeer && p.Addr != nil {
+		peerIp = p.Addr.String()
+		if host, _, err := net.SplitHostPort(peerIp); err == nil {
+			peerIp = host
+		}
+	}
+	if hasPeer && !isLoopback(peerIp) {
+		return peerIp
+	}
+	var hops []string
+	for _, value := range md.Get("x-forwarded-for") {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	if len(hops) == 0 {
+		return peerIp
+	}
+	return hops[max(len(hops)-1-TrustedProxies, 0)]
+}
+
+func isLoopback(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.IsLoopback()
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestDeviceFromContext(t *testing.T) {
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 52000},
+	})
+	gatewayCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 52000},
+	})
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		trustedProxies int
+		want           entity.Device
+	}{
+		{
+			name: "no metadata",
+			ctx:  context.Background(),
+			want: entity.Device{},
+		},
+		{
+			name: "through gateway",
+			ctx: metadata.NewIncomingContext(gatewayCtx, metadata.Pairs(
+				"x-device-name", "Pixel 8",
+				"grpcgateway-user-agent", "okhttp/4.12",
+				"user-agent", "grpc-go/1.60",
+				"x-forwarded-for", "198.51.100.1, 203.0.113.7",
+			)),
+			want: entity.Device{Name: "Pixel 8", UserAgent: "okhttp/4.12", IpAddress: "203.0.113.7"},
+		},
+		{
+			name:           "through a load balancer and the gateway",
+			ctx:            metadata.NewIncomingContext(gatewayCtx, metadata.Pairs("x-forwarded-for", "198.51.100.1, 203.0.113.7", "x-forwarded-for", "10.0.0.1")),
+			trustedProxies: 1,
+			want:           entity.Device{IpAddress: "203.0.113.7"},
+		},
+		{
+			name:           "fewer hops than trusted proxies",
+			ctx:            metadata.NewIncomingContext(gatewayCtx, metadata.Pairs("x-forwarded-for", "203.0.113.7")),
+			trustedProxies: 2,
+			want:           entity.Device{IpAddress: "203.0.113.7"},
+		},
+		{
+			name: "in process",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.1, 203.0.113.7")),
+			want: entity.Device{IpAddress: "203.0.113.7"},
+		},
+		{
+			name: "direct grpc call spoofing x-forwarded-for",
+			ctx:  metadata.NewIncomingContext(peerCtx, metadata.Pairs("x-forwarded-for", "198.51.100.1")),
+			want: entity.Device{IpAddress: "10.0.0.2"},
+		},
+		{
+			name: "direct grpc call",
+			ctx:  metadata.NewIncomingContext(peerCtx, metadata.Pairs("user-agent", "grpc-go/1.60")),
+			want: entity.Device{UserAgent: "grpc-go/1.60", IpAddress: "10.0.0.2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TrustedProxies = tt.trustedProxies
+			defer func() { TrustedProxies = 0 }()
+			if got := DeviceFromContext(tt.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeviceFromContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
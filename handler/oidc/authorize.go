@@ -0,0 +1,316 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	codeChallengeMethod = "S256"
+	csrfCookie          = "oidc_csrf"
+)
+
+var supportedScopes = []string{entity.ScopeOpenId, entity.ScopeProfile, entity.ScopeEmail, entity.ScopePhone}
+
+// authorizeRequest holds the parameters of an authorization request, carried through the
+// sign in form as hidden fields
+type authorizeRequest struct {
+	ClientId            string
+	RedirectUri         string
+	ResponseType        string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Prompt              string
+}
+
+func parseAuthorizeRequest(form url.Values) authorizeRequest {
+	return authorizeRequest{
+		ClientId:            form.Get("client_id"),
+		RedirectUri:         form.Get("redirect_uri"),
+		ResponseType:        form.Get("response_type"),
+		Scope:               form.Get("scope"),
+		State:               form.Get("state"),
+		Nonce:               form.Get("nonce"),
+		CodeChallenge:       form.Get("code_challenge"),
+		CodeChallengeMethod: form.Get("code_challenge_method"),
+		Prompt:              form.Get("prompt"),
+	}
+}
+
+func (a authorizeRequest) hiddenFields() map[string]string {
+	return map[string]string{
+		"client_id":             a.ClientId,
+		"redirect_uri":          a.RedirectUri,
+		"response_type":         a.ResponseType,
+		"scope":                 a.Scope,
+		"state":                 a.State,
+		"nonce":                 a.Nonce,
+		"code_challenge":        a.CodeChallenge,
+		"code_challenge_method": a.CodeChallengeMethod,
+	}
+}
+
+// grantedScope drops the scopes the provider does not know
+func (a authorizeRequest) grantedScope() string {
+	var scopes []string
+	for _, scope := range strings.Fields(a.Scope) {
+		if slices.Contains(supportedScopes, scope) && !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+// validate returns the error sent back to the client when the request cannot be served
+func (a authorizeRequest) validate() (errorCode string, description string) {
+	switch {
+	case a.ResponseType != "code":
+		return "unsupported_response_type", "only the code response type is supported"
+	case !slices.Contains(strings.Fields(a.Scope), entity.ScopeOpenId):
+		return "invalid_scope", "the openid scope is required"
+	case a.CodeChallenge == "":
+		return "invalid_request", "code_challenge is required"
+	case a.CodeChallengeMethod != codeChallengeMethod:
+		return "invalid_request", "only the S256 code_challenge_method is supported"
+	case a.Prompt == "none":
+		// users always sign in, there is no session of the provider to reuse
+		return "login_required", "the user must sign in"
+	}
+	return "", ""
+}
+
+type loginPage struct {
+	ClientName string
+	Error      string
+	Email      string
+	MfaToken   string
+	CSRF       string
+	Fields     map[string]string
+	// RedirectOrigin is allowed as form action, the form is answered with a redirect to the client
+	RedirectOrigin string
+}
+
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Masuk</title>
+</head>
+<body>
+{{if .ClientName}}<h1>Masuk ke {{.ClientName}}</h1>{{end}}
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+{{if .Fields}}
+<form method="post">
+{{range $name, $value := .Fields}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}<input type="hidden" name="csrf_token" value="{{.CSRF}}">
+{{if .MfaToken}}<input type="hidden" name="mfa_token" value="{{.MfaToken}}">
+<label>Kode verifikasi <input name="code" inputmode="numeric" autocomplete="one-time-code" required autofocus></label>
+{{else}}<label>Email <input type="email" name="email" value="{{.Email}}" autocomplete="username" required autofocus></label>
+<label>Kata sandi <input type="password" name="password" autocomplete="current-password" required></label>
+{{end}}<button type="submit">Masuk</button>
+</form>
+{{end}}
+</body>
+</html>
+`))
+
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		renderPage(w, http.StatusBadRequest, loginPage{Error: "Permintaan tidak valid"})
+		return
+	}
+	ctx := requestContext(r)
+	req := parseAuthorizeRequest(r.Form)
+
+	// without a registered client and redirect uri errors cannot be sent back to the client
+	client, err := p.service.GetOAuthClient(ctx, req.ClientId)
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			renderPage(w, http.StatusBadRequest, loginPage{Error: "Aplikasi tidak terdaftar"})
+			return
+		}
+		logger.FromContext(ctx).WithError(err).Error("failed to get oauth client")
+		renderPage(w, http.StatusInternalServerError, loginPage{Error: "Terjadi kesalahan, silahkan coba lagi"})
+		return
+	}
+	if !client.AllowsRedirectUri(req.RedirectUri) {
+		renderPage(w, http.StatusBadRequest, loginPage{Error: "Alamat redirect tidak terdaftar untuk aplikasi ini"})
+		return
+	}
+	if errorCode, description := req.validate(); errorCode != "" {
+		redirectError(w, r, req, errorCode, description)
+		return
+	}
+
+	page := loginPage{
+		ClientName:     client.Name,
+		Fields:         req.hiddenFields(),
+		RedirectOrigin: redirectOrigin(req.RedirectUri),
+	}
+	if r.Method == http.MethodGet {
+		page.CSRF, err = randomString()
+		if err != nil {
+			logger.FromContext(ctx).WithError(err).Error("failed to generate csrf token")
+			renderPage(w, http.StatusInternalServerError, loginPage{Error: "Terjadi kesalahan, silahkan coba lagi"})
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     csrfCookie,
+			Value:    page.CSRF,
+			Path:     AuthorizePath,
+			HttpOnly: true,
+			Secure:   isHTTPS(r),
+			SameSite: http.SameSiteStrictMode,
+		})
+		renderPage(w, http.StatusOK, page)
+		return
+	}
+
+	// the form is posted along with the cookie set when it was rendered
+	cookie, err := r.Cookie(csrfCookie)
+	page.CSRF = r.PostForm.Get("csrf_token")
+	if err != nil || page.CSRF == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(page.CSRF)) != 1 {
+		renderPage(w, http.StatusForbidden, loginPage{Error: "Sesi formulir kedaluwarsa, silahkan muat ulang halaman"})
+		return
+	}
+	user, page := p.signIn(ctx, r, page)
+	if user == nil {
+		renderPage(w, http.StatusOK, page)
+		return
+	}
+
+	code, err := p.service.CreateAuthorizationCode(ctx, &entity.AuthorizationCode{
+		ClientId:      client.Id,
+		RedirectUri:   req.RedirectUri,
+		UserId:        user.Id,
+		Scope:         req.grantedScope(),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      time.Now(),
+	})
+	if err != nil {
+		redirectError(w, r, req, "server_error", "failed to issue the authorization code")
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: csrfCookie, Path: AuthorizePath, MaxAge: -1})
+	redirect(w, r, req, url.Values{"code": {code}})
+}
+
+// signIn checks the password, then the second factor of users who enabled it. Until the user
+// is signed in it returns the page to render next.
+func (p *Provider) signIn(ctx context.Context, r *http.Request, page loginPage) (*entity.User, loginPage) {
+	if mfaToken := r.PostForm.Get("mfa_token"); mfaToken != "" {
+		user, err := p.service.VerifyMfa(ctx, mfaToken, strings.TrimSpace(r.PostForm.Get("code")))
+		if err != nil {
+			// an expired challenge starts over from the password
+			if status.Code(err) == codes.Unauthenticated {
+				page.Error = "Sesi login telah berakhir, silahkan masuk kembali"
+				return nil, page
+			}
+			page.MfaToken = mfaToken
+			page.Error = signInError(err, "Kode verifikasi tidak sesuai")
+			return nil, page
+		}
+		return user, page
+	}
+
+	page.Email = strings.TrimSpace(r.PostForm.Get("email"))
+	user, err := p.service.Login(ctx, entity.LoginRequest{
+		Email:    page.Email,
+		Password: r.PostForm.Get("password"),
+	})
+	if err != nil {
+		page.Error = signInError(err, "Email atau kata sandi tidak sesuai")
+		return nil, page
+	}
+	if !user.IsMfaEnabled {
+		return user, page
+	}
+	if page.MfaToken, err = p.service.CreateMfaChallenge(ctx, user.Id); err != nil {
+		page.Error = signInError(err, "")
+	}
+	return nil, page
+}
+
+func signInError(err error, credentialsMessage string) string {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound:
+		if credentialsMessage != "" {
+			return credentialsMessage
+		}
+	case codes.PermissionDenied:
+		return "Akun anda tidak dapat digunakan untuk masuk"
+	}
+	return "Terjadi kesalahan, silahkan coba lagi"
+}
+
+func renderPage(w http.ResponseWriter, code int, page loginPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	// the form takes passwords, it must not be framed by other sites
+	w.Header().Set("X-Frame-Options", "DENY")
+	// browsers apply form-action to the redirects following a submission too
+	formAction := "'self'"
+	if page.RedirectOrigin != "" {
+		formAction += " " + page.RedirectOrigin
+	}
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; form-action "+formAction+"; frame-ancestors 'none'")
+	w.WriteHeader(code)
+	loginTemplate.Execute(w, page)
+}
+
+// redirectOrigin returns the csp source of redirectUri: its origin, or its scheme for the custom
+// schemes of mobile apps. It is empty when the uri cannot be written in a policy.
+func redirectOrigin(redirectUri string) string {
+	target, err := url.Parse(redirectUri)
+	if err != nil || target.Scheme == "" {
+		return ""
+	}
+	origin := target.Scheme + ":"
+	if target.Host != "" {
+		origin += "//" + target.Host
+	}
+	if strings.ContainsAny(origin, " ;,'") {
+		return ""
+	}
+	return origin
+}
+
+func redirectError(w http.ResponseWriter, r *http.Request, req authorizeRequest, errorCode string, description string) {
+	redirect(w, r, req, url.Values{"error": {errorCode}, "error_description": {description}})
+}
+
+// redirect sends the user back to the redirect uri of the client with params and the state of the request
+func redirect(w http.ResponseWriter, r *http.Request, req authorizeRequest, params url.Values) {
+	// registered redirect uris are validated as uris
+	target, _ := url.Parse(req.RedirectUri)
+	query := target.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	target.RawQuery = query.Encode()
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
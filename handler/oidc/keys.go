@@ -0,0 +1,79 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const signingAlg = "RS256"
+
+// Signer signs id tokens with an rsa key, its public key is served at KeysPath for clients to verify them
+type Signer struct {
+	key *rsa.PrivateKey
+	kid string
+}
+
+func NewSigner(key *rsa.PrivateKey) *Signer {
+	// the kid follows the key so clients pick the right one while keys are rotated
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	sum := sha256.Sum256(der)
+	return &Signer{
+		key: key,
+		kid: base64.RawURLEncoding.EncodeToString(sum[:12]),
+	}
+}
+
+// ParseSigningKey reads a pem encoded rsa private key, PKCS #1 or PKCS #8
+func ParseSigningKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("signing key is not an rsa key")
+	}
+	return rsaKey, nil
+}
+
+func (s *Signer) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.kid
+	return token.SignedString(s.key)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (s *Signer) keySet() map[string][]jsonWebKey {
+	return map[string][]jsonWebKey{
+		"keys": {{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: signingAlg,
+			Kid: s.kid,
+			N:   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	}
+}
@@ -0,0 +1,134 @@
+// Package oidc serves the openid connect provider our apps sign their users in with: the authorization
+// code flow with PKCE, the token, userinfo and discovery endpoints, on the http gateway.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/handler/middleware"
+	"github.com/Mitra-Apps/be-user-service/service"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+// Endpoints of the provider, relative to its issuer
+const (
+	DiscoveryPath = "/.well-known/openid-configuration"
+	AuthorizePath = "/oauth2/authorize"
+	TokenPath     = "/oauth2/token"
+	UserInfoPath  = "/oauth2/userinfo"
+	KeysPath      = "/oauth2/jwks"
+)
+
+// lifetimes of the tokens issued, matching the tokens of the login rpcs
+const (
+	accessTokenMinutes  = 60
+	refreshTokenMinutes = 43200
+)
+
+type Provider struct {
+	service service.ServiceInterface
+	auth    service.Authentication
+	signer  *Signer
+	issuer  string
+}
+
+// New serves the provider identified by issuer, the public url of the gateway.
+// Users and sessions come from svc, access and refresh tokens from auth and id tokens are signed by signer.
+func New(svc service.ServiceInterface, auth service.Authentication, signer *Signer, issuer string) *Provider {
+	return &Provider{
+		service: svc,
+		auth:    auth,
+		signer:  signer,
+		issuer:  strings.TrimSuffix(issuer, "/"),
+	}
+}
+
+// Register adds the endpoints of the provider to mux
+func (p *Provider) Register(mux *runtime.ServeMux) error {
+	routes := []struct {
+		method  string
+		path    string
+		handler http.HandlerFunc
+	}{
+		{http.MethodGet, DiscoveryPath, p.discovery},
+		{http.MethodGet, KeysPath, p.keys},
+		{http.MethodGet, AuthorizePath, p.authorize},
+		{http.MethodPost, AuthorizePath, p.authorize},
+		{http.MethodPost, TokenPath, p.token},
+		{http.MethodGet, UserInfoPath, p.userInfo},
+		{http.MethodPost, UserInfoPath, p.userInfo},
+	}
+	for _, route := range routes {
+		handler := route.handler
+		err := mux.HandlePath(route.method, route.path, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			handler(w, r)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + AuthorizePath,
+		"token_endpoint":                        p.issuer + TokenPath,
+		"userinfo_endpoint":                     p.issuer + UserInfoPath,
+		"jwks_uri":                              p.issuer + KeysPath,
+		"response_types_supported":              []string{"code"},
+		"response_modes_supported":              []string{"query"},
+		"grant_types_supported":                 []string{"authorization_code", "refresh_token"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{signingAlg},
+		"scopes_supported":                      supportedScopes,
+		"token_endpoint_auth_methods_supported": []string{"none", "client_secret_basic", "client_secret_post"},
+		"code_challenge_methods_supported":      []string{codeChallengeMethod},
+		"claims_supported": []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "sid",
+			"name", "email", "email_verified", "phone_number", "phone_number_verified",
+		},
+	})
+}
+
+func (p *Provider) keys(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, p.signer.keySet())
+}
+
+// requestContext exposes the client of r the way the gateway does for the grpc handlers,
+// so sessions and logins record the device
+func requestContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	if userAgent := r.UserAgent(); userAgent != "" {
+		md.Set("user-agent", userAgent)
+	}
+	if name := r.Header.Get(middleware.DeviceNameHeader); name != "" {
+		md.Set(middleware.DeviceNameHeader, name)
+	}
+	// appends the caller like the gateway does, see middleware.DeviceFromContext
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			host = forwarded + ", " + host
+		}
+		md.Set("x-forwarded-for", host)
+	}
+	if requestId := r.Header.Get(logger.RequestIDHeader); requestId != "" {
+		md.Set(logger.RequestIDHeader, requestId)
+	}
+	return metadata.NewIncomingContext(r.Context(), md)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
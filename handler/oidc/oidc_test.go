@@ -0,0 +1,546 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/service"
+	"github.com/Mitra-Apps/be-user-service/service/mock"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testIssuer      = "https://auth.mitra.test"
+	testRedirectUri = "https://app.mitra.test/callback"
+)
+
+var testClient = &entity.OAuthClient{
+	Id:           "client",
+	Name:         "Mitra",
+	RedirectUris: []string{testRedirectUri},
+	IsActive:     true,
+}
+
+func newTestMux(t *testing.T) (*runtime.ServeMux, *mock.MockServiceInterface, *mock.MockAuthentication, *rsa.PrivateKey) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockAuth := mock.NewMockAuthentication(ctrl)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := runtime.NewServeMux()
+	if err := New(mockSvc, mockAuth, NewSigner(key), testIssuer+"/").Register(mux); err != nil {
+		t.Fatal(err)
+	}
+	return mux, mockSvc, mockAuth, key
+}
+
+func serve(mux http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func authorizeQuery() url.Values {
+	return url.Values{
+		"client_id":             {testClient.Id},
+		"redirect_uri":          {testRedirectUri},
+		"response_type":         {"code"},
+		"scope":                 {"openid email unknown"},
+		"state":                 {"state"},
+		"nonce":                 {"nonce"},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+func TestProvider_discovery(t *testing.T) {
+	mux, _, _, key := newTestMux(t)
+
+	rec := serve(mux, httptest.NewRequest(http.MethodGet, DiscoveryPath, nil))
+	var discovery map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&discovery); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("discovery code = %d, error = %v", rec.Code, err)
+	}
+	if discovery["issuer"] != testIssuer || discovery["token_endpoint"] != testIssuer+TokenPath || discovery["jwks_uri"] != testIssuer+KeysPath {
+		t.Errorf("discovery = %v", discovery)
+	}
+
+	rec = serve(mux, httptest.NewRequest(http.MethodGet, KeysPath, nil))
+	var keys struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&keys); err != nil || len(keys.Keys) != 1 {
+		t.Fatalf("keys = %v, error = %v", keys, err)
+	}
+	n, _ := base64.RawURLEncoding.DecodeString(keys.Keys[0].N)
+	if new(big.Int).SetBytes(n).Cmp(key.N) != 0 || keys.Keys[0].Kid == "" {
+		t.Errorf("keys = %v, does not publish the signing key", keys)
+	}
+}
+
+func TestProvider_authorize(t *testing.T) {
+	mux, mockSvc, _, _ := newTestMux(t)
+	mockSvcRec := mockSvc.EXPECT()
+	tests := []struct {
+		name         string
+		query        func(url.Values)
+		wantCode     int
+		wantLocation string
+		mocks        []*gomock.Call
+	}{
+		{
+			name:     "unknown client",
+			query:    func(url.Values) {},
+			wantCode: http.StatusBadRequest,
+			mocks: []*gomock.Call{
+				mockSvcRec.GetOAuthClient(gomock.Any(), testClient.Id).Return(nil, status.Error(codes.Unauthenticated, "unknown")),
+			},
+		},
+		{
+			name:     "unregistered redirect uri",
+			query:    func(q url.Values) { q.Set("redirect_uri", "https://evil.test/callback") },
+			wantCode: http.StatusBadRequest,
+			mocks: []*gomock.Call{
+				mockSvcRec.GetOAuthClient(gomock.Any(), testClient.Id).Return(testClient, nil),
+			},
+		},
+		{
+			name:         "without openid scope",
+			query:        func(q url.Values) { q.Set("scope", "email") },
+			wantCode:     http.StatusFound,
+			wantLocation: "error=invalid_scope",
+			mocks: []*gomock.Call{
+				mockSvcRec.GetOAuthClient(gomock.Any(), testClient.Id).Return(testClient, nil),
+			},
+		},
+		{
+			name:         "plain code challenge",
+			query:        func(q url.Values) { q.Set("code_challenge_method", "plain") },
+			wantCode:     http.StatusFound,
+			wantLocation: "error=invalid_request",
+			mocks: []*gomock.Call{
+				mockSvcRec.GetOAuthClient(gomock.Any(), testClient.Id).Return(testClient, nil),
+			},
+		},
+		{
+			name:         "prompt none",
+			query:        func(q url.Values) { q.Set("prompt", "none") },
+			wantCode:     http.StatusFound,
+			wantLocation: "error=login_required",
+			mocks: []*gomock.Call{
+				mockSvcRec.GetOAuthClient(gomock.Any(), testClient.Id).Return(testClient, nil),
+			},
+		},
+		{
+			name:     "renders the sign in form",
+			query:    func(url.Values) {},
+			wantCode: http.StatusOK,
+			mocks: []*gomock.Call{
+				mockSvcRec.GetOAuthClient(gomock.Any(), testClient.Id).Return(testClient, nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := authorizeQuery()
+			tt.query(query)
+			rec := serve(mux, httptest.NewRequest(http.MethodGet, AuthorizePath+"?"+query.Encode(), nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("authorize code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			location := rec.Header().Get("Location")
+			if tt.wantLocation != "" && (!strings.HasPrefix(location, testRedirectUri) || !strings.Contains(location, tt.wantLocation) || !strings.Contains(location, "state=state")) {
+				t.Errorf("authorize location = %s, want %s", location, tt.wantLocation)
+			}
+			if tt.wantCode == http.StatusOK && (len(rec.Result().Cookies()) != 1 || rec.Header().Get("X-Frame-Options") != "DENY") {
+				t.Errorf("authorize form without csrf cookie or frame protection: %v", rec.Header())
+			}
+			if csp := rec.Header().Get("Content-Security-Policy"); tt.wantCode == http.StatusOK && !strings.Contains(csp, "form-action 'self' https://app.mitra.test;") {
+				t.Errorf("authorize form csp = %s, want form-action allowing the redirect uri", csp)
+			}
+		})
+	}
+}
+
+func Test_redirectOrigin(t *testing.T) {
+	tests := []struct {
+		redirectUri string
+		want        string
+	}{
+		{redirectUri: "https://app.mitra.test/callback?from=web", want: "https://app.mitra.test"},
+		{redirectUri: "http://localhost:3000/callback", want: "http://localhost:3000"},
+		{redirectUri: "id.mitra.app:/oauth/callback", want: "id.mitra.app:"},
+		{redirectUri: "/callback", want: ""},
+	}
+	for _, tt := range tests {
+		if got := redirectOrigin(tt.redirectUri); got != tt.want {
+			t.Errorf("redirectOrigin(%q) = %q, want %q", tt.redirectUri, got, tt.want)
+		}
+	}
+}
+
+func TestProvider_authorizeSignIn(t *testing.T) {
+	mux, mockSvc, _, _ := newTestMux(t)
+	mockSvc.EXPECT().GetOAuthClient(gomock.Any(), testClient.Id).Return(testClient, nil).AnyTimes()
+	mockSvcRec := mockSvc.EXPECT()
+	user := &entity.User{Id: uuid.New()}
+	mfaUser := &entity.User{Id: uuid.New(), IsMfaEnabled: true}
+	issued := func(x any) bool {
+		authorization := x.(*entity.AuthorizationCode)
+		return authorization.UserId == user.Id && authorization.Scope == "openid email" && authorization.Nonce == "nonce" &&
+			authorization.CodeChallenge == "challenge" && authorization.RedirectUri == testRedirectUri
+	}
+	tests := []struct {
+		name         string
+		form         url.Values
+		csrfCookie   string
+		wantCode     int
+		wantBody     string
+		wantLocation string
+		mocks        []*gomock.Call
+	}{
+		{
+			name:       "csrf token mismatch",
+			form:       url.Values{"csrf_token": {"csrf"}, "email": {"test@gmail.com"}, "password": {"password"}},
+			csrfCookie: "other",
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "wrong password",
+			form:       url.Values{"csrf_token": {"csrf"}, "email": {"test@gmail.com"}, "password": {"wrong"}},
+			csrfCookie: "csrf",
+			wantCode:   http.StatusOK,
+			wantBody:   "Email atau kata sandi tidak sesuai",
+			mocks: []*gomock.Call{
+				mockSvcRec.Login(gomock.Any(), entity.LoginRequest{Email: "test@gmail.com", Password: "wrong"}).Return(nil, status.Error(codes.InvalidArgument, "wrong")),
+			},
+		},
+		{
+			name:         "signed in",
+			form:         url.Values{"csrf_token": {"csrf"}, "email": {"test@gmail.com"}, "password": {"password"}},
+			csrfCookie:   "csrf",
+			wantCode:     http.StatusFound,
+			wantLocation: testRedirectUri + "?code=code&state=state",
+			mocks: []*gomock.Call{
+				mockSvcRec.Login(gomock.Any(), entity.LoginRequest{Email: "test@gmail.com", Password: "password"}).Return(user, nil),
+				mockSvcRec.CreateAuthorizationCode(gomock.Any(), gomock.Cond(issued)).Return("code", nil),
+			},
+		},
+		{
+			name:       "asks for the second factor",
+			form:       url.Values{"csrf_token": {"csrf"}, "email": {"test@gmail.com"}, "password": {"password"}},
+			csrfCookie: "csrf",
+			wantCode:   http.StatusOK,
+			wantBody:   `name="mfa_token" value="mfaToken"`,
+			mocks: []*gomock.Call{
+				mockSvcRec.Login(gomock.Any(), gomock.Any()).Return(mfaUser, nil),
+				mockSvcRec.CreateMfaChallenge(gomock.Any(), mfaUser.Id).Return("mfaToken", nil),
+			},
+		},
+		{
+			name:       "wrong second factor",
+			form:       url.Values{"csrf_token": {"csrf"}, "mfa_token": {"mfaToken"}, "code": {"000000"}},
+			csrfCookie: "csrf",
+			wantCode:   http.StatusOK,
+			wantBody:   "Kode verifikasi tidak sesuai",
+			mocks: []*gomock.Call{
+				mockSvcRec.VerifyMfa(gomock.Any(), "mfaToken", "000000").Return(nil, status.Error(codes.InvalidArgument, "wrong")),
+			},
+		},
+		{
+			name:         "signed in with the second factor",
+			form:         url.Values{"csrf_token": {"csrf"}, "mfa_token": {"mfaToken"}, "code": {"123456"}},
+			csrfCookie:   "csrf",
+			wantCode:     http.StatusFound,
+			wantLocation: testRedirectUri + "?code=code&state=state",
+			mocks: []*gomock.Call{
+				mockSvcRec.VerifyMfa(gomock.Any(), "mfaToken", "123456").Return(user, nil),
+				mockSvcRec.CreateAuthorizationCode(gomock.Any(), gomock.Cond(issued)).Return("code", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := authorizeQuery()
+			for key, values := range tt.form {
+				form[key] = values
+			}
+			req := httptest.NewRequest(http.MethodPost, AuthorizePath, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.AddCookie(&http.Cookie{Name: csrfCookie, Value: tt.csrfCookie})
+			rec := serve(mux, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("authorize code = %d, want %d, body %s", rec.Code, tt.wantCode, rec.Body)
+			}
+			if tt.wantBody != "" && !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("authorize body = %s, want %s", rec.Body, tt.wantBody)
+			}
+			if location := rec.Header().Get("Location"); location != tt.wantLocation {
+				t.Errorf("authorize location = %s, want %s", location, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestProvider_token(t *testing.T) {
+	mux, mockSvc, mockAuth, key := newTestMux(t)
+	mockSvcRec := mockSvc.EXPECT()
+	mockAuthRec := mockAuth.EXPECT()
+	user := &entity.User{Id: uuid.New(), Name: "Test", Email: "test@gmail.com", IsVerified: true, PhoneNumber: "0812"}
+	session := &entity.Session{Id: uuid.New()}
+	authorization := &entity.AuthorizationCode{
+		ClientId: testClient.Id,
+		UserId:   user.Id,
+		Scope:    "openid email",
+		Nonce:    "nonce",
+		AuthTime: time.Now(),
+	}
+	refreshTokenId := uuid.New()
+	tests := []struct {
+		name        string
+		form        url.Values
+		basicAuth   bool
+		wantCode    int
+		wantError   string
+		wantIdToken bool
+		mocks       []*gomock.Call
+	}{
+		{
+			name:      "wrong client secret",
+			form:      url.Values{"grant_type": {"authorization_code"}},
+			basicAuth: true,
+			wantCode:  http.StatusUnauthorized,
+			wantError: "invalid_client",
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateOAuthClient(gomock.Any(), testClient.Id, "secret").Return(nil, status.Error(codes.Unauthenticated, "invalid")),
+			},
+		},
+		{
+			name:      "unsupported grant",
+			form:      url.Values{"grant_type": {"password"}, "client_id": {testClient.Id}},
+			wantCode:  http.StatusBadRequest,
+			wantError: "unsupported_grant_type",
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateOAuthClient(gomock.Any(), testClient.Id, "").Return(testClient, nil),
+			},
+		},
+		{
+			name:      "invalid code",
+			form:      url.Values{"grant_type": {"authorization_code"}, "client_id": {testClient.Id}, "code": {"code"}, "redirect_uri": {testRedirectUri}, "code_verifier": {"verifier"}},
+			wantCode:  http.StatusBadRequest,
+			wantError: "invalid_grant",
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateOAuthClient(gomock.Any(), testClient.Id, "").Return(testClient, nil),
+				mockSvcRec.ExchangeAuthorizationCode(gomock.Any(), testClient.Id, "code", testRedirectUri, "verifier").Return(nil, status.Error(codes.InvalidArgument, "invalid")),
+			},
+		},
+		{
+			name:      "failed to create the session",
+			form:      url.Values{"grant_type": {"authorization_code"}, "code": {"code"}, "redirect_uri": {testRedirectUri}, "code_verifier": {"verifier"}},
+			basicAuth: true,
+			wantCode:  http.StatusInternalServerError,
+			wantError: "server_error",
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateOAuthClient(gomock.Any(), testClient.Id, "secret").Return(testClient, nil),
+				mockSvcRec.ExchangeAuthorizationCode(gomock.Any(), testClient.Id, "code", testRedirectUri, "verifier").Return(authorization, nil),
+				mockSvcRec.GetUser(gomock.Any(), user.Id).Return(user, nil),
+				mockSvcRec.CreateOAuthSession(gomock.Any(), user.Id, testClient.Id, gomock.Any()).Return(nil, status.Error(codes.Internal, "db")),
+			},
+		},
+		{
+			name:        "exchanges the code",
+			form:        url.Values{"grant_type": {"authorization_code"}, "code": {"code"}, "redirect_uri": {testRedirectUri}, "code_verifier": {"verifier"}},
+			basicAuth:   true,
+			wantCode:    http.StatusOK,
+			wantIdToken: true,
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateOAuthClient(gomock.Any(), testClient.Id, "secret").Return(testClient, nil),
+				mockSvcRec.ExchangeAuthorizationCode(gomock.Any(), testClient.Id, "code", testRedirectUri, "verifier").Return(authorization, nil),
+				mockSvcRec.GetUser(gomock.Any(), user.Id).Return(user, nil),
+				mockSvcRec.CreateOAuthSession(gomock.Any(), user.Id, testClient.Id, gomock.Any()).Return(session, nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, session, service.TokenTypeAccess, 60).Return("accessToken", nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, session, service.TokenTypeRefresh, 43200).Return("refreshToken", nil),
+			},
+		},
+		{
+			name:      "access token as refresh token",
+			form:      url.Values{"grant_type": {"refresh_token"}, "client_id": {testClient.Id}, "refresh_token": {"accessToken"}},
+			wantCode:  http.StatusBadRequest,
+			wantError: "invalid_grant",
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateOAuthClient(gomock.Any(), testClient.Id, "").Return(testClient, nil),
+				mockAuthRec.ValidateToken(gomock.Any(), "accessToken").Return(&service.JwtCustomClaim{TokenType: service.TokenTypeAccess}, nil),
+			},
+		},
+		{
+			name:      "refresh token of another client",
+			form:      url.Values{"grant_type": {"refresh_token"}, "client_id": {testClient.Id}, "refresh_token": {"refreshToken"}},
+			wantCode:  http.StatusBadRequest,
+			wantError: "invalid_grant",
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateOAuthClient(gomock.Any(), testClient.Id, "").Return(testClient, nil),
+				mockAuthRec.ValidateToken(gomock.Any(), "refreshToken").Return(&service.JwtCustomClaim{
+					TokenType:        service.TokenTypeRefresh,
+					SessionId:        session.Id.String(),
+					RegisteredClaims: jwt.RegisteredClaims{ID: refreshTokenId.String()},
+				}, nil),
+				mockSvcRec.RefreshSession(gomock.Any(), session.Id, refreshTokenId, testClient.Id).Return(nil, nil, status.Error(codes.Unauthenticated, "AUTH_SESSION_INVALID")),
+			},
+		},
+		{
+			name:     "refreshes the session",
+			form:     url.Values{"grant_type": {"refresh_token"}, "client_id": {testClient.Id}, "refresh_token": {"refreshToken"}},
+			wantCode: http.StatusOK,
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateOAuthClient(gomock.Any(), testClient.Id, "").Return(testClient, nil),
+				mockAuthRec.ValidateToken(gomock.Any(), "refreshToken").Return(&service.JwtCustomClaim{
+					TokenType:        service.TokenTypeRefresh,
+					SessionId:        session.Id.String(),
+					RegisteredClaims: jwt.RegisteredClaims{ID: refreshTokenId.String()},
+				}, nil),
+				mockSvcRec.RefreshSession(gomock.Any(), session.Id, refreshTokenId, testClient.Id).Return(user, session, nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, session, service.TokenTypeAccess, 60).Return("accessToken", nil),
+				mockAuthRec.GenerateToken(gomock.Any(), user, session, service.TokenTypeRefresh, 43200).Return("refreshToken", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.basicAuth {
+				req.SetBasicAuth(testClient.Id, "secret")
+			}
+			rec := serve(mux, req)
+			var got tokenResponse
+			var gotError errorResponse
+			body := rec.Body.Bytes()
+			json.Unmarshal(body, &got)
+			json.Unmarshal(body, &gotError)
+			if rec.Code != tt.wantCode || gotError.Error != tt.wantError {
+				t.Fatalf("token = %d %s, want %d %s", rec.Code, body, tt.wantCode, tt.wantError)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			if got.AccessToken != "accessToken" || got.RefreshToken != "refreshToken" || got.TokenType != "Bearer" || got.ExpiresIn != 3600 {
+				t.Errorf("token = %s", body)
+			}
+			if (got.IdToken != "") != tt.wantIdToken {
+				t.Fatalf("token id_token = %s, want id token %v", got.IdToken, tt.wantIdToken)
+			}
+			if !tt.wantIdToken {
+				return
+			}
+			claims := jwt.MapClaims{}
+			_, err := jwt.ParseWithClaims(got.IdToken, claims, func(token *jwt.Token) (interface{}, error) {
+				return &key.PublicKey, nil
+			}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithIssuer(testIssuer), jwt.WithAudience(testClient.Id))
+			if err != nil {
+				t.Fatalf("id token does not verify: %v", err)
+			}
+			if claims["sub"] != user.Id.String() || claims["nonce"] != "nonce" || claims["email"] != user.Email || claims["sid"] != session.Id.String() {
+				t.Errorf("id token claims = %v", claims)
+			}
+			if _, ok := claims["phone_number"]; ok {
+				t.Errorf("id token claims = %v, phone scope was not granted", claims)
+			}
+		})
+	}
+}
+
+func TestProvider_userInfo(t *testing.T) {
+	mux, mockSvc, _, _ := newTestMux(t)
+	mockSvcRec := mockSvc.EXPECT()
+	user := &entity.User{Id: uuid.New(), Name: "Test", Email: "test@gmail.com", IsVerified: true}
+	principal := &entity.TokenIntrospection{Active: true, UserId: user.Id, SessionId: uuid.New()}
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      int
+		mocks         []*gomock.Call
+	}{
+		{
+			name:     "without token",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer invalid",
+			wantCode:      http.StatusUnauthorized,
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateAccessToken(gomock.Any(), "invalid").Return(nil, status.Error(codes.Unauthenticated, "invalid")),
+			},
+		},
+		{
+			name:          "revoked session",
+			authorization: "Bearer accessToken",
+			wantCode:      http.StatusUnauthorized,
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateAccessToken(gomock.Any(), "accessToken").Return(nil, status.Error(codes.Unauthenticated, "AUTH_SESSION_INVALID")),
+			},
+		},
+		{
+			name:          "impersonation token",
+			authorization: "Bearer accessToken",
+			wantCode:      http.StatusUnauthorized,
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateAccessToken(gomock.Any(), "accessToken").Return(&entity.TokenIntrospection{Active: true, UserId: user.Id, ActorId: uuid.New()}, nil),
+			},
+		},
+		{
+			name:          "error checking the token",
+			authorization: "Bearer accessToken",
+			wantCode:      http.StatusInternalServerError,
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateAccessToken(gomock.Any(), "accessToken").Return(nil, status.Error(codes.Internal, "db")),
+			},
+		},
+		{
+			name:          "returns the claims of the user",
+			authorization: "Bearer accessToken",
+			wantCode:      http.StatusOK,
+			mocks: []*gomock.Call{
+				mockSvcRec.AuthenticateAccessToken(gomock.Any(), "accessToken").Return(principal, nil),
+				mockSvcRec.GetUser(gomock.Any(), user.Id).Return(user, nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, UserInfoPath, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := serve(mux, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("userinfo code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var got map[string]interface{}
+			json.NewDecoder(rec.Body).Decode(&got)
+			if got["sub"] != user.Id.String() || got["email"] != user.Email || got["name"] != user.Name || got["email_verified"] != true {
+				t.Errorf("userinfo = %v", got)
+			}
+		})
+	}
+}
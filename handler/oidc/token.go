@@ -0,0 +1,224 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/handler/middleware"
+	"github.com/Mitra-Apps/be-user-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	IdToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{"invalid_request", "the body must be form encoded"})
+		return
+	}
+	ctx := requestContext(r)
+
+	// clients authenticate with http basic or with the client_id and client_secret parameters
+	clientId, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientId, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	client, err := p.service.AuthenticateOAuthClient(ctx, clientId, clientSecret)
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			w.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+			writeJSON(w, http.StatusUnauthorized, errorResponse{"invalid_client", "client authentication failed"})
+			return
+		}
+		p.serverError(ctx, w, err)
+		return
+	}
+
+	var response *tokenResponse
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		response, err = p.exchangeCode(ctx, client, r)
+	case "refresh_token":
+		response, err = p.refresh(ctx, client, r)
+	default:
+		writeJSON(w, http.StatusBadRequest, errorResponse{"unsupported_grant_type", "only authorization_code and refresh_token are supported"})
+		return
+	}
+	if err != nil {
+		p.grantError(ctx, w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// exchangeCode redeems an authorization code for a new session of its user
+func (p *Provider) exchangeCode(ctx context.Context, client *entity.OAuthClient, r *http.Request) (*tokenResponse, error) {
+	authorization, err := p.service.ExchangeAuthorizationCode(ctx, client.Id, r.PostForm.Get("code"), r.PostForm.Get("redirect_uri"), r.PostForm.Get("code_verifier"))
+	if err != nil {
+		return nil, err
+	}
+	user, err := p.service.GetUser(ctx, authorization.UserId)
+	if err != nil {
+		return nil, err
+	}
+	session, err := p.service.CreateOAuthSession(ctx, user.Id, client.Id, middleware.DeviceFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	response, err := p.sessionTokens(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+	response.Scope = authorization.Scope
+
+	now := time.Now()
+	claims := userClaims(user, authorization.HasScope)
+	claims["iss"] = p.issuer
+	claims["aud"] = client.Id
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(accessTokenMinutes * time.Minute).Unix()
+	claims["auth_time"] = authorization.AuthTime.Unix()
+	claims["sid"] = session.Id.String()
+	if authorization.Nonce != "" {
+		claims["nonce"] = authorization.Nonce
+	}
+	if response.IdToken, err = p.signer.sign(claims); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return response, nil
+}
+
+// refresh rotates the refresh token of a session the way the RefreshToken rpc does, for the
+// client the session was opened for only
+func (p *Provider) refresh(ctx context.Context, client *entity.OAuthClient, r *http.Request) (*tokenResponse, error) {
+	claims, err := p.auth.ValidateToken(ctx, r.PostForm.Get("refresh_token"))
+	if err != nil || claims.TokenType != service.TokenTypeRefresh {
+		return nil, status.Error(codes.InvalidArgument, "refresh token required")
+	}
+	sessionId, err := uuid.Parse(claims.SessionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "refresh token without session")
+	}
+	refreshTokenId, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "refresh token without id")
+	}
+	user, session, err := p.service.RefreshSession(ctx, sessionId, refreshTokenId, client.Id)
+	if err != nil {
+		return nil, err
+	}
+	return p.sessionTokens(ctx, user, session)
+}
+
+func (p *Provider) sessionTokens(ctx context.Context, user *entity.User, session *entity.Session) (*tokenResponse, error) {
+	accessToken, err := p.auth.GenerateToken(ctx, user, session, service.TokenTypeAccess, accessTokenMinutes)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	refreshToken, err := p.auth.GenerateToken(ctx, user, session, service.TokenTypeRefresh, refreshTokenMinutes)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &tokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    accessTokenMinutes * 60,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (p *Provider) userInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := requestContext(r)
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="oauth2"`)
+		writeJSON(w, http.StatusUnauthorized, errorResponse{"invalid_request", "a bearer access token is required"})
+		return
+	}
+	// checked like on the rpcs, tokens of revoked sessions are refused right away
+	principal, err := p.service.AuthenticateAccessToken(ctx, strings.TrimSpace(token))
+	if err != nil && status.Code(err) == codes.Internal {
+		p.serverError(ctx, w, err)
+		return
+	}
+	// impersonations are limited to the first party apps
+	if err != nil || principal.ActorId != uuid.Nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="oauth2", error="invalid_token"`)
+		writeJSON(w, http.StatusUnauthorized, errorResponse{"invalid_token", "the access token is invalid"})
+		return
+	}
+	user, err := p.service.GetUser(ctx, principal.UserId)
+	if err != nil {
+		if status.Code(err) == codes.Internal {
+			p.serverError(ctx, w, err)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Bearer realm="oauth2", error="invalid_token"`)
+		writeJSON(w, http.StatusUnauthorized, errorResponse{"invalid_token", "the user of the access token cannot sign in"})
+		return
+	}
+	// access tokens do not carry scopes, the first party apps get every claim
+	writeJSON(w, http.StatusOK, userClaims(user, func(string) bool { return true }))
+}
+
+// userClaims returns the standard claims of user for the scopes granted
+func userClaims(user *entity.User, hasScope func(scope string) bool) map[string]interface{} {
+	claims := map[string]interface{}{
+		"sub": user.Id.String(),
+	}
+	if hasScope(entity.ScopeProfile) {
+		claims["name"] = user.Name
+	}
+	if hasScope(entity.ScopeEmail) {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.IsVerified
+	}
+	if hasScope(entity.ScopePhone) && user.PhoneNumber != "" {
+		claims["phone_number"] = user.PhoneNumber
+		claims["phone_number_verified"] = user.IsPhoneVerified
+	}
+	return claims
+}
+
+// grantError answers a failed grant, the service refuses invalid grants with client errors
+func (p *Provider) grantError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.Internal, codes.Unavailable, codes.Unknown:
+		p.serverError(ctx, w, err)
+	default:
+		writeJSON(w, http.StatusBadRequest, errorResponse{"invalid_grant", status.Convert(err).Message()})
+	}
+}
+
+func (p *Provider) serverError(ctx context.Context, w http.ResponseWriter, err error) {
+	logger.FromContext(ctx).WithError(err).Error("oidc request failed")
+	writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "server_error"})
+}
+
+func randomString() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
@@ -2,118 +2,293 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
-	"log"
 	"net"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/metrics"
 	"github.com/Mitra-Apps/be-user-service/config/postgre"
 	"github.com/Mitra-Apps/be-user-service/config/tools"
 	"github.com/Mitra-Apps/be-user-service/config/tools/redis"
+	"github.com/Mitra-Apps/be-user-service/config/tracing"
 	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	userPostgreRepo "github.com/Mitra-Apps/be-user-service/domain/user/repository/postgre"
 	grpcRoute "github.com/Mitra-Apps/be-user-service/handler/grpc"
 	"github.com/Mitra-Apps/be-user-service/handler/middleware"
+	"github.com/Mitra-Apps/be-user-service/handler/oidc"
 	"github.com/Mitra-Apps/be-user-service/service"
+	"github.com/Mitra-Apps/be-user-service/service/identity"
+	"github.com/Mitra-Apps/be-user-service/service/notifier"
+	"github.com/Mitra-Apps/be-user-service/service/publisher"
 	util "github.com/Mitra-Apps/be-utility-service/config/tools"
 	utilPb "github.com/Mitra-Apps/be-utility-service/domain/proto/utility"
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"go.elastic.co/apm/module/apmgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
-// Middleware interceptor
-func middlewareInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+// Middleware interceptor authenticating the protected methods
+func middlewareInterceptor(svc service.ServiceInterface, auth service.Authentication) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, svc, auth, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		// Call the actual handler to process the request
+		return handler(ctx, req)
+	}
+}
+
+// streamMiddlewareInterceptor authenticates the protected streaming methods
+func streamMiddlewareInterceptor(svc service.ServiceInterface, auth service.Authentication) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), svc, auth, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
+
+// authenticate validates the access token of the protected methods and adds its user to ctx.
+// The user of the token must still be active, admin methods also require the admin role.
+// Services call the methods declaring a scope with an api key granted that scope instead.
+func authenticate(ctx context.Context, svc service.ServiceInterface, auth service.Authentication, fullMethod string) (context.Context, error) {
 	// Check if the method should be included from the middleware
-	log.Print(info.FullMethod)
 	addMiddleware := true
+	adminOnly := false
+	serviceOnly := false
+	serviceScope := ""
 	// Add the method that will be included for middleware
-	switch info.FullMethod {
+	switch fullMethod {
 	case "/proto.UserService/GetUsers":
-		// Middleware logic for specific route
+		serviceScope = entity.ScopeUsersRead
+	case "/proto.UserService/GetUsersByIds", "/proto.UserService/GetUserByEmail":
+		// internal lookups of other services
+		serviceOnly = true
+		serviceScope = entity.ScopeUsersRead
+	case "/proto.UserService/IntrospectToken":
+		// the services authenticating their own callers
+		serviceOnly = true
+		serviceScope = entity.ScopeTokensIntrospect
 	case "/proto.UserService/GetOwnData":
 		// Middleware logic for specific route
+	case "/proto.UserService/SendPhoneVerificationOtp":
+		// Middleware logic for specific route
+	case "/proto.UserService/VerifyPhone":
+		// Middleware logic for specific route
+	case "/proto.UserService/RequestEmailChange":
+		// Middleware logic for specific route
+	case "/proto.UserService/ConfirmEmailChange":
+		// Middleware logic for specific route
+	case "/proto.UserService/EnrollMfa":
+		// Middleware logic for specific route
+	case "/proto.UserService/ConfirmMfa":
+		// Middleware logic for specific route
+	case "/proto.UserService/ListSessions":
+		// Middleware logic for specific route
+	case "/proto.UserService/RevokeSession":
+		// Middleware logic for specific route
+	case "/proto.UserService/DeleteAccount":
+		// Middleware logic for specific route
+	case "/proto.UserService/ExportMyData":
+		// Middleware logic for specific route
+	case "/proto.UserService/GetMyLoginHistory":
+		// Middleware logic for specific route
+	case "/proto.UserService/StopImpersonation":
+		// Middleware logic for specific route
+	case "/proto.UserService/DeactivateUser", "/proto.UserService/ReactivateUser", "/proto.UserService/SetUserRoles", "/proto.UserService/ExportUserData",
+		"/proto.UserService/CreateRole", "/proto.UserService/CreateOAuthClient", "/proto.UserService/CreateServiceClient",
+		"/proto.UserService/ListServiceClients", "/proto.UserService/RevokeServiceClient", "/proto.UserService/ImpersonateUser":
+		adminOnly = true
+	case "/proto.UserService/ListAuditEvents":
+		adminOnly = true
+		serviceScope = entity.ScopeAuditRead
 	default:
 		addMiddleware = false
 	}
-	if addMiddleware {
-		// Validate and parse the JWT token
-		token, err := middleware.GetToken(ctx)
-		if err != nil {
-			return nil, err
+	if !addMiddleware {
+		// public rpcs ignore tokens, yet the password is not changed on behalf of an admin either
+		if slices.Contains(impersonationBlocked, fullMethod) && isImpersonation(ctx, auth) {
+			return nil, errImpersonationBlocked
 		}
+		return ctx, nil
+	}
 
-		auth := service.NewAuthClient(os.Getenv("JWT_SECRET"))
-		claims, err := auth.ValidateToken(ctx, token)
-		if err != nil {
-			return nil, err
-		}
+	// Validate and parse the JWT token
+	token, err := middleware.GetToken(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if strings.HasPrefix(token, entity.APIKeyPrefix) {
+		return authenticateService(ctx, svc, token, serviceScope)
+	}
+	if serviceOnly {
+		return nil, status.Error(codes.PermissionDenied, "api key required")
+	}
 
-		//claim our user id input in subject from token
-		id, err := claims.GetSubject()
-		if err != nil {
-			return nil, err
-		}
-		var userId uuid.UUID
-		userId, err = uuid.Parse(id)
-		if err != nil {
+	// signature, expiry, user and session are checked as by IntrospectToken
+	principal, err := svc.AuthenticateAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if adminOnly && !slices.Contains(principal.Roles, entity.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "admin role required")
+	}
+	if principal.ActorId != uuid.Nil {
+		if ctx, err = authenticateImpersonation(ctx, principal, fullMethod); err != nil {
 			return nil, err
 		}
+	}
 
-		ctx = middleware.SetUserIDKey(ctx, userId)
-		// Call the actual handler to process the request
-		return handler(ctx, req)
+	ctx = middleware.SetPrincipalTypeKey(ctx, middleware.PrincipalUser)
+	ctx = middleware.SetUserIDKey(ctx, principal.UserId)
+	if principal.SessionId != uuid.Nil {
+		ctx = middleware.SetSessionIDKey(ctx, principal.SessionId)
+	}
+	return logger.WithUserID(ctx, principal.UserId), nil
+}
+
+// impersonationBlocked are the rpcs changing the credentials or the account of a user,
+// an admin impersonating the user cannot call them
+var impersonationBlocked = []string{
+	"/proto.UserService/ChangePassword",
+	"/proto.UserService/EnrollMfa",
+	"/proto.UserService/ConfirmMfa",
+	"/proto.UserService/RequestEmailChange",
+	"/proto.UserService/ConfirmEmailChange",
+	"/proto.UserService/RevokeSession",
+	"/proto.UserService/DeleteAccount",
+	"/proto.UserService/ExportMyData",
+}
+
+var errImpersonationBlocked = status.Error(codes.PermissionDenied, "not allowed while impersonating a user")
+
+// authenticateImpersonation adds the admin acting for the user of principal to ctx
+func authenticateImpersonation(ctx context.Context, principal *entity.TokenIntrospection, fullMethod string) (context.Context, error) {
+	if slices.Contains(impersonationBlocked, fullMethod) {
+		return nil, errImpersonationBlocked
+	}
+	ctx = middleware.SetActorIDKey(ctx, principal.ActorId)
+	return logger.ToContext(ctx, logger.FromContext(ctx).WithField("actor_id", principal.ActorId.String())), nil
+}
+
+// isImpersonation reports whether the request carries a valid impersonation token
+func isImpersonation(ctx context.Context, auth service.Authentication) bool {
+	token, err := middleware.GetToken(ctx)
+	if err != nil {
+		return false
+	}
+	claims, err := auth.ValidateToken(ctx, token)
+	return err == nil && claims.Act != nil
+}
+
+// authenticateService checks apiKey is granted scope, methods without scope are for users only
+func authenticateService(ctx context.Context, svc service.ServiceInterface, apiKey string, scope string) (context.Context, error) {
+	client, err := svc.AuthenticateServiceClient(ctx, apiKey)
+	if err != nil {
+		return nil, err
 	}
-	// Call the actual handler to process the request
-	return handler(ctx, req)
+	if scope == "" {
+		return nil, status.Error(codes.PermissionDenied, "user token required")
+	}
+	if !client.HasScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s scope required", scope)
+	}
+	ctx = middleware.SetPrincipalTypeKey(ctx, middleware.PrincipalService)
+	ctx = middleware.SetServiceClientIDKey(ctx, client.Id)
+	return logger.WithServiceClientID(ctx, client.Id), nil
 }
 
 func main() {
 	ctx := context.Background()
 
 	godotenv.Load("config.env")
+	logger.Init()
+	// proxies in front of the gateway, their addresses are skipped when reading the client ip
+	if proxies, err := strconv.Atoi(os.Getenv("TRUSTED_PROXIES")); err == nil && proxies >= 0 {
+		middleware.TrustedProxies = proxies
+	}
+
+	shutdownTracing, err := tracing.Init(ctx)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("failed to init tracing")
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.FromContext(ctx).WithError(err).Error("failed to shutdown tracing")
+		}
+	}()
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", os.Getenv("GRPC_PORT")))
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		logger.FromContext(ctx).WithError(err).Fatal("failed to listen")
 	}
 
-	utilityGrpcConn, err := grpc.DialContext(ctx, os.Getenv("GRPC_UTILITY_HOST"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	utilityGrpcConn, err := grpc.DialContext(ctx, os.Getenv("GRPC_UTILITY_HOST"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 	if err != nil {
-		log.Fatal("Cannot connect to utility grpc server ", err)
+		logger.FromContext(ctx).WithError(err).Fatal("Cannot connect to utility grpc server")
 	}
 	defer func() {
-		log.Println("Closing connection ...")
+		logger.FromContext(ctx).Info("Closing connection ...")
 		utilityGrpcConn.Close()
 	}()
 	mailSvcClient := utilPb.NewMailServiceClient(utilityGrpcConn)
 
 	db := postgre.Connection()
-	user := &entity.User{}
-	err = db.Where("name = '1'").First(user).Error
-	fmt.Println("not found", err)
-
-	fmt.Println(user)
 	redis := redis.Connection()
+
+	sqlDb, err := db.DB()
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("Cannot get database connection pool")
+	}
+	metrics.RegisterDBStats(sqlDb, os.Getenv("DB_NAME"))
+	metrics.RegisterRedisStats(redis.PoolStats)
+
 	usrRepo := userPostgreRepo.NewUserRepoImpl(db)
 	roleRepo := userPostgreRepo.NewRoleRepoImpl(db)
-	bcrypt := tools.New(&tools.Bcrypt{})
-	auth := service.NewAuthClient(os.Getenv("JWT_SECRET"))
-	svc := service.New(usrRepo, roleRepo, bcrypt, redis, auth)
-	grpcServer := GrpcNewServer(ctx, []grpc.ServerOption{})
-	route := grpcRoute.New(svc, auth, mailSvcClient)
+	mfaRepo := userPostgreRepo.NewMfaRepoImpl(db)
+	sessionRepo := userPostgreRepo.NewSessionRepoImpl(db)
+	auditRepo := userPostgreRepo.NewAuditRepoImpl(db)
+	loginHistoryRepo := userPostgreRepo.NewLoginHistoryRepoImpl(db)
+	externalIdentityRepo := userPostgreRepo.NewExternalIdentityRepoImpl(db)
+	oauthClientRepo := userPostgreRepo.NewOAuthClientRepoImpl(db)
+	serviceClientRepo := userPostgreRepo.NewServiceClientRepoImpl(db)
+	outboxRepo := userPostgreRepo.NewOutboxRepoImpl(db)
+	transactor := userPostgreRepo.NewTransactor(db)
+	auth := service.NewAuthClient(os.Getenv("JWT_SECRET"), authConfig())
+	notifiers := otpNotifiers(mailSvcClient)
+	svc := service.New(usrRepo, roleRepo, mfaRepo, sessionRepo, auditRepo, loginHistoryRepo, externalIdentityRepo, oauthClientRepo, serviceClientRepo, outboxRepo, transactor, passwordHasher(ctx), mfaCipher(ctx), redis, auth, notifiers, socialLogin(ctx), passwordPolicy(ctx))
+	grpcServer := GrpcNewServer(ctx, svc, auth, []grpc.ServerOption{})
+	route := grpcRoute.New(svc, auth)
 	pb.RegisterUserServiceServer(grpcServer, route)
 
 	go func() {
@@ -121,31 +296,44 @@ func main() {
 		grpcServer.GracefulStop()
 	}()
 
-	go HttpNewServer(ctx, os.Getenv("GRPC_PORT"), os.Getenv("HTTP_PORT"))
+	events := publisher.NewRedisStream(redis, eventStream(), publisher.DefaultStreamMaxLen)
+	dispatcher := service.NewOutboxDispatcher(outboxRepo, notifiers, events, outboxConfig())
+	go dispatcher.Run(ctx)
+
+	deletionWorker := service.NewAccountDeletionWorker(usrRepo, mfaRepo, sessionRepo, loginHistoryRepo, externalIdentityRepo, outboxRepo, auditRepo, transactor, redis, accountDeletionConfig())
+	go deletionWorker.Run(ctx)
+
+	go HttpNewServer(ctx, os.Getenv("GRPC_PORT"), os.Getenv("HTTP_PORT"), oidcProvider(ctx, svc, auth))
 
 	grpcServer.Serve(lis)
 }
 
-func GrpcNewServer(ctx context.Context, opts []grpc.ServerOption) *grpc.Server {
+func GrpcNewServer(ctx context.Context, svc service.ServiceInterface, auth service.Authentication, opts []grpc.ServerOption) *grpc.Server {
 	logrusEntry := logrus.NewEntry(logrus.StandardLogger())
 	logrusOpts := []grpc_logrus.Option{
 		grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel),
 	}
 	grpc_logrus.ReplaceGrpcLogger(logrusEntry)
 
-	opts = append(opts, grpc.StreamInterceptor(
+	opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler()), grpc.StreamInterceptor(
 		grpc_middleware.ChainStreamServer(
 			grpc_ctxtags.StreamServerInterceptor(),
+			metrics.StreamServerInterceptor(),
 			grpc_logrus.StreamServerInterceptor(logrusEntry, logrusOpts...),
+			logger.StreamServerInterceptor(),
 			grpc_recovery.StreamServerInterceptor(),
 			apmgrpc.NewStreamServerInterceptor(apmgrpc.WithRecovery()),
+			streamMiddlewareInterceptor(svc, auth),
 		)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			grpc_ctxtags.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
 			grpc_logrus.UnaryServerInterceptor(logrusEntry, logrusOpts...),
+			logger.UnaryServerInterceptor(),
+			middleware.DeadlineUnaryServerInterceptor(rpcTimeouts()),
 			grpc_recovery.UnaryServerInterceptor(),
 			apmgrpc.NewUnaryServerInterceptor(apmgrpc.WithRecovery()),
-			middlewareInterceptor,
+			middlewareInterceptor(svc, auth),
 		)),
 	)
 
@@ -155,8 +343,238 @@ func GrpcNewServer(ctx context.Context, opts []grpc.ServerOption) *grpc.Server {
 	return myServer
 }
 
-func HttpNewServer(ctx context.Context, grpcPort, httpPort string) error {
-	mux := runtime.NewServeMux(runtime.WithErrorHandler(util.CustomErrorHandler))
+// rpcTimeouts reads the default rpc deadline from RPC_DEFAULT_TIMEOUT,
+// registration hashes the password and writes in a transaction so it gets a longer one
+func rpcTimeouts() middleware.Timeouts {
+	defaultTimeout, err := time.ParseDuration(os.Getenv("RPC_DEFAULT_TIMEOUT"))
+	if err != nil {
+		defaultTimeout = 10 * time.Second
+	}
+	return middleware.Timeouts{
+		Default: defaultTimeout,
+		PerMethod: map[string]time.Duration{
+			"/proto.UserService/Register": 2 * defaultTimeout,
+		},
+	}
+}
+
+// otpNotifiers always sends otp by email, sms and whatsapp are enabled
+// when their gateway is configured. Email notices need the mail gateway.
+func otpNotifiers(mail utilPb.MailServiceClient) service.Notifiers {
+	client := &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+		Timeout:   10 * time.Second,
+	}
+	var mailGateway *notifier.HTTP
+	if url := os.Getenv("MAIL_GATEWAY_URL"); url != "" {
+		mailGateway = notifier.NewMail(client, url, os.Getenv("MAIL_GATEWAY_TOKEN"))
+	}
+	notifiers := service.Notifiers{
+		entity.OtpChannelEmail: notifier.NewEmail(mail, mailGateway),
+	}
+	if url := os.Getenv("SMS_GATEWAY_URL"); url != "" {
+		notifiers[entity.OtpChannelSms] = notifier.NewSms(client, url, os.Getenv("SMS_GATEWAY_TOKEN"))
+	}
+	if url := os.Getenv("WHATSAPP_GATEWAY_URL"); url != "" {
+		notifiers[entity.OtpChannelWhatsapp] = notifier.NewWhatsapp(client, url, os.Getenv("WHATSAPP_GATEWAY_TOKEN"))
+	}
+	return notifiers
+}
+
+// mfaCipher encrypts totp secrets with MFA_ENCRYPTION_KEY, a base64 encoded 32 bytes key.
+// 2FA is unavailable when the key is not set.
+func mfaCipher(ctx context.Context) tools.CipherInterface {
+	key := os.Getenv("MFA_ENCRYPTION_KEY")
+	if key == "" {
+		logger.FromContext(ctx).Warn("MFA_ENCRYPTION_KEY is not set, 2FA is disabled")
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("invalid MFA_ENCRYPTION_KEY")
+	}
+	cipher, err := tools.NewAesGcm(decoded)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("invalid MFA_ENCRYPTION_KEY")
+	}
+	return cipher
+}
+
+// socialLogin enables google login when GOOGLE_CLIENT_IDS lists the comma separated oauth client ids
+// of our apps. Keys are read from GOOGLE_JWKS_URL, google's by default, and the users created get
+// the role named SOCIAL_LOGIN_DEFAULT_ROLE, customer by default.
+func socialLogin(ctx context.Context) service.SocialLogin {
+	config := service.SocialLogin{DefaultRole: "customer"}
+	if role := os.Getenv("SOCIAL_LOGIN_DEFAULT_ROLE"); role != "" {
+		config.DefaultRole = role
+	}
+	var clientIds []string
+	for _, clientId := range strings.Split(os.Getenv("GOOGLE_CLIENT_IDS"), ",") {
+		if clientId = strings.TrimSpace(clientId); clientId != "" {
+			clientIds = append(clientIds, clientId)
+		}
+	}
+	if len(clientIds) == 0 {
+		logger.FromContext(ctx).Warn("GOOGLE_CLIENT_IDS is not set, google login is disabled")
+		return config
+	}
+	keysUrl := identity.GoogleKeysURL
+	if url := os.Getenv("GOOGLE_JWKS_URL"); url != "" {
+		keysUrl = url
+	}
+	client := &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+		Timeout:   10 * time.Second,
+	}
+	config.Google = identity.NewGoogle(identity.NewKeySet(client, keysUrl), clientIds...)
+	return config
+}
+
+// passwordHasher hashes new passwords with PASSWORD_HASH_ALGORITHM, argon2id by default or bcrypt. The argon2id
+// costs are overridden with ARGON2_MEMORY (KiB), ARGON2_ITERATIONS and ARGON2_PARALLELISM, the bcrypt one with
+// BCRYPT_COST. Hashes of the other algorithm or of lower costs are upgraded on the next login.
+func passwordHasher(ctx context.Context) tools.PasswordHasher {
+	config := tools.DefaultHasherConfig()
+	if algorithm := os.Getenv("PASSWORD_HASH_ALGORITHM"); algorithm != "" {
+		config.Algorithm = algorithm
+	}
+	if memory, err := strconv.ParseUint(os.Getenv("ARGON2_MEMORY"), 10, 32); err == nil {
+		config.Argon2id.Memory = uint32(memory)
+	}
+	if iterations, err := strconv.ParseUint(os.Getenv("ARGON2_ITERATIONS"), 10, 32); err == nil {
+		config.Argon2id.Iterations = uint32(iterations)
+	}
+	if parallelism, err := strconv.ParseUint(os.Getenv("ARGON2_PARALLELISM"), 10, 8); err == nil {
+		config.Argon2id.Parallelism = uint8(parallelism)
+	}
+	if cost, err := strconv.Atoi(os.Getenv("BCRYPT_COST")); err == nil {
+		config.BcryptCost = cost
+	}
+	hasher, err := tools.NewHasher(config)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("invalid password hashing configuration")
+	}
+	return hasher
+}
+
+// passwordPolicy overrides the default policy with PASSWORD_MIN_LENGTH, PASSWORD_MAX_LENGTH (at most 128)
+// and PASSWORD_MIN_CHAR_CLASSES. PASSWORD_BANNED_LIST_FILE adds a password per line to the common ones
+// refused, PASSWORD_BREACHED_HASHES_FILE lists the sha1 hashes of breached passwords, one per line.
+func passwordPolicy(ctx context.Context) service.PasswordPolicy {
+	policy := service.DefaultPasswordPolicy()
+	if length, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_LENGTH")); err == nil && length > 0 {
+		policy.MinLength = length
+	}
+	if length, err := strconv.Atoi(os.Getenv("PASSWORD_MAX_LENGTH")); err == nil && length > 0 {
+		policy.MaxLength = min(length, service.MaxPasswordLength)
+	}
+	// bcrypt cannot hash longer passwords, refuse them before they are hashed
+	if os.Getenv("PASSWORD_HASH_ALGORITHM") == tools.AlgorithmBcrypt {
+		policy.MaxBytes = tools.BcryptMaxPasswordBytes
+	}
+	if classes, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_CHAR_CLASSES")); err == nil && classes >= 0 {
+		policy.MinCharClasses = classes
+	}
+	if file := os.Getenv("PASSWORD_BANNED_LIST_FILE"); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			logger.FromContext(ctx).WithError(err).Fatal("failed to read PASSWORD_BANNED_LIST_FILE")
+		}
+		for _, password := range strings.Split(string(data), "\n") {
+			if password = strings.TrimSpace(password); password != "" {
+				policy.Banned = append(policy.Banned, password)
+			}
+		}
+	}
+	file := os.Getenv("PASSWORD_BREACHED_HASHES_FILE")
+	if file == "" {
+		logger.FromContext(ctx).Warn("PASSWORD_BREACHED_HASHES_FILE is not set, breached passwords are not checked")
+		return policy
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("failed to open PASSWORD_BREACHED_HASHES_FILE")
+	}
+	defer f.Close()
+	breached, err := tools.LoadHashPrefixList(f)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("invalid PASSWORD_BREACHED_HASHES_FILE")
+	}
+	policy.Breached = breached
+	return policy
+}
+
+// oidcProvider serves the openid connect provider when OIDC_ISSUER, the public url of the gateway,
+// and OIDC_SIGNING_KEY_FILE, the pem encoded rsa key id tokens are signed with, are set
+func oidcProvider(ctx context.Context, svc service.ServiceInterface, auth service.Authentication) *oidc.Provider {
+	issuer, keyFile := os.Getenv("OIDC_ISSUER"), os.Getenv("OIDC_SIGNING_KEY_FILE")
+	if issuer == "" || keyFile == "" {
+		logger.FromContext(ctx).Warn("OIDC_ISSUER or OIDC_SIGNING_KEY_FILE is not set, the openid connect provider is disabled")
+		return nil
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("failed to read OIDC_SIGNING_KEY_FILE")
+	}
+	key, err := oidc.ParseSigningKey(data)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Fatal("invalid OIDC_SIGNING_KEY_FILE")
+	}
+	return oidc.New(svc, auth, oidc.NewSigner(key), issuer)
+}
+
+// outboxConfig overrides the dispatcher defaults with OUTBOX_POLL_INTERVAL and OUTBOX_MAX_ATTEMPTS
+func outboxConfig() service.OutboxConfig {
+	config := service.DefaultOutboxConfig()
+	if interval, err := time.ParseDuration(os.Getenv("OUTBOX_POLL_INTERVAL")); err == nil {
+		config.PollInterval = interval
+	}
+	if attempts, err := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS")); err == nil && attempts > 0 {
+		config.MaxAttempts = attempts
+	}
+	return config
+}
+
+// authConfig reads the claims of the tokens from JWT_ISSUER and JWT_AUDIENCE and
+// the clock skew tolerated when validating them from JWT_LEEWAY
+func authConfig() service.AuthConfig {
+	config := service.DefaultAuthConfig()
+	config.Issuer = os.Getenv("JWT_ISSUER")
+	config.Audience = os.Getenv("JWT_AUDIENCE")
+	if leeway, err := time.ParseDuration(os.Getenv("JWT_LEEWAY")); err == nil && leeway >= 0 {
+		config.Leeway = leeway
+	}
+	return config
+}
+
+// eventStream is the redis stream domain events are published to, EVENT_STREAM or mitra.user.events
+func eventStream() string {
+	if stream := os.Getenv("EVENT_STREAM"); stream != "" {
+		return stream
+	}
+	return "mitra.user.events"
+}
+
+// accountDeletionConfig overrides the grace period before deleted accounts are anonymized with ACCOUNT_DELETION_GRACE_PERIOD
+func accountDeletionConfig() service.AccountDeletionConfig {
+	config := service.DefaultAccountDeletionConfig()
+	if gracePeriod, err := time.ParseDuration(os.Getenv("ACCOUNT_DELETION_GRACE_PERIOD")); err == nil && gracePeriod >= 0 {
+		config.GracePeriod = gracePeriod
+	}
+	return config
+}
+
+func HttpNewServer(ctx context.Context, grpcPort, httpPort string, provider *oidc.Provider) error {
+	mux := runtime.NewServeMux(
+		runtime.WithErrorHandler(util.CustomErrorHandler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
+
+	if provider != nil {
+		if err := provider.Register(mux); err != nil {
+			return err
+		}
+	}
 
 	mux.HandlePath("GET", "/docs/v1/users/openapi.yaml", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
 		http.ServeFile(w, r, "docs/openapi.yaml")
@@ -166,14 +584,21 @@ func HttpNewServer(ctx context.Context, grpcPort, httpPort string) error {
 		http.ServeFile(w, r, "docs/index.html")
 	})
 
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	mux.HandlePath("GET", "/metrics", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		promhttp.Handler().ServeHTTP(w, r)
+	})
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
 	if err := pb.RegisterUserServiceHandlerFromEndpoint(ctx, mux, fmt.Sprintf("localhost:%s", grpcPort), opts); err != nil {
 		return err
 	}
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", httpPort),
-		Handler: mux,
+		Handler: otelhttp.NewHandler(mux, "gateway"),
 	}
 
 	go func() {
@@ -185,3 +610,14 @@ func HttpNewServer(ctx context.Context, grpcPort, httpPort string) error {
 
 	return srv.ListenAndServe()
 }
+
+// headerMatcher forwards the request id and device name headers to the grpc server on top of the default headers
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, logger.RequestIDHeader) {
+		return logger.RequestIDHeader, true
+	}
+	if strings.EqualFold(key, middleware.DeviceNameHeader) {
+		return middleware.DeviceNameHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/handler/middleware"
+	"github.com/Mitra-Apps/be-user-service/service"
+	"github.com/Mitra-Apps/be-user-service/service/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock.NewMockServiceInterface(ctrl)
+	mockAuth := mock.NewMockAuthentication(ctrl)
+	userId := uuid.New()
+	userToken := "eyJhbGciOiJIUzI1NiJ9.user.token"
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	reader := &entity.ServiceClient{Id: uuid.New(), Scopes: []string{entity.ScopeUsersRead}}
+	introspector := &entity.ServiceClient{Id: uuid.New(), Scopes: []string{entity.ScopeTokensIntrospect}}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		method        string
+		wantCode      codes.Code
+		wantPrincipal middleware.PrincipalType
+		mocks         func()
+	}{
+		{
+			name:     "public rpc",
+			ctx:      context.Background(),
+			method:   "/proto.UserService/Login",
+			wantCode: codes.OK,
+			mocks:    func() {},
+		},
+		{
+			name:     "password change while impersonating",
+			ctx:      withToken(userToken),
+			method:   "/proto.UserService/ChangePassword",
+			wantCode: codes.PermissionDenied,
+			mocks: func() {
+				mockAuth.EXPECT().ValidateToken(gomock.Any(), userToken).Return(&service.JwtCustomClaim{Act: &service.ActorClaim{Subject: uuid.NewString()}}, nil)
+			},
+		},
+		{
+			name:     "password change with a user token",
+			ctx:      withToken(userToken),
+			method:   "/proto.UserService/ChangePassword",
+			wantCode: codes.OK,
+			mocks: func() {
+				mockAuth.EXPECT().ValidateToken(gomock.Any(), userToken).Return(&service.JwtCustomClaim{}, nil)
+			},
+		},
+		{
+			name:     "unauthenticated users lookup",
+			ctx:      context.Background(),
+			method:   "/proto.UserService/GetUsersByIds",
+			wantCode: codes.Unauthenticated,
+			mocks:    func() {},
+		},
+		{
+			name:     "unauthenticated email lookup",
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "grpcurl")),
+			method:   "/proto.UserService/GetUserByEmail",
+			wantCode: codes.Unauthenticated,
+			mocks:    func() {},
+		},
+		{
+			name:     "email lookup with a user token",
+			ctx:      withToken(userToken),
+			method:   "/proto.UserService/GetUserByEmail",
+			wantCode: codes.PermissionDenied,
+			mocks:    func() {},
+		},
+		{
+			name:     "users lookup with an api key without scope",
+			ctx:      withToken("mk_0123456789abcdef_secret"),
+			method:   "/proto.UserService/GetUsersByIds",
+			wantCode: codes.PermissionDenied,
+			mocks: func() {
+				mockSvc.EXPECT().AuthenticateServiceClient(gomock.Any(), "mk_0123456789abcdef_secret").Return(&entity.ServiceClient{Id: uuid.New()}, nil)
+			},
+		},
+		{
+			name:          "users lookup with an api key",
+			ctx:           withToken("mk_0123456789abcdef_secret"),
+			method:        "/proto.UserService/GetUsersByIds",
+			wantCode:      codes.OK,
+			wantPrincipal: middleware.PrincipalService,
+			mocks: func() {
+				mockSvc.EXPECT().AuthenticateServiceClient(gomock.Any(), "mk_0123456789abcdef_secret").Return(reader, nil)
+			},
+		},
+		{
+			name:     "unauthenticated introspection",
+			ctx:      context.Background(),
+			method:   "/proto.UserService/IntrospectToken",
+			wantCode: codes.Unauthenticated,
+			mocks:    func() {},
+		},
+		{
+			name:     "introspection with a user token",
+			ctx:      withToken(userToken),
+			method:   "/proto.UserService/IntrospectToken",
+			wantCode: codes.PermissionDenied,
+			mocks:    func() {},
+		},
+		{
+			name:     "introspection with an api key of another scope",
+			ctx:      withToken("mk_0123456789abcdef_secret"),
+			method:   "/proto.UserService/IntrospectToken",
+			wantCode: codes.PermissionDenied,
+			mocks: func() {
+				mockSvc.EXPECT().AuthenticateServiceClient(gomock.Any(), "mk_0123456789abcdef_secret").Return(reader, nil)
+			},
+		},
+		{
+			name:          "introspection with an api key",
+			ctx:           withToken("mk_0123456789abcdef_secret"),
+			method:        "/proto.UserService/IntrospectToken",
+			wantCode:      codes.OK,
+			wantPrincipal: middleware.PrincipalService,
+			mocks: func() {
+				mockSvc.EXPECT().AuthenticateServiceClient(gomock.Any(), "mk_0123456789abcdef_secret").Return(introspector, nil)
+			},
+		},
+		{
+			name:     "user rpc with a revoked session",
+			ctx:      withToken(userToken),
+			method:   "/proto.UserService/GetOwnData",
+			wantCode: codes.Unauthenticated,
+			mocks: func() {
+				mockSvc.EXPECT().AuthenticateAccessToken(gomock.Any(), userToken).Return(nil, status.Error(codes.Unauthenticated, "AUTH_SESSION_INVALID"))
+			},
+		},
+		{
+			name:     "admin rpc with a user token",
+			ctx:      withToken(userToken),
+			method:   "/proto.UserService/DeactivateUser",
+			wantCode: codes.PermissionDenied,
+			mocks: func() {
+				mockSvc.EXPECT().AuthenticateAccessToken(gomock.Any(), userToken).Return(&entity.TokenIntrospection{Active: true, UserId: userId}, nil)
+			},
+		},
+		{
+			name:          "user rpc",
+			ctx:           withToken(userToken),
+			method:        "/proto.UserService/GetOwnData",
+			wantCode:      codes.OK,
+			wantPrincipal: middleware.PrincipalUser,
+			mocks: func() {
+				mockSvc.EXPECT().AuthenticateAccessToken(gomock.Any(), userToken).Return(&entity.TokenIntrospection{Active: true, UserId: userId}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			ctx, err := authenticate(tt.ctx, mockSvc, mockAuth, tt.method)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("authenticate() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err == nil && middleware.GetPrincipalTypeValue(ctx) != tt.wantPrincipal {
+				t.Errorf("authenticate() principal = %v, want %v", middleware.GetPrincipalTypeValue(ctx), tt.wantPrincipal)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/tools/redis"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// DeactivateUser blocks userId from signing in and signs out all of its sessions
+func (s *Service) DeactivateUser(ctx context.Context, adminId uuid.UUID, userId uuid.UUID) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.SetActive(ctx, userId, false, adminId); err != nil {
+			return err
+		}
+		if err := s.sessionRepo.RevokeAllByUserID(ctx, userId); err != nil {
+			return err
+		}
+		return s.publishEvent(ctx, userId, &events.UserDeactivated{
+			UserId:        userId.String(),
+			Reason:        entity.DeactivationReasonAdmin,
+			DeactivatedBy: adminId.String(),
+		})
+	})
+	if err != nil {
+		return setActiveError(err)
+	}
+	dropCachedUsers(ctx, s.redis, userId)
+	s.audit(ctx, entity.AuditUserDeactivated, adminId, userId, nil)
+	logger.FromContext(ctx).WithField("target_user_id", userId.String()).Info("user deactivated")
+	return nil
+}
+
+// ReactivateUser lets userId sign in again, cancelling its deletion if one was requested
+func (s *Service) ReactivateUser(ctx context.Context, adminId uuid.UUID, userId uuid.UUID) error {
+	if err := s.userRepository.SetActive(ctx, userId, true, adminId); err != nil {
+		return setActiveError(err)
+	}
+	dropCachedUsers(ctx, s.redis, userId)
+	s.audit(ctx, entity.AuditUserReactivated, adminId, userId, nil)
+	logger.FromContext(ctx).WithField("target_user_id", userId.String()).Info("user reactivated")
+	return nil
+}
+
+// SetUserRoles replaces all the roles of userId with roleIds
+func (s *Service) SetUserRoles(ctx context.Context, adminId uuid.UUID, userId uuid.UUID, roleIds []string) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.ReplaceRoles(ctx, userId, roleIds, adminId); err != nil {
+			return err
+		}
+		return s.publishEvent(ctx, userId, &events.UserRolesChanged{
+			UserId:    userId.String(),
+			RoleIds:   roleIds,
+			ChangedBy: adminId.String(),
+		})
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			return util.NewError(codes.NotFound, pbErr.ErrorCode_RECORD_NOT_FOUND.String(), "Role tidak ditemukan")
+		}
+		return setActiveError(err)
+	}
+	s.audit(ctx, entity.AuditRolesChanged, adminId, userId, map[string]string{"roles": strings.Join(roleIds, ",")})
+	return nil
+}
+
+// DeleteAccount deactivates the account of userId once the password is confirmed. The personal
+// data is anonymized by the AccountDeletionWorker after the grace period, until then an admin
+// can cancel the deletion with ReactivateUser.
+func (s *Service) DeleteAccount(ctx context.Context, userId uuid.UUID, password string) error {
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if err := s.hashing.Compare(user.Password, []byte(password)); err != nil {
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_LOGIN_PASSWORD_INCORRECT.String()
+		ErrorMessage = "Data yang dimasukkan tidak sesuai"
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.RequestDeletion(ctx, user.Id, time.Now()); err != nil {
+			return err
+		}
+		if err := s.sessionRepo.RevokeAllByUserID(ctx, user.Id); err != nil {
+			return err
+		}
+		return s.publishEvent(ctx, user.Id, &events.UserDeactivated{
+			UserId:        user.Id.String(),
+			Reason:        entity.DeactivationReasonDeletionRequested,
+			DeactivatedBy: user.Id.String(),
+		})
+	})
+	if err != nil {
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	dropCachedUsers(ctx, s.redis, user.Id)
+	s.audit(ctx, entity.AuditDeletionRequested, user.Id, user.Id, nil)
+	return nil
+}
+
+// CheckUserActive fails for users that are deactivated, deleted or do not exist anymore
+func (s *Service) CheckUserActive(ctx context.Context, userId uuid.UUID) error {
+	user, err := s.userRepository.GetByID(ctx, userId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return userInactiveError()
+		}
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return checkUserActive(user)
+}
+
+// checkUserActive is run by every flow signing a user in
+func checkUserActive(user *entity.User) error {
+	if !user.IsActive {
+		return userInactiveError()
+	}
+	return nil
+}
+
+func userInactiveError() error {
+	ErrorCode = codes.PermissionDenied
+	ErrorCodeDetail = pbErr.ErrorCode_AUTH_USER_INACTIVE.String()
+	ErrorMessage = "Akun tidak aktif, silahkan hubungi customer service"
+	return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+}
+
+func setActiveError(err error) error {
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return util.NewError(codes.NotFound, pbErr.ErrorCode_RECORD_NOT_FOUND.String(), "User tidak ditemukan")
+	}
+	return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+}
+
+type AccountDeletionConfig struct {
+	// GracePeriod between a deletion request and the anonymization of the account
+	GracePeriod  time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+func DefaultAccountDeletionConfig() AccountDeletionConfig {
+	return AccountDeletionConfig{
+		GracePeriod:  30 * 24 * time.Hour,
+		PollInterval: time.Hour,
+		BatchSize:    50,
+	}
+}
+
+// AccountDeletionWorker anonymizes the accounts whose deletion was requested more than the
+// grace period ago. The user row is kept with its personal data scrubbed, the 2FA secrets as
+// well as the sessions and login history holding device details, the linked external
+// identities and the outbox messages carrying contact details are removed. Audit events
+// are kept without their metadata and device details.
+type AccountDeletionWorker struct {
+	userRepository       repository.User
+	mfaRepo              repository.Mfa
+	sessionRepo          repository.Session
+	loginHistoryRepo     repository.LoginHistory
+	externalIdentityRepo repository.ExternalIdentity
+	outbox               repository.Outbox
+	auditRepo            repository.Audit
+	transactor           repository.Transactor
+	redis                redis.RedisInterface
+	config               AccountDeletionConfig
+}
+
+func NewAccountDeletionWorker(
+	userRepository repository.User,
+	mfaRepo repository.Mfa,
+	sessionRepo repository.Session,
+	loginHistoryRepo repository.LoginHistory,
+	externalIdentityRepo repository.ExternalIdentity,
+	outbox repository.Outbox,
+	auditRepo repository.Audit,
+	transactor repository.Transactor,
+	redis redis.RedisInterface,
+	config AccountDeletionConfig) *AccountDeletionWorker {
+	return &AccountDeletionWorker{
+		userRepository:       userRepository,
+		mfaRepo:              mfaRepo,
+		sessionRepo:          sessionRepo,
+		loginHistoryRepo:     loginHistoryRepo,
+		externalIdentityRepo: externalIdentityRepo,
+		outbox:               outbox,
+		auditRepo:            auditRepo,
+		transactor:           transactor,
+		redis:                redis,
+		config:               config,
+	}
+}
+
+// Run anonymizes due accounts until ctx is done
+func (w *AccountDeletionWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.AnonymizeDue(ctx); err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).WithError(err).Error("failed to anonymize deleted accounts")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// AnonymizeDue anonymizes one batch of due accounts and returns how many were anonymized
+func (w *AccountDeletionWorker) AnonymizeDue(ctx context.Context) (int, error) {
+	users, err := w.userRepository.ListDeletionDue(ctx, time.Now().Add(-w.config.GracePeriod), w.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	anonymized := 0
+	for _, user := range users {
+		err := w.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := w.userRepository.Anonymize(ctx, user.Id); err != nil {
+				return err
+			}
+			if err := w.mfaRepo.DeleteByUserID(ctx, user.Id); err != nil {
+				return err
+			}
+			if err := w.sessionRepo.DeleteByUserID(ctx, user.Id); err != nil {
+				return err
+			}
+			if err := w.loginHistoryRepo.DeleteByUserID(ctx, user.Id); err != nil {
+				return err
+			}
+			if err := w.externalIdentityRepo.DeleteByUserID(ctx, user.Id); err != nil {
+				return err
+			}
+			if err := w.outbox.DeleteByUser(ctx, user.Id, user.Email, user.PhoneNumber); err != nil {
+				return err
+			}
+			return w.auditRepo.ScrubByUserID(ctx, user.Id)
+		})
+		if errors.Is(err, repository.ErrUserNotFound) {
+			// anonymized by another instance meanwhile
+			continue
+		}
+		if err != nil {
+			logger.FromContext(ctx).WithError(err).WithField("user_id", user.Id.String()).Error("failed to anonymize account")
+			continue
+		}
+		dropCachedUsers(ctx, w.redis, user.Id)
+		anonymized++
+	}
+	return anonymized, nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mockTools "github.com/Mitra-Apps/be-user-service/config/tools/mock"
+	mockRedis "github.com/Mitra-Apps/be-user-service/config/tools/redis/mock"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_Login_inactive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockHash := mockTools.NewMockPasswordHasher(ctrl)
+	s := &Service{auditRepo: newMockAudit(ctrl), loginHistoryRepo: newMockLoginHistory(ctrl), userRepository: mockUser, hashing: mockHash}
+	user := &entity.User{Id: uuid.New(), Email: "test@mail.com", IsVerified: true, IsActive: false}
+
+	mockUser.EXPECT().GetByEmail(gomock.Any(), user.Email).Return(user, nil)
+	mockHash.EXPECT().Compare(gomock.Any(), gomock.Any()).Return(nil)
+	if _, err := s.Login(context.Background(), entity.LoginRequest{Email: user.Email, Password: "123456"}); err == nil {
+		t.Errorf("Service.Login() error = nil, want inactive user error")
+	}
+}
+
+func TestService_DeactivateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		sessionRepo:    mockSession,
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+		redis:          redis,
+	}
+	adminId := uuid.New()
+	userId := uuid.New()
+	inTransaction := func() {
+		mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	tests := []struct {
+		name    string
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error user not found",
+			wantErr: true,
+			mocks: func() {
+				inTransaction()
+				mockUser.EXPECT().SetActive(gomock.Any(), userId, false, adminId).Return(repository.ErrUserNotFound)
+			},
+		},
+		{
+			name:    "error revoke sessions",
+			wantErr: true,
+			mocks: func() {
+				inTransaction()
+				mockUser.EXPECT().SetActive(gomock.Any(), userId, false, adminId).Return(nil)
+				mockSession.EXPECT().RevokeAllByUserID(gomock.Any(), userId).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:    "error queue event",
+			wantErr: true,
+			mocks: func() {
+				inTransaction()
+				mockUser.EXPECT().SetActive(gomock.Any(), userId, false, adminId).Return(nil)
+				mockSession.EXPECT().RevokeAllByUserID(gomock.Any(), userId).Return(nil)
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			wantErr: false,
+			mocks: func() {
+				inTransaction()
+				mockUser.EXPECT().SetActive(gomock.Any(), userId, false, adminId).Return(nil)
+				mockSession.EXPECT().RevokeAllByUserID(gomock.Any(), userId).Return(nil)
+				expectEvent(t, mockOutbox, userId, &events.UserDeactivated{
+					UserId:        userId.String(),
+					Reason:        entity.DeactivationReasonAdmin,
+					DeactivatedBy: adminId.String(),
+				})
+				redis.EXPECT().Del(gomock.Any(), "user-summary:"+userId.String()).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			if err := s.DeactivateUser(context.Background(), adminId, userId); (err != nil) != tt.wantErr {
+				t.Errorf("Service.DeactivateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_SetUserRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+	}
+	adminId := uuid.New()
+	userId := uuid.New()
+	roleIds := []string{"1", "2"}
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr bool
+	}{
+		{
+			name:    "error user not found",
+			repoErr: repository.ErrUserNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "error role not found",
+			repoErr: repository.ErrRoleNotFound,
+			wantErr: true,
+		},
+		{
+			name: "success",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+			mockUser.EXPECT().ReplaceRoles(gomock.Any(), userId, roleIds, adminId).Return(tt.repoErr)
+			if tt.repoErr == nil {
+				expectEvent(t, mockOutbox, userId, &events.UserRolesChanged{
+					UserId:    userId.String(),
+					RoleIds:   roleIds,
+					ChangedBy: adminId.String(),
+				})
+			}
+			if err := s.SetUserRoles(context.Background(), adminId, userId, roleIds); (err != nil) != tt.wantErr {
+				t.Errorf("Service.SetUserRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_DeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockHash := mockTools.NewMockPasswordHasher(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		sessionRepo:    mockSession,
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+		hashing:        mockHash,
+		redis:          redis,
+	}
+	userId := uuid.New()
+	user := &entity.User{Id: userId, Password: "hashed", IsActive: true}
+	tests := []struct {
+		name    string
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error wrong password",
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(user, nil)
+				mockHash.EXPECT().Compare("hashed", []byte("secret")).Return(errors.New("mismatch"))
+			},
+		},
+		{
+			name:    "success",
+			wantErr: false,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(user, nil)
+				mockHash.EXPECT().Compare("hashed", []byte("secret")).Return(nil)
+				mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				mockUser.EXPECT().RequestDeletion(gomock.Any(), userId, gomock.Any()).Return(nil)
+				mockSession.EXPECT().RevokeAllByUserID(gomock.Any(), userId).Return(nil)
+				expectEvent(t, mockOutbox, userId, &events.UserDeactivated{
+					UserId:        userId.String(),
+					Reason:        entity.DeactivationReasonDeletionRequested,
+					DeactivatedBy: userId.String(),
+				})
+				redis.EXPECT().Del(gomock.Any(), "user-summary:"+userId.String()).Return(errors.New("any error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			if err := s.DeleteAccount(context.Background(), userId, "secret"); (err != nil) != tt.wantErr {
+				t.Errorf("Service.DeleteAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CheckUserActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	s := &Service{userRepository: mockUser}
+	userId := uuid.New()
+	tests := []struct {
+		name    string
+		user    *entity.User
+		repoErr error
+		wantErr bool
+	}{
+		{
+			name:    "user not found",
+			repoErr: repository.ErrUserNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "user deactivated",
+			user:    &entity.User{Id: userId},
+			wantErr: true,
+		},
+		{
+			name:    "user active",
+			user:    &entity.User{Id: userId, IsActive: true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(tt.user, tt.repoErr)
+			if err := s.CheckUserActive(context.Background(), userId); (err != nil) != tt.wantErr {
+				t.Errorf("Service.CheckUserActive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccountDeletionWorker_AnonymizeDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockMfa := mock.NewMockMfa(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	mockLoginHistory := mock.NewMockLoginHistory(ctrl)
+	mockExternalIdentity := mock.NewMockExternalIdentity(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockAudit := mock.NewMockAudit(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	config := DefaultAccountDeletionConfig()
+	w := NewAccountDeletionWorker(mockUser, mockMfa, mockSession, mockLoginHistory, mockExternalIdentity, mockOutbox, mockAudit, mockTransactor, redis, config)
+	due := &entity.User{Id: uuid.New(), Email: "due@mail.com", PhoneNumber: "08123456789"}
+	cancelled := &entity.User{Id: uuid.New()}
+	failing := &entity.User{Id: uuid.New()}
+
+	mockUser.EXPECT().ListDeletionDue(gomock.Any(), gomock.Any(), config.BatchSize).DoAndReturn(
+		func(ctx context.Context, requestedBefore time.Time, limit int) ([]*entity.User, error) {
+			if time.Since(requestedBefore) < config.GracePeriod {
+				t.Errorf("AccountDeletionWorker.AnonymizeDue() listed deletions requested before %v, want grace period %v", requestedBefore, config.GracePeriod)
+			}
+			return []*entity.User{due, cancelled, failing}, nil
+		})
+	mockUser.EXPECT().Anonymize(gomock.Any(), due.Id).Return(nil)
+	mockMfa.EXPECT().DeleteByUserID(gomock.Any(), due.Id).Return(nil)
+	mockSession.EXPECT().DeleteByUserID(gomock.Any(), due.Id).Return(nil)
+	mockLoginHistory.EXPECT().DeleteByUserID(gomock.Any(), due.Id).Return(nil)
+	mockExternalIdentity.EXPECT().DeleteByUserID(gomock.Any(), due.Id).Return(nil)
+	mockOutbox.EXPECT().DeleteByUser(gomock.Any(), due.Id, "due@mail.com", "08123456789").Return(nil)
+	mockAudit.EXPECT().ScrubByUserID(gomock.Any(), due.Id).Return(nil)
+	redis.EXPECT().Del(gomock.Any(), "user-summary:"+due.Id.String()).Return(nil)
+	mockUser.EXPECT().Anonymize(gomock.Any(), cancelled.Id).Return(repository.ErrUserNotFound)
+	mockUser.EXPECT().Anonymize(gomock.Any(), failing.Id).Return(nil)
+	mockMfa.EXPECT().DeleteByUserID(gomock.Any(), failing.Id).Return(errors.New("any error"))
+
+	got, err := w.AnonymizeDue(context.Background())
+	if err != nil {
+		t.Fatalf("AccountDeletionWorker.AnonymizeDue() error = %v", err)
+	}
+	if got != 1 {
+		t.Errorf("AccountDeletionWorker.AnonymizeDue() = %v, want 1", got)
+	}
+}
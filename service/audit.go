@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/handler/middleware"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+)
+
+// audit records action on targetId performed by actorId, either may be uuid.Nil when unknown.
+// The client ip and user agent are taken from the request metadata. Events are written once
+// the action succeeded, outside of its transaction, and a failing write is only logged so the
+// audit trail never blocks users.
+func (s *Service) audit(ctx context.Context, action string, actorId uuid.UUID, targetId uuid.UUID, metadata map[string]string) {
+	device := middleware.DeviceFromContext(ctx)
+	event := &entity.AuditEvent{
+		Action:       action,
+		ActorId:      uuid.NullUUID{UUID: actorId, Valid: actorId != uuid.Nil},
+		TargetUserId: uuid.NullUUID{UUID: targetId, Valid: targetId != uuid.Nil},
+		IpAddress:    truncate(device.IpAddress, 64),
+		UserAgent:    truncate(device.UserAgent, 512),
+	}
+	// actions taken while impersonating are traced back to the admin
+	if impersonatorId := middleware.GetActorIDValue(ctx); impersonatorId != uuid.Nil {
+		metadata = maps.Clone(metadata)
+		if metadata == nil {
+			metadata = map[string]string{}
+		}
+		metadata["impersonator_id"] = impersonatorId.String()
+	}
+	if len(metadata) > 0 {
+		// a map of strings always encodes
+		event.Metadata, _ = json.Marshal(metadata)
+	}
+	if err := s.auditRepo.Create(ctx, event); err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("action", action).Error("failed to record audit event")
+	}
+}
+
+// ListAuditEvents returns the page of audit events matching filter, newest first, and the count of all matching events
+func (s *Service) ListAuditEvents(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEvent, int64, error) {
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultAuditPageSize
+	}
+	filter.PageSize = min(filter.PageSize, maxAuditPageSize)
+	filter.Page = max(filter.Page, 1)
+	events, total, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return events, total, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mockTools "github.com/Mitra-Apps/be-user-service/config/tools/mock"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestService_audit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAudit := mock.NewMockAudit(ctrl)
+	s := &Service{auditRepo: mockAudit}
+	adminId := uuid.New()
+	userId := uuid.New()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-forwarded-for", "198.51.100.1, 203.0.113.7",
+		"grpcgateway-user-agent", "okhttp/4.12",
+	))
+
+	mockAudit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *entity.AuditEvent) error {
+		if event.Action != entity.AuditUserDeactivated || event.ActorId.UUID != adminId || event.TargetUserId.UUID != userId ||
+			event.IpAddress != "203.0.113.7" || event.UserAgent != "okhttp/4.12" || event.MetadataMap()["reason"] != "fraud" {
+			t.Errorf("Service.audit() recorded %+v", event)
+		}
+		return nil
+	})
+	s.audit(ctx, entity.AuditUserDeactivated, adminId, userId, map[string]string{"reason": "fraud"})
+
+	mockAudit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *entity.AuditEvent) error {
+		if event.ActorId.Valid || event.TargetUserId.Valid || event.Metadata != nil {
+			t.Errorf("Service.audit() recorded %+v, want no actor, target nor metadata", event)
+		}
+		// a failing write does not fail the audited action
+		return errors.New("any error")
+	})
+	s.audit(context.Background(), entity.AuditLoginFailed, uuid.Nil, uuid.Nil, nil)
+}
+
+func TestService_Login_audit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockAudit := mock.NewMockAudit(ctrl)
+	mockHash := mockTools.NewMockPasswordHasher(ctrl)
+	s := &Service{userRepository: mockUser, auditRepo: mockAudit, loginHistoryRepo: newMockLoginHistory(ctrl), hashing: mockHash}
+	user := &entity.User{Id: uuid.New(), Email: "test@mail.com", IsActive: true, IsVerified: true, WrongPasswordCounter: 2}
+
+	mockUser.EXPECT().GetByEmail(gomock.Any(), user.Email).Return(user, nil)
+	mockHash.EXPECT().Compare(gomock.Any(), gomock.Any()).Return(errors.New("mismatch"))
+	mockUser.EXPECT().Save(gomock.Any(), user).Return(nil)
+	var actions []string
+	mockAudit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *entity.AuditEvent) error {
+		if event.TargetUserId.UUID != user.Id || event.ActorId.Valid {
+			t.Errorf("Service.Login() recorded %+v, want anonymous event on %v", event, user.Id)
+		}
+		actions = append(actions, event.Action)
+		return nil
+	}).Times(2)
+
+	if _, err := s.Login(context.Background(), entity.LoginRequest{Email: user.Email, Password: "wrong"}); err == nil {
+		t.Fatalf("Service.Login() error = nil, want wrong password error")
+	}
+	if len(actions) != 2 || actions[0] != entity.AuditLoginFailed || actions[1] != entity.AuditUserLockedOut {
+		t.Errorf("Service.Login() recorded %v, want login failure then lockout", actions)
+	}
+}
+
+func TestService_ListAuditEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAudit := mock.NewMockAudit(ctrl)
+	s := &Service{auditRepo: mockAudit}
+	targetId := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	tests := []struct {
+		name    string
+		filter  entity.AuditFilter
+		want    entity.AuditFilter
+		repoErr error
+		wantErr bool
+	}{
+		{
+			name:   "default page",
+			filter: entity.AuditFilter{TargetUserId: targetId},
+			want:   entity.AuditFilter{TargetUserId: targetId, Page: 1, PageSize: defaultAuditPageSize},
+		},
+		{
+			name:   "page size capped",
+			filter: entity.AuditFilter{Action: entity.AuditLoginFailed, Page: 3, PageSize: 10000},
+			want:   entity.AuditFilter{Action: entity.AuditLoginFailed, Page: 3, PageSize: maxAuditPageSize},
+		},
+		{
+			name:    "error list",
+			filter:  entity.AuditFilter{Page: 1, PageSize: 10},
+			want:    entity.AuditFilter{Page: 1, PageSize: 10},
+			repoErr: errors.New("any error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAudit.EXPECT().List(gomock.Any(), tt.want).Return([]*entity.AuditEvent{}, int64(0), tt.repoErr)
+			if _, _, err := s.ListAuditEvents(context.Background(), tt.filter); (err != nil) != tt.wantErr {
+				t.Errorf("Service.ListAuditEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -20,31 +20,65 @@ var (
 	errUserIDRequired = errors.New("user id is required")
 )
 
+// Token types, a refresh token is only accepted by RefreshToken
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 type JwtCustomClaim struct {
 	Roles []string `json:"roles"`
+	// SessionId is the id of the session the token was issued for
+	SessionId string `json:"sid,omitempty"`
+	TokenType string `json:"typ,omitempty"`
+	// Act identifies the admin impersonating the subject (RFC 8693), nil for the tokens of the user
+	Act *ActorClaim `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
+type ActorClaim struct {
+	Subject string `json:"sub"`
+}
+
 type authClient struct {
 	secret string
+	config AuthConfig
+}
+
+// AuthConfig sets the issuer and audience claims of the tokens issued, tokens
+// validated must carry them. Empty claims are neither set nor checked.
+type AuthConfig struct {
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew between services when checking the token times
+	Leeway time.Duration
+}
+
+func DefaultAuthConfig() AuthConfig {
+	return AuthConfig{
+		Leeway: 30 * time.Second,
+	}
 }
 
 //go:generate mockgen -source=auth.go -destination=mock/auth.go -package=mock
 type Authentication interface {
-	GenerateToken(ctx context.Context, user *entity.User, expiredMinute int) (token string, err error)
+	GenerateToken(ctx context.Context, user *entity.User, session *entity.Session, tokenType string, expiredMinute int) (token string, err error)
 	ValidateToken(ctx context.Context, requestToken string) (*JwtCustomClaim, error)
 }
 
 // Authentication client constructor
-func NewAuthClient(secret string) *authClient {
+func NewAuthClient(secret string, config AuthConfig) *authClient {
 	return &authClient{
 		secret: secret,
+		config: config,
 	}
 }
 
 // CreateAccessToken will create access token that will be used for user authentication.
-// access token will be needed in API that needs user to be authorized
-func (c *authClient) GenerateToken(ctx context.Context, user *entity.User, expiredMinute int) (token string, err error) {
+// access token will be needed in API that needs user to be authorized.
+// A refresh token carries the current refresh token id of session as jti, the tokens
+// of an impersonation session carry the admin as act.
+func (c *authClient) GenerateToken(ctx context.Context, user *entity.User, session *entity.Session, tokenType string, expiredMinute int) (token string, err error) {
 	if user.Id == uuid.Nil {
 		return "", errUserIDRequired
 	}
@@ -58,15 +92,29 @@ func (c *authClient) GenerateToken(ctx context.Context, user *entity.User, expir
 	//set token with criteria below and input userID into subject
 	//this will be needed to check which user is this token for
 	registeredClaims := jwt.RegisteredClaims{
+		Issuer:    c.config.Issuer,
 		Subject:   user.Id.String(),
 		ExpiresAt: jwt.NewNumericDate(currentTime.Add(time.Minute * time.Duration(expiredMinute))),
 		IssuedAt:  jwt.NewNumericDate(currentTime),
 	}
+	if c.config.Audience != "" {
+		registeredClaims.Audience = jwt.ClaimStrings{c.config.Audience}
+	}
 
 	claims := &JwtCustomClaim{
 		Roles:            roles,
+		TokenType:        tokenType,
 		RegisteredClaims: registeredClaims,
 	}
+	if session != nil {
+		claims.SessionId = session.Id.String()
+		if tokenType == TokenTypeRefresh {
+			claims.ID = session.RefreshTokenId.String()
+		}
+		if session.ImpersonatorId.Valid {
+			claims.Act = &ActorClaim{Subject: session.ImpersonatorId.UUID.String()}
+		}
+	}
 
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
@@ -77,59 +125,37 @@ func (c *authClient) GenerateToken(ctx context.Context, user *entity.User, expir
 	return token, err
 }
 
-// ValidateTokens will validate whether the token is valid
-// and will return user id if the user is exist in our database
-// otherwise it will return error
+// ValidateToken parses requestToken into its claims. The token must be signed with HS256 by
+// the secret, carry a subject, an expiry not passed and an issue time not ahead, give or take
+// the leeway, and the configured issuer and audience.
 func (c *authClient) ValidateToken(ctx context.Context, requestToken string) (*JwtCustomClaim, error) {
-	token, err := jwt.Parse(requestToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(c.secret), nil
-	})
-
-	if err != nil {
-		return nil, util.NewError(codes.Unauthenticated, codes.Unauthenticated.String(), err.Error())
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(c.config.Leeway),
 	}
-	// assert jwt.MapClaims type
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		fmt.Println("error 1")
-		return nil, util.NewError(codes.Unauthenticated, codes.Unauthenticated.String(), errInvalidToken.Error())
+	if c.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(c.config.Issuer))
 	}
-
-	currentTime := time.Now().UTC()
-	expTime, err := claims.GetExpirationTime()
-	if err != nil {
-		return nil, util.NewError(codes.Unauthenticated, codes.Unauthenticated.String(), errClaimingToken.Error())
+	if c.config.Audience != "" {
+		options = append(options, jwt.WithAudience(c.config.Audience))
 	}
 
-	if expTime.Before(currentTime) {
+	claims := &JwtCustomClaim{}
+	_, err := jwt.ParseWithClaims(requestToken, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(c.secret), nil
+	}, options...)
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
 		return nil, util.NewError(codes.Unauthenticated, codes.Unauthenticated.String(), errTokenExpired.Error())
+	case errors.Is(err, jwt.ErrTokenMalformed), errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return nil, util.NewError(codes.Unauthenticated, codes.Unauthenticated.String(), errInvalidToken.Error())
+	case err != nil:
+		return nil, util.NewError(codes.Unauthenticated, codes.Unauthenticated.String(), fmt.Sprintf("%s: %v", errClaimingToken, err))
 	}
-
-	sub, err := claims.GetSubject()
-	if err != nil {
-		return nil, util.NewError(codes.Unauthenticated, codes.Unauthenticated.String(), errClaimingToken.Error())
-	}
-	iat, err := claims.GetIssuedAt()
-	if err != nil {
+	if claims.Subject == "" {
 		return nil, util.NewError(codes.Unauthenticated, codes.Unauthenticated.String(), errClaimingToken.Error())
 	}
-	var roles []string
-	claimRoles := claims["roles"].([]interface{})
-	for _, v := range claimRoles {
-		roles = append(roles, v.(string))
-	}
-
-	res := &JwtCustomClaim{
-		Roles: roles,
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   sub,
-			ExpiresAt: expTime,
-			IssuedAt:  iat,
-		},
-	}
-
-	return res, nil
+	return claims, nil
 }
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	"github.com/golang-jwt/jwt/v5"
@@ -13,17 +16,28 @@ import (
 func TestNewAuthClient(t *testing.T) {
 	type args struct {
 		secret string
+		config AuthConfig
 	}
 	tests := []struct {
 		name string
 		args args
-		want Authentication
+		want *authClient
 	}{
-		// TODO: Add test cases.
+		{
+			name: "success",
+			args: args{
+				secret: "secret",
+				config: AuthConfig{Issuer: "mitra", Audience: "mitra-apps", Leeway: time.Second},
+			},
+			want: &authClient{
+				secret: "secret",
+				config: AuthConfig{Issuer: "mitra", Audience: "mitra-apps", Leeway: time.Second},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewAuthClient(tt.args.secret); !reflect.DeepEqual(got, tt.want) {
+			if got := NewAuthClient(tt.args.secret, tt.args.config); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewAuthClient() = %v, want %v", got, tt.want)
 			}
 		})
@@ -31,36 +45,81 @@ func TestNewAuthClient(t *testing.T) {
 }
 
 func Test_authClient_GenerateToken(t *testing.T) {
+	user := &entity.User{Id: uuid.New()}
+	adminId := uuid.New()
+	impersonation := &entity.Session{Id: uuid.New(), ImpersonatorId: uuid.NullUUID{UUID: adminId, Valid: true}}
 	type args struct {
 		ctx           context.Context
 		user          *entity.User
+		session       *entity.Session
+		tokenType     string
 		expiredMinute int
 	}
 	tests := []struct {
-		name      string
-		c         *authClient
-		args      args
-		wantToken string
-		wantErr   bool
+		name         string
+		c            *authClient
+		args         args
+		wantIssuer   string
+		wantAudience jwt.ClaimStrings
+		wantActor    string
+		wantErr      bool
 	}{
-		// TODO: Add test cases.
+		{
+			name:    "error user id required",
+			c:       NewAuthClient("secret", DefaultAuthConfig()),
+			args:    args{ctx: context.Background(), user: &entity.User{}, tokenType: TokenTypeAccess, expiredMinute: 60},
+			wantErr: true,
+		},
+		{
+			name: "success without issuer and audience",
+			c:    NewAuthClient("secret", DefaultAuthConfig()),
+			args: args{ctx: context.Background(), user: user, tokenType: TokenTypeAccess, expiredMinute: 60},
+		},
+		{
+			name:         "success with issuer and audience",
+			c:            NewAuthClient("secret", AuthConfig{Issuer: "mitra", Audience: "mitra-apps"}),
+			args:         args{ctx: context.Background(), user: user, tokenType: TokenTypeAccess, expiredMinute: 60},
+			wantIssuer:   "mitra",
+			wantAudience: jwt.ClaimStrings{"mitra-apps"},
+		},
+		{
+			name:      "success impersonation carries actor",
+			c:         NewAuthClient("secret", DefaultAuthConfig()),
+			args:      args{ctx: context.Background(), user: user, session: impersonation, tokenType: TokenTypeAccess, expiredMinute: 15},
+			wantActor: adminId.String(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotToken, err := tt.c.GenerateToken(tt.args.ctx, tt.args.user, tt.args.expiredMinute)
+			gotToken, err := tt.c.GenerateToken(tt.args.ctx, tt.args.user, tt.args.session, tt.args.tokenType, tt.args.expiredMinute)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("authClient.GenerateToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if gotToken != tt.wantToken {
-				t.Errorf("authClient.GenerateToken() = %v, want %v", gotToken, tt.wantToken)
+			if tt.wantErr {
+				return
+			}
+			got, err := tt.c.ValidateToken(tt.args.ctx, gotToken)
+			if err != nil {
+				t.Errorf("authClient.ValidateToken() error = %v", err)
+				return
+			}
+			if got.Issuer != tt.wantIssuer || !reflect.DeepEqual(got.Audience, tt.wantAudience) || got.Subject != user.Id.String() {
+				t.Errorf("authClient.GenerateToken() claims = %+v, want issuer %q and audience %v", got, tt.wantIssuer, tt.wantAudience)
+			}
+			var gotActor string
+			if got.Act != nil {
+				gotActor = got.Act.Subject
+			}
+			if gotActor != tt.wantActor {
+				t.Errorf("authClient.GenerateToken() actor = %q, want %q", gotActor, tt.wantActor)
 			}
 		})
 	}
 }
 
 func Test_authClient_ValidateToken(t *testing.T) {
-	auth := NewAuthClient("secret")
+	auth := NewAuthClient("secret", AuthConfig{Issuer: "mitra", Audience: "mitra-apps", Leeway: 30 * time.Second})
 	user := &entity.User{
 		Id: uuid.MustParse("b70a2a5e-bbd2-4000-96c0-aaa533b8236f"),
 		Roles: []entity.Role{
@@ -75,10 +134,46 @@ func Test_authClient_ValidateToken(t *testing.T) {
 			},
 		},
 	}
-	token, err := auth.GenerateToken(context.Background(), user, 60)
+	session := &entity.Session{
+		Id:             uuid.MustParse("0f0b5c3e-5d9f-4d4e-9a57-3c3f1f3b1a01"),
+		RefreshTokenId: uuid.MustParse("5a3e8f43-6c1b-4a8e-b1f9-2a4d6c8e0b02"),
+	}
+	token, err := auth.GenerateToken(context.Background(), user, session, TokenTypeRefresh, 60)
 	if err != nil {
 		panic(err.Error())
 	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	now := time.Now()
+	// claims returns valid claims of user with modify applied
+	claims := func(modify func(claims jwt.MapClaims)) jwt.MapClaims {
+		claims := jwt.MapClaims{
+			"sub":   user.Id.String(),
+			"iss":   "mitra",
+			"aud":   "mitra-apps",
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+			"roles": []string{"customer"},
+		}
+		if modify != nil {
+			modify(claims)
+		}
+		return claims
+	}
+	sign := func(method jwt.SigningMethod, claims jwt.MapClaims, key interface{}) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			panic(err.Error())
+		}
+		return token
+	}
+	hs256 := func(claims jwt.MapClaims) string {
+		return sign(jwt.SigningMethodHS256, claims, []byte("secret"))
+	}
+
 	type args struct {
 		ctx          context.Context
 		requestToken string
@@ -92,9 +187,7 @@ func Test_authClient_ValidateToken(t *testing.T) {
 	}{
 		{
 			name: "success",
-			c: &authClient{
-				secret: "secret",
-			},
+			c:    auth,
 			args: args{
 				ctx:          context.Background(),
 				requestToken: token,
@@ -105,12 +198,160 @@ func Test_authClient_ValidateToken(t *testing.T) {
 					"customer",
 					"admin",
 				},
+				SessionId: "0f0b5c3e-5d9f-4d4e-9a57-3c3f1f3b1a01",
+				TokenType: TokenTypeRefresh,
 				RegisteredClaims: jwt.RegisteredClaims{
 					Subject: "b70a2a5e-bbd2-4000-96c0-aaa533b8236f",
+					ID:      "5a3e8f43-6c1b-4a8e-b1f9-2a4d6c8e0b02",
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "success null roles",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { claims["roles"] = nil })),
+			},
+			want: &JwtCustomClaim{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: user.Id.String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success missing roles",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { delete(claims, "roles") })),
+			},
+			want: &JwtCustomClaim{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: user.Id.String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success expired within leeway",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { claims["exp"] = now.Add(-10 * time.Second).Unix() })),
+			},
+			want: &JwtCustomClaim{
+				Roles:            []string{"customer"},
+				RegisteredClaims: jwt.RegisteredClaims{Subject: user.Id.String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error malformed token",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: "not.a.token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "error roles not strings",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { claims["roles"] = []int{1} })),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error unsigned token",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: sign(jwt.SigningMethodNone, claims(nil), jwt.UnsafeAllowNoneSignatureType),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error other hmac algorithm",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: sign(jwt.SigningMethodHS512, claims(nil), []byte("secret")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error asymmetric algorithm",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: sign(jwt.SigningMethodRS256, claims(nil), rsaKey),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error wrong secret",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: sign(jwt.SigningMethodHS256, claims(nil), []byte("other secret")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error expired",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { claims["exp"] = now.Add(-time.Minute).Unix() })),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error no expiry",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { delete(claims, "exp") })),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error issued in the future",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { claims["iat"] = now.Add(time.Minute).Unix() })),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error wrong issuer",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { claims["iss"] = "other" })),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error wrong audience",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { claims["aud"] = "other" })),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error no subject",
+			c:    auth,
+			args: args{
+				ctx:          context.Background(),
+				requestToken: hs256(claims(func(claims jwt.MapClaims) { delete(claims, "sub") })),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -120,12 +361,17 @@ func Test_authClient_ValidateToken(t *testing.T) {
 				return
 			}
 			if got != nil {
-				if !reflect.DeepEqual(got.Roles, tt.want.Roles) {
-					t.Errorf("authClient.ValidateToken() = %v, want %v", got, tt.want)
+				if len(got.Roles) != 0 || len(tt.want.Roles) != 0 {
+					if !reflect.DeepEqual(got.Roles, tt.want.Roles) {
+						t.Errorf("authClient.ValidateToken() = %v, want %v", got, tt.want)
+					}
 				}
 				if !reflect.DeepEqual(got.Subject, tt.want.Subject) {
 					t.Errorf("authClient.ValidateToken() = %v, want %v", got, tt.want)
 				}
+				if got.SessionId != tt.want.SessionId || got.TokenType != tt.want.TokenType || got.ID != tt.want.ID {
+					t.Errorf("authClient.ValidateToken() = %v, want %v", got, tt.want)
+				}
 			}
 		})
 	}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/tools"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+const emailChangedNotice = "Email akun Mitra anda telah diubah menjadi %s. Jika anda tidak merasa melakukan perubahan ini, segera hubungi kami."
+
+// RequestEmailChange sends an otp to newEmail. The current email keeps working until
+// the change is confirmed with ConfirmEmailChange. The wrong codes counted for the user are kept,
+// a new code does not lift a lockout.
+func (s *Service) RequestEmailChange(ctx context.Context, userId uuid.UUID, newEmail string) error {
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(newEmail, user.Email) {
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_EMAIL_REGISTERED.String()
+		ErrorMessage = "Email baru sama dengan email saat ini"
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	if err := s.checkEmailAvailable(ctx, newEmail); err != nil {
+		return err
+	}
+	if err := s.throttleOtp(ctx, tools.EmailChangeOtpCooldownRedisPrefix+user.Id.String()); err != nil {
+		return err
+	}
+
+	recipient := *user
+	recipient.Email = newEmail
+	otpKey := tools.EmailChangeOtpRedisPrefix + user.Id.String()
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.issueOtp(ctx, &recipient, entity.OtpChannelEmail, "email_change", otpKey); err != nil {
+			return err
+		}
+		return s.redis.Set(ctx, tools.EmailChangeRedisPrefix+user.Id.String(), newEmail, otpExpiration)
+	})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("failed to issue email change otp")
+		ErrorCode = codes.Internal
+		ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
+		ErrorMessage = "Gagal mengirim kode OTP"
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	s.audit(ctx, entity.AuditOtpSent, user.Id, user.Id, map[string]string{"purpose": "email_change", "channel": entity.OtpChannelEmail})
+	return nil
+}
+
+// ConfirmEmailChange switches the email and username of the user to the address proven by otp
+// and lets the previous address know about the change
+func (s *Service) ConfirmEmailChange(ctx context.Context, userId uuid.UUID, otp int) (*entity.User, error) {
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	pendingKey := tools.EmailChangeRedisPrefix + user.Id.String()
+	newEmail, err := s.redis.GetStringKey(ctx, pendingKey)
+	if err != nil {
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_OTP_INVALID.String()
+		ErrorMessage = "Kode OTP Tidak Berlaku"
+		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	if _, err := s.consumeOtp(ctx, otp, tools.EmailChangeOtpRedisPrefix+user.Id.String()); err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.UpdateEmail(ctx, user.Id, newEmail); err != nil {
+			return err
+		}
+		message, err := entity.NewOutboxMessage(entity.OutboxTopicNotice, &entity.Notice{
+			Channel: entity.OtpChannelEmail,
+			Name:    user.Name,
+			Email:   user.Email,
+			Subject: "Email akun Mitra telah diubah",
+			Message: fmt.Sprintf(emailChangedNotice, newEmail),
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.outbox.Create(ctx, message); err != nil {
+			return err
+		}
+		return s.publishEvent(ctx, user.Id, &events.UserProfileUpdated{
+			UserId:        user.Id.String(),
+			Email:         newEmail,
+			PhoneNumber:   user.PhoneNumber,
+			Name:          user.Name,
+			Address:       user.Address,
+			ChangedFields: []string{"email"},
+		})
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateEmail) {
+			return nil, emailRegisteredError()
+		}
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if err := s.redis.Del(ctx, pendingKey); err != nil {
+		logger.FromContext(ctx).WithError(err).Warn("failed to drop confirmed email change")
+	}
+	dropCachedUsers(ctx, s.redis, user.Id)
+
+	s.audit(ctx, entity.AuditProfileChanged, user.Id, user.Id, map[string]string{"field": "email"})
+
+	user.Email = newEmail
+	user.Username = newEmail
+	return user, nil
+}
+
+// checkEmailAvailable fails when email already belongs to an account
+func (s *Service) checkEmailAvailable(ctx context.Context, email string) error {
+	_, err := s.userRepository.GetByEmail(ctx, email)
+	if err == nil {
+		return emailRegisteredError()
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return nil
+}
+
+func emailRegisteredError() error {
+	ErrorCode = codes.AlreadyExists
+	ErrorCodeDetail = pbErr.ErrorCode_AUTH_EMAIL_REGISTERED.String()
+	ErrorMessage = "Email sudah digunakan oleh akun lain"
+	return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	mockRedis "github.com/Mitra-Apps/be-user-service/config/tools/redis/mock"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	r "github.com/go-redis/redis"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_RequestEmailChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockUserRecord := mockUser.EXPECT()
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	redisRecord := redis.EXPECT()
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	inTransaction := func() *gomock.Call {
+		return mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		redis:          redis,
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+	}
+	userId := uuid.New()
+	user := &entity.User{
+		Id:    userId,
+		Name:  "test",
+		Email: "old@mail.com",
+	}
+	newEmail := "new@mail.com"
+	otpKey := "otp:email-change:" + userId.String()
+	cooldownKey := "otp-cooldown:email-change:" + userId.String()
+	tests := []struct {
+		name     string
+		newEmail string
+		wantErr  bool
+		mocks    []*gomock.Call
+	}{
+		{
+			name:     "error same email",
+			newEmail: "OLD@mail.com",
+			wantErr:  true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+			},
+		},
+		{
+			name:     "error email used by another account",
+			newEmail: newEmail,
+			wantErr:  true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+				mockUserRecord.GetByEmail(gomock.Any(), newEmail).Return(&entity.User{Email: newEmail}, nil),
+			},
+		},
+		{
+			name:     "error get by email repo",
+			newEmail: newEmail,
+			wantErr:  true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+				mockUserRecord.GetByEmail(gomock.Any(), newEmail).Return(nil, errors.New("any error")),
+			},
+		},
+		{
+			name:     "error within the cooldown",
+			newEmail: newEmail,
+			wantErr:  true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+				mockUserRecord.GetByEmail(gomock.Any(), newEmail).Return(nil, repository.ErrUserNotFound),
+				redisRecord.Incr(gomock.Any(), cooldownKey, otpCooldown).Return(int64(2), nil),
+			},
+		},
+		{
+			name:     "error store new email",
+			newEmail: newEmail,
+			wantErr:  true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+				mockUserRecord.GetByEmail(gomock.Any(), newEmail).Return(nil, repository.ErrUserNotFound),
+				redisRecord.Incr(gomock.Any(), cooldownKey, otpCooldown).Return(int64(1), nil),
+				inTransaction(),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+				redisRecord.Set(gomock.Any(), otpKey, gomock.Any(), gomock.Any()).Return(nil),
+				redisRecord.Set(gomock.Any(), "email-change:"+userId.String(), newEmail, otpExpiration).Return(errors.New("any error")),
+			},
+		},
+		{
+			name:     "success",
+			newEmail: newEmail,
+			wantErr:  false,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+				mockUserRecord.GetByEmail(gomock.Any(), newEmail).Return(nil, repository.ErrUserNotFound),
+				redisRecord.Incr(gomock.Any(), cooldownKey, otpCooldown).Return(int64(1), nil),
+				inTransaction(),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, message *entity.OutboxMessage) error {
+						var otp entity.OtpNotification
+						if err := json.Unmarshal(message.Payload, &otp); err != nil || otp.Email != newEmail {
+							t.Errorf("Service.RequestEmailChange() otp sent to %v, want %v", otp.Email, newEmail)
+						}
+						return nil
+					}),
+				redisRecord.Set(gomock.Any(), otpKey, gomock.Any(), gomock.Any()).Return(nil),
+				redisRecord.Set(gomock.Any(), "email-change:"+userId.String(), newEmail, otpExpiration).Return(nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.RequestEmailChange(context.Background(), userId, tt.newEmail); (err != nil) != tt.wantErr {
+				t.Errorf("Service.RequestEmailChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if user.Email != "old@mail.com" {
+				t.Errorf("Service.RequestEmailChange() changed email to %v before confirmation", user.Email)
+			}
+		})
+	}
+}
+
+func TestService_ConfirmEmailChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockUserRecord := mockUser.EXPECT()
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	redisRecord := redis.EXPECT()
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	inTransaction := func() *gomock.Call {
+		return mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		redis:          redis,
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+	}
+	userId := uuid.New()
+	newUser := func() *entity.User {
+		return &entity.User{
+			Id:       userId,
+			Name:     "test",
+			Username: "old@mail.com",
+			Email:    "old@mail.com",
+		}
+	}
+	newEmail := "new@mail.com"
+	pendingKey := "email-change:" + userId.String()
+	otpKey := "otp:email-change:" + userId.String()
+	attemptsKey := otpKey + ":attempts"
+	storedOtp := `{"OTP":"1234","Channel":"email"}`
+	validOtp := func() {
+		mockUserRecord.GetByID(gomock.Any(), userId).Return(newUser(), nil)
+		redisRecord.GetStringKey(gomock.Any(), pendingKey).Return(newEmail, nil)
+		redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(1), nil)
+		redisRecord.GetStringKey(gomock.Any(), otpKey).Return(storedOtp, nil)
+		redisRecord.Del(gomock.Any(), otpKey, attemptsKey).Return(nil)
+	}
+	tests := []struct {
+		name    string
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error no pending change",
+			wantErr: true,
+			mocks: func() {
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(newUser(), nil)
+				redisRecord.GetStringKey(gomock.Any(), pendingKey).Return("", errors.New("redis: nil"))
+			},
+		},
+		{
+			name:    "error wrong otp",
+			wantErr: true,
+			mocks: func() {
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(newUser(), nil)
+				redisRecord.GetStringKey(gomock.Any(), pendingKey).Return(newEmail, nil)
+				redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(1), nil)
+				redisRecord.GetStringKey(gomock.Any(), otpKey).Return(`{"OTP":"4321","Channel":"email"}`, nil)
+			},
+		},
+		{
+			name:    "error email taken meanwhile",
+			wantErr: true,
+			mocks: func() {
+				validOtp()
+				inTransaction()
+				mockUserRecord.UpdateEmail(gomock.Any(), userId, newEmail).Return(repository.ErrDuplicateEmail)
+			},
+		},
+		{
+			name:    "success",
+			wantErr: false,
+			mocks: func() {
+				validOtp()
+				inTransaction()
+				mockUserRecord.UpdateEmail(gomock.Any(), userId, newEmail).Return(nil)
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, message *entity.OutboxMessage) error {
+						var notice entity.Notice
+						if err := json.Unmarshal(message.Payload, &notice); err != nil || message.Topic != entity.OutboxTopicNotice || notice.Email != "old@mail.com" {
+							t.Errorf("Service.ConfirmEmailChange() notice = %v, want notice to old@mail.com", notice)
+						}
+						return nil
+					})
+				expectEvent(t, mockOutbox, userId, &events.UserProfileUpdated{
+					UserId:        userId.String(),
+					Email:         newEmail,
+					Name:          "test",
+					ChangedFields: []string{"email"},
+				})
+				redisRecord.Del(gomock.Any(), pendingKey).Return(nil)
+				redisRecord.Del(gomock.Any(), "user-summary:"+userId.String()).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.ConfirmEmailChange(context.Background(), userId, 1234)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.ConfirmEmailChange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Email != newEmail || got.Username != newEmail) {
+				t.Errorf("Service.ConfirmEmailChange() = %v, want email and username %v", got, newEmail)
+			}
+		})
+	}
+}
+
+func TestService_EmailChangeOtpLockoutSurvivesReissue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		redis:          redis,
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+	}
+	user := &entity.User{Id: uuid.New(), Name: "test", Email: "old@mail.com", IsActive: true}
+	newEmail := "new@mail.com"
+	mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil).AnyTimes()
+	mockUser.EXPECT().GetByEmail(gomock.Any(), newEmail).Return(nil, repository.ErrUserNotFound).AnyTimes()
+	mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	// redis keeping its values in memory
+	values := map[string]string{}
+	counters := map[string]int64{}
+	redis.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+			switch value := value.(type) {
+			case []byte:
+				values[key] = string(value)
+			case string:
+				values[key] = value
+			}
+			return nil
+		}).AnyTimes()
+	redis.EXPECT().GetStringKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string) (string, error) {
+			value, ok := values[key]
+			if !ok {
+				return "", r.Nil
+			}
+			return value, nil
+		}).AnyTimes()
+	redis.EXPECT().Incr(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+			counters[key]++
+			return counters[key], nil
+		}).AnyTimes()
+	redis.EXPECT().Del(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, keys ...string) error {
+			for _, key := range keys {
+				delete(values, key)
+				delete(counters, key)
+			}
+			return nil
+		}).AnyTimes()
+	issuedOtp := func() int {
+		var stored struct{ OTP string }
+		json.Unmarshal([]byte(values["otp:email-change:"+user.Id.String()]), &stored)
+		otp, _ := strconv.Atoi(stored.OTP)
+		return otp
+	}
+
+	if err := s.RequestEmailChange(context.Background(), user.Id, newEmail); err != nil {
+		t.Fatalf("Service.RequestEmailChange() error = %v", err)
+	}
+	if err := s.RequestEmailChange(context.Background(), user.Id, newEmail); err == nil {
+		t.Errorf("Service.RequestEmailChange() within the cooldown error = nil, want rate limited")
+	}
+	// another 4 digit code
+	wrongOtp := issuedOtp()%9000 + 1000
+	for i := 0; i < maxOtpAttempts; i++ {
+		if _, err := s.ConfirmEmailChange(context.Background(), user.Id, wrongOtp); err == nil {
+			t.Fatalf("Service.ConfirmEmailChange() with a wrong code error = nil")
+		}
+	}
+
+	// once the cooldown expired a new code is sent, it cannot be guessed further
+	delete(counters, "otp-cooldown:email-change:"+user.Id.String())
+	if err := s.RequestEmailChange(context.Background(), user.Id, newEmail); err != nil {
+		t.Fatalf("Service.RequestEmailChange() reissue error = %v", err)
+	}
+	_, err := s.ConfirmEmailChange(context.Background(), user.Id, issuedOtp())
+	if err == nil || !strings.Contains(err.Error(), pbErr.ErrorCode_AUTH_OTP_TOO_MANY_ATTEMPTS.String()) {
+		t.Errorf("Service.ConfirmEmailChange() after a reissue error = %v, want %v", err, pbErr.ErrorCode_AUTH_OTP_TOO_MANY_ATTEMPTS)
+	}
+	if user.Email != "old@mail.com" {
+		t.Errorf("Service.ConfirmEmailChange() changed email to %v", user.Email)
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// publishEvent queues event about userId in the outbox. Called within the transaction
+// of the change it announces, the event is published if and only if the change is committed.
+func (s *Service) publishEvent(ctx context.Context, userId uuid.UUID, event proto.Message) error {
+	domainEvent, err := entity.NewDomainEvent(userId, event)
+	if err != nil {
+		return err
+	}
+	message, err := entity.NewOutboxMessage(entity.OutboxTopicEvent, domainEvent)
+	if err != nil {
+		return err
+	}
+	return s.outbox.Create(ctx, message)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+// expectEvent expects want about userId to be queued in mockOutbox
+func expectEvent(t *testing.T, mockOutbox *mock.MockOutbox, userId uuid.UUID, want proto.Message) {
+	mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, message *entity.OutboxMessage) error {
+		if message.Topic != entity.OutboxTopicEvent {
+			t.Errorf("outbox message topic = %v, want %v", message.Topic, entity.OutboxTopicEvent)
+			return nil
+		}
+		var event entity.DomainEvent
+		if err := json.Unmarshal(message.Payload, &event); err != nil {
+			t.Errorf("outbox message payload error = %v", err)
+			return nil
+		}
+		got := want.ProtoReflect().New().Interface()
+		if err := proto.Unmarshal(event.Payload, got); err != nil {
+			t.Errorf("event payload error = %v", err)
+			return nil
+		}
+		if event.UserId != userId || event.Type != string(want.ProtoReflect().Descriptor().FullName()) || !proto.Equal(got, want) {
+			t.Errorf("queued event %v %v about %v, want %v about %v", event.Type, got, event.UserId, want, userId)
+		}
+		return nil
+	})
+}
+
+func TestService_publishEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	s := &Service{outbox: mockOutbox}
+	userId := uuid.New()
+	want := &events.UserVerified{UserId: userId.String(), PhoneVerified: true}
+
+	expectEvent(t, mockOutbox, userId, want)
+	if err := s.publishEvent(context.Background(), userId, want); err != nil {
+		t.Errorf("Service.publishEvent() error = %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// ExportUserData assembles the personal data held about userId
+func (s *Service) ExportUserData(ctx context.Context, userId uuid.UUID) (*entity.DataExport, error) {
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionRepo.ListByUserID(ctx, user.Id)
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	attempts, err := s.listAllLoginAttempts(ctx, user.Id)
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	events, err := s.listAllAuditEvents(ctx, entity.AuditFilter{TargetUserId: uuid.NullUUID{UUID: user.Id, Valid: true}})
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+
+	export := &entity.DataExport{
+		ExportedAt:   time.Now().UTC(),
+		Profile:      user.ToProfileExport(),
+		Roles:        []string{},
+		Sessions:     make([]entity.SessionExport, 0, len(sessions)),
+		LoginHistory: make([]entity.LoginAttemptExport, 0, len(attempts)),
+		Activity:     make([]entity.AuditEventExport, 0, len(events)),
+	}
+	for _, role := range user.Roles {
+		export.Roles = append(export.Roles, role.RoleName)
+	}
+	for _, session := range sessions {
+		export.Sessions = append(export.Sessions, session.ToExport())
+	}
+	for _, attempt := range attempts {
+		export.LoginHistory = append(export.LoginHistory, attempt.ToExport())
+	}
+	for _, event := range events {
+		export.Activity = append(export.Activity, event.ToExport())
+	}
+	return export, nil
+}
+
+// listAllLoginAttempts pages through the whole login history of userId
+func (s *Service) listAllLoginAttempts(ctx context.Context, userId uuid.UUID) ([]*entity.LoginAttempt, error) {
+	var all []*entity.LoginAttempt
+	for page := 1; ; page++ {
+		attempts, total, err := s.loginHistoryRepo.ListByUserID(ctx, userId, page, maxLoginHistoryPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, attempts...)
+		if len(attempts) < maxLoginHistoryPageSize || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
+
+// listAllAuditEvents pages through all the audit events matching filter
+func (s *Service) listAllAuditEvents(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEvent, error) {
+	var all []*entity.AuditEvent
+	filter.PageSize = maxAuditPageSize
+	for filter.Page = 1; ; filter.Page++ {
+		events, total, err := s.auditRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, events...)
+		if len(events) < filter.PageSize || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_ExportUserData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	mockAudit := mock.NewMockAudit(ctrl)
+	mockLoginHistory := mock.NewMockLoginHistory(ctrl)
+	s := &Service{
+		userRepository:   mockUser,
+		sessionRepo:      mockSession,
+		auditRepo:        mockAudit,
+		loginHistoryRepo: mockLoginHistory,
+	}
+	userId := uuid.New()
+	user := &entity.User{
+		Id:       userId,
+		Email:    "test@mail.com",
+		Password: "hashed",
+		Roles:    []entity.Role{{RoleName: "customer"}},
+	}
+	session := &entity.Session{Id: uuid.New(), UserId: userId, DeviceName: "Pixel 8"}
+	attempt := &entity.LoginAttempt{Id: uuid.New(), UserId: userId, Method: entity.LoginMethodPassword, Succeeded: true}
+	event := &entity.AuditEvent{Id: uuid.New(), Action: entity.AuditLoginSucceeded, Metadata: []byte(`{"method":"password"}`)}
+	tests := []struct {
+		name    string
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error user not found",
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(nil, repository.ErrUserNotFound)
+			},
+		},
+		{
+			name:    "error list sessions",
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(user, nil)
+				mockSession.EXPECT().ListByUserID(gomock.Any(), userId).Return(nil, errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			wantErr: false,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(user, nil)
+				mockSession.EXPECT().ListByUserID(gomock.Any(), userId).Return([]*entity.Session{session}, nil)
+				mockLoginHistory.EXPECT().ListByUserID(gomock.Any(), userId, 1, maxLoginHistoryPageSize).Return([]*entity.LoginAttempt{attempt}, int64(1), nil)
+				mockAudit.EXPECT().List(gomock.Any(), entity.AuditFilter{
+					TargetUserId: uuid.NullUUID{UUID: userId, Valid: true},
+					Page:         1,
+					PageSize:     maxAuditPageSize,
+				}).Return([]*entity.AuditEvent{event}, int64(1), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.ExportUserData(context.Background(), userId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.ExportUserData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Profile.Email != user.Email || len(got.Roles) != 1 || got.Roles[0] != "customer" ||
+				len(got.Sessions) != 1 || got.Sessions[0].DeviceName != "Pixel 8" ||
+				len(got.LoginHistory) != 1 || !got.LoginHistory[0].Succeeded || len(got.Activity) != 1 || got.Activity[0].Metadata["method"] != "password" {
+				t.Errorf("Service.ExportUserData() = %+v, want profile, roles, sessions, login history and activity of %v", got, userId)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+)
+
+// IdentityVerifier verifies the id tokens of an external identity provider
+type IdentityVerifier interface {
+	Verify(ctx context.Context, idToken string) (*entity.ExternalClaims, error)
+}
+
+// isInvalidIdToken reports whether a verifier rejected the token itself rather than failed
+// to check it, verifiers mark such errors with an InvalidToken method
+func isInvalidIdToken(err error) bool {
+	var invalid interface{ InvalidToken() bool }
+	return errors.As(err, &invalid) && invalid.InvalidToken()
+}
@@ -0,0 +1,84 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GoogleKeysURL publishes the keys google signs its id tokens with
+const GoogleKeysURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+// google issues its id tokens under either issuer
+var googleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+// tolerated clock skew between google and us
+const googleLeeway = 30 * time.Second
+
+// Google verifies the id tokens google issues to our apps on "Sign in with Google"
+type Google struct {
+	keys      *KeySet
+	clientIds []string
+}
+
+// NewGoogle accepts the id tokens signed with keys and issued to one of clientIds,
+// the oauth client ids of our apps
+func NewGoogle(keys *KeySet, clientIds ...string) *Google {
+	return &Google{
+		keys:      keys,
+		clientIds: clientIds,
+	}
+}
+
+type googleClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// Verify checks the signature, issuer, audience and expiry of idToken and returns its claims.
+// Errors about the token itself have an InvalidToken method, the others come from fetching the keys.
+func (g *Google) Verify(ctx context.Context, idToken string) (*entity.ExternalClaims, error) {
+	var keyErr error
+	claims := &googleClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := g.keys.Key(ctx, kid)
+		keyErr = err
+		return key, err
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(googleLeeway),
+	)
+	if keyErr != nil {
+		return nil, keyErr
+	}
+	if err != nil {
+		return nil, invalidTokenError{err}
+	}
+
+	if !slices.Contains(googleIssuers, claims.Issuer) {
+		return nil, invalidTokenError{fmt.Errorf("unexpected issuer %q", claims.Issuer)}
+	}
+	if !slices.ContainsFunc(claims.Audience, func(aud string) bool { return slices.Contains(g.clientIds, aud) }) {
+		return nil, invalidTokenError{fmt.Errorf("unexpected audience %v", claims.Audience)}
+	}
+	if claims.Subject == "" {
+		return nil, invalidTokenError{errors.New("missing subject")}
+	}
+	return &entity.ExternalClaims{
+		Provider:      entity.IdentityProviderGoogle,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
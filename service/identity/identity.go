@@ -0,0 +1,19 @@
+// Package identity verifies the id tokens of the external identity providers users sign in with
+package identity
+
+// invalidTokenError marks a token rejected for itself, retrying it would fail the same way
+type invalidTokenError struct {
+	err error
+}
+
+func (e invalidTokenError) Error() string {
+	return "invalid id token: " + e.err.Error()
+}
+
+func (e invalidTokenError) Unwrap() error {
+	return e.err
+}
+
+func (e invalidTokenError) InvalidToken() bool {
+	return true
+}
@@ -0,0 +1,249 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// keyServer publishes a locally generated key set the way google does
+type keyServer struct {
+	*httptest.Server
+	keys    map[string]*rsa.PublicKey
+	fetches atomic.Int32
+	fail    atomic.Bool
+}
+
+func newKeyServer(t *testing.T, keys map[string]*rsa.PublicKey) *keyServer {
+	s := &keyServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		if s.fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var body struct {
+			Keys []jsonWebKey `json:"keys"`
+		}
+		for kid, key := range s.keys {
+			body.Keys = append(body.Keys, jsonWebKey{
+				Kty: "RSA",
+				Kid: kid,
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestGoogle_Verify(t *testing.T) {
+	key := generateKey(t)
+	otherKey := generateKey(t)
+	server := newKeyServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	google := NewGoogle(NewKeySet(server.Client(), server.URL), "web-client", "android-client")
+
+	now := time.Now()
+	// claims returns the claims google issues to the android app, with modify applied
+	claims := func(modify func(claims jwt.MapClaims)) jwt.MapClaims {
+		claims := jwt.MapClaims{
+			"iss":            "https://accounts.google.com",
+			"aud":            "android-client",
+			"sub":            "108123456789",
+			"email":          "test@gmail.com",
+			"email_verified": true,
+			"name":           "Test",
+			"iat":            now.Unix(),
+			"exp":            now.Add(time.Hour).Unix(),
+		}
+		if modify != nil {
+			modify(claims)
+		}
+		return claims
+	}
+	sign := func(method jwt.SigningMethod, kid string, claims jwt.MapClaims, key interface{}) string {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	rs256 := func(claims jwt.MapClaims) string {
+		return sign(jwt.SigningMethodRS256, "key-1", claims, key)
+	}
+
+	tests := []struct {
+		name        string
+		idToken     string
+		want        *entity.ExternalClaims
+		wantInvalid bool
+	}{
+		{
+			name:    "success",
+			idToken: rs256(claims(nil)),
+			want: &entity.ExternalClaims{
+				Provider:      entity.IdentityProviderGoogle,
+				Subject:       "108123456789",
+				Email:         "test@gmail.com",
+				EmailVerified: true,
+				Name:          "Test",
+			},
+		},
+		{
+			name: "success issuer without scheme and unverified email",
+			idToken: rs256(claims(func(claims jwt.MapClaims) {
+				claims["iss"] = "accounts.google.com"
+				claims["aud"] = "web-client"
+				claims["email_verified"] = false
+			})),
+			want: &entity.ExternalClaims{
+				Provider: entity.IdentityProviderGoogle,
+				Subject:  "108123456789",
+				Email:    "test@gmail.com",
+				Name:     "Test",
+			},
+		},
+		{
+			name:        "error malformed token",
+			idToken:     "not.a.token",
+			wantInvalid: true,
+		},
+		{
+			name:        "error unsigned token",
+			idToken:     sign(jwt.SigningMethodNone, "key-1", claims(nil), jwt.UnsafeAllowNoneSignatureType),
+			wantInvalid: true,
+		},
+		{
+			name:        "error hmac signed with the public key",
+			idToken:     sign(jwt.SigningMethodHS256, "key-1", claims(nil), key.PublicKey.N.Bytes()),
+			wantInvalid: true,
+		},
+		{
+			name:        "error signed by another key",
+			idToken:     sign(jwt.SigningMethodRS256, "key-1", claims(nil), otherKey),
+			wantInvalid: true,
+		},
+		{
+			name:        "error unknown key id",
+			idToken:     sign(jwt.SigningMethodRS256, "key-2", claims(nil), otherKey),
+			wantInvalid: true,
+		},
+		{
+			name:        "error expired",
+			idToken:     rs256(claims(func(claims jwt.MapClaims) { claims["exp"] = now.Add(-time.Minute).Unix() })),
+			wantInvalid: true,
+		},
+		{
+			name:        "error other issuer",
+			idToken:     rs256(claims(func(claims jwt.MapClaims) { claims["iss"] = "https://accounts.example.com" })),
+			wantInvalid: true,
+		},
+		{
+			name:        "error issued to another app",
+			idToken:     rs256(claims(func(claims jwt.MapClaims) { claims["aud"] = "other-client" })),
+			wantInvalid: true,
+		},
+		{
+			name:        "error no subject",
+			idToken:     rs256(claims(func(claims jwt.MapClaims) { delete(claims, "sub") })),
+			wantInvalid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := google.Verify(context.Background(), tt.idToken)
+			var invalid interface{ InvalidToken() bool }
+			if isInvalid := errors.As(err, &invalid) && invalid.InvalidToken(); isInvalid != tt.wantInvalid {
+				t.Errorf("Google.Verify() error = %v, wantInvalid %v", err, tt.wantInvalid)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Google.Verify() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogle_Verify_keysUnavailable(t *testing.T) {
+	key := generateKey(t)
+	server := newKeyServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	server.fail.Store(true)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": "https://accounts.google.com",
+		"aud": "client",
+		"sub": "108123456789",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "key-1"
+	idToken, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewGoogle(NewKeySet(server.Client(), server.URL), "client").Verify(context.Background(), idToken)
+	var invalid interface{ InvalidToken() bool }
+	if err == nil || errors.As(err, &invalid) {
+		t.Errorf("Google.Verify() error = %v, want a failure to fetch the keys", err)
+	}
+}
+
+func TestKeySet_Key(t *testing.T) {
+	key := generateKey(t)
+	rolledKey := generateKey(t)
+	server := newKeyServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	keys := NewKeySet(server.Client(), server.URL)
+	ctx := context.Background()
+
+	if got, err := keys.Key(ctx, "key-1"); err != nil || !got.Equal(&key.PublicKey) {
+		t.Fatalf("KeySet.Key() = %v, %v, want key-1", got, err)
+	}
+	if _, err := keys.Key(ctx, "key-1"); err != nil || server.fetches.Load() != 1 {
+		t.Errorf("KeySet.Key() fetched %v times, want cached keys", server.fetches.Load())
+	}
+
+	// the provider rolls its keys
+	server.keys = map[string]*rsa.PublicKey{"key-1": &key.PublicKey, "key-2": &rolledKey.PublicKey}
+	if _, err := keys.Key(ctx, "key-2"); err == nil || server.fetches.Load() != 1 {
+		t.Errorf("KeySet.Key() = %v after %v fetches, want unknown key id until the refresh interval passed", err, server.fetches.Load())
+	}
+	keys.fetchedAt = keys.fetchedAt.Add(-minRefreshInterval)
+	if got, err := keys.Key(ctx, "key-2"); err != nil || !got.Equal(&rolledKey.PublicKey) {
+		t.Errorf("KeySet.Key() = %v, %v, want the rolled key", got, err)
+	}
+
+	// known keys stay usable while the endpoint is down
+	server.fail.Store(true)
+	keys.fetchedAt = keys.fetchedAt.Add(-maxKeysAge)
+	if got, err := keys.Key(ctx, "key-1"); err != nil || !got.Equal(&key.PublicKey) {
+		t.Errorf("KeySet.Key() = %v, %v, want the stale key", got, err)
+	}
+	if _, err := keys.Key(ctx, "key-3"); err == nil {
+		t.Errorf("KeySet.Key() error = nil, want unknown key id")
+	}
+}
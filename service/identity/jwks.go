@@ -0,0 +1,135 @@
+package identity
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// keys are refetched at most this often for unknown key ids, so tokens with made up
+	// key ids cannot hammer the provider
+	minRefreshInterval = 30 * time.Second
+	// keys are refetched after this long so retired keys are dropped
+	maxKeysAge = time.Hour
+)
+
+// KeySet holds the rsa public keys published at a JWKS endpoint. Keys are fetched on first use,
+// refetched once stale and whenever a token is signed with a key id not known yet, which is how
+// providers roll their keys.
+type KeySet struct {
+	client *http.Client
+	url    string
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewKeySet reads the keys published at url
+func NewKeySet(client *http.Client, url string) *KeySet {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &KeySet{
+		client: client,
+		url:    url,
+	}
+}
+
+// Key returns the key identified by kid
+func (k *KeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	key, ok := k.keys[kid]
+	age := time.Since(k.fetchedAt)
+	if ok && age < maxKeysAge {
+		return key, nil
+	}
+	if !ok && k.keys != nil && age < minRefreshInterval {
+		return nil, invalidTokenError{fmt.Errorf("unknown key id %q", kid)}
+	}
+	keys, err := k.fetch(ctx)
+	if err != nil {
+		if ok {
+			// a known key stays usable while the endpoint is down
+			return key, nil
+		}
+		return nil, err
+	}
+	k.keys = keys
+	k.fetchedAt = time.Now()
+	if key, ok = k.keys[kid]; !ok {
+		return nil, invalidTokenError{fmt.Errorf("unknown key id %q", kid)}
+	}
+	return key, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (k *KeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := k.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: status %d", res.StatusCode)
+	}
+	var body struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(body.Keys))
+	for _, jwk := range body.Keys {
+		// only signing keys of the rsa algorithms id tokens are verified with
+		if jwk.Kty != "RSA" || jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.rsaPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("decode jwk %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (jwk jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid rsa key")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// ImpersonationLifetime bounds an impersonation, its token cannot be refreshed
+const ImpersonationLifetime = 15 * time.Minute
+
+// StartImpersonation opens a session of userId on behalf of adminId, from device. Its tokens carry
+// the admin as actor. Admins cannot be impersonated, that would hand over their rights.
+func (s *Service) StartImpersonation(ctx context.Context, adminId uuid.UUID, userId uuid.UUID, reason string, device entity.Device) (*entity.User, *entity.Session, error) {
+	if adminId == userId {
+		return nil, nil, impersonationNotAllowedError("Tidak dapat meniru akun sendiri")
+	}
+	user, err := s.GetUser(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if slices.ContainsFunc(user.Roles, func(role entity.Role) bool { return role.RoleName == entity.RoleAdmin }) {
+		return nil, nil, impersonationNotAllowedError("Akun admin tidak dapat ditiru")
+	}
+
+	now := time.Now()
+	session := &entity.Session{
+		UserId:         userId,
+		DeviceName:     truncate(device.Name, 255),
+		UserAgent:      truncate(device.UserAgent, 512),
+		IpAddress:      truncate(device.IpAddress, 64),
+		RefreshTokenId: uuid.New(),
+		CreatedAt:      now,
+		LastSeenAt:     now,
+		ExpiresAt:      now.Add(ImpersonationLifetime),
+		ImpersonatorId: uuid.NullUUID{UUID: adminId, Valid: true},
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditImpersonateStart, adminId, userId, map[string]string{
+		"session_id": session.Id.String(),
+		"reason":     reason,
+	})
+	logger.FromContext(ctx).WithField("target_user_id", userId.String()).Info("impersonation started")
+	return user, session, nil
+}
+
+// StopImpersonation revokes the impersonation session sessionId of userId opened by adminId
+func (s *Service) StopImpersonation(ctx context.Context, adminId uuid.UUID, userId uuid.UUID, sessionId uuid.UUID) error {
+	if err := s.sessionRepo.Revoke(ctx, userId, sessionId); err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return sessionInvalidError()
+		}
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditImpersonateStop, adminId, userId, map[string]string{"session_id": sessionId.String()})
+	logger.FromContext(ctx).WithField("target_user_id", userId.String()).Info("impersonation stopped")
+	return nil
+}
+
+// CheckSessionActive fails once sessionId of userId was revoked or expired
+func (s *Service) CheckSessionActive(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return sessionInvalidError()
+		}
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if session.UserId != userId || !session.IsActive(time.Now()) {
+		return sessionInvalidError()
+	}
+	return nil
+}
+
+func impersonationNotAllowedError(message string) error {
+	return util.NewError(codes.PermissionDenied, pbErr.ErrorCode_IMPERSONATION_NOT_ALLOWED.String(), message)
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/Mitra-Apps/be-user-service/handler/middleware"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_StartImpersonation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	mockAudit := mock.NewMockAudit(ctrl)
+	s := &Service{
+		userRepository: mockUser,
+		sessionRepo:    mockSession,
+		auditRepo:      mockAudit,
+	}
+	adminId := uuid.New()
+	user := &entity.User{Id: uuid.New(), IsActive: true, Roles: []entity.Role{{RoleName: "customer"}}}
+	admin := &entity.User{Id: uuid.New(), IsActive: true, Roles: []entity.Role{{RoleName: entity.RoleAdmin}}}
+	tests := []struct {
+		name    string
+		userId  uuid.UUID
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error impersonating oneself",
+			userId:  adminId,
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "error user not found",
+			userId:  user.Id,
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(nil, repository.ErrUserNotFound)
+			},
+		},
+		{
+			name:    "error user inactive",
+			userId:  user.Id,
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(&entity.User{Id: user.Id}, nil)
+			},
+		},
+		{
+			name:    "error impersonating an admin",
+			userId:  admin.Id,
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), admin.Id).Return(admin, nil)
+			},
+		},
+		{
+			name:    "error create session",
+			userId:  user.Id,
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:   "success",
+			userId: user.Id,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockAudit.EXPECT().Create(gomock.Any(), gomock.Cond(func(x any) bool {
+					event := x.(*entity.AuditEvent)
+					return event.Action == entity.AuditImpersonateStart && event.ActorId.UUID == adminId && event.TargetUserId.UUID == user.Id
+				})).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, session, err := s.StartImpersonation(context.Background(), adminId, tt.userId, "ticket 42", entity.Device{Name: "support"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Service.StartImpersonation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != user || session.UserId != user.Id || session.ImpersonatorId.UUID != adminId || session.ExpiresAt.After(time.Now().Add(ImpersonationLifetime)) {
+				t.Errorf("Service.StartImpersonation() = %v, %+v, want short session of %v opened by %v", got, session, user.Id, adminId)
+			}
+		})
+	}
+}
+
+func TestService_StopImpersonation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSession := mock.NewMockSession(ctrl)
+	mockAudit := mock.NewMockAudit(ctrl)
+	s := &Service{
+		sessionRepo: mockSession,
+		auditRepo:   mockAudit,
+	}
+	adminId := uuid.New()
+	userId := uuid.New()
+	sessionId := uuid.New()
+
+	mockSession.EXPECT().Revoke(gomock.Any(), userId, sessionId).Return(repository.ErrSessionNotFound)
+	if err := s.StopImpersonation(context.Background(), adminId, userId, sessionId); err == nil {
+		t.Errorf("Service.StopImpersonation() error = nil, want session invalid")
+	}
+
+	// the stop is requested with the impersonation token
+	ctx := middleware.SetActorIDKey(context.Background(), adminId)
+	mockSession.EXPECT().Revoke(gomock.Any(), userId, sessionId).Return(nil)
+	mockAudit.EXPECT().Create(gomock.Any(), gomock.Cond(func(x any) bool {
+		event := x.(*entity.AuditEvent)
+		return event.Action == entity.AuditImpersonateStop && event.ActorId.UUID == adminId &&
+			strings.Contains(string(event.Metadata), `"impersonator_id":"`+adminId.String()+`"`)
+	})).Return(nil)
+	if err := s.StopImpersonation(ctx, adminId, userId, sessionId); err != nil {
+		t.Errorf("Service.StopImpersonation() error = %v", err)
+	}
+}
+
+func TestService_CheckSessionActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSession := mock.NewMockSession(ctrl)
+	s := &Service{sessionRepo: mockSession}
+	userId := uuid.New()
+	sessionId := uuid.New()
+	revoked := time.Now()
+	tests := []struct {
+		name    string
+		session *entity.Session
+		err     error
+		wantErr bool
+	}{
+		{
+			name:    "error session not found",
+			err:     repository.ErrSessionNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "error get session",
+			err:     errors.New("any error"),
+			wantErr: true,
+		},
+		{
+			name:    "error revoked",
+			session: &entity.Session{Id: sessionId, UserId: userId, ExpiresAt: time.Now().Add(time.Minute), RevokedAt: &revoked},
+			wantErr: true,
+		},
+		{
+			name:    "error session of another user",
+			session: &entity.Session{Id: sessionId, UserId: uuid.New(), ExpiresAt: time.Now().Add(time.Minute)},
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			session: &entity.Session{Id: sessionId, UserId: userId, ExpiresAt: time.Now().Add(time.Minute)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(tt.session, tt.err)
+			if err := s.CheckSessionActive(context.Background(), userId, sessionId); (err != nil) != tt.wantErr {
+				t.Errorf("Service.CheckSessionActive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
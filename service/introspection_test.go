@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestService_IntrospectToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	auth := NewAuthClient("secret", DefaultAuthConfig())
+	s := &Service{userRepository: mockUser, sessionRepo: mockSession, auth: auth}
+	user := &entity.User{Id: uuid.New(), IsActive: true, Roles: []entity.Role{{RoleName: "customer"}}}
+	session := &entity.Session{Id: uuid.New(), UserId: user.Id, ExpiresAt: time.Now().Add(time.Hour)}
+	token := func(user *entity.User, session *entity.Session, tokenType string, expiredMinute int) string {
+		token, err := auth.GenerateToken(context.Background(), user, session, tokenType, expiredMinute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	adminId := uuid.New()
+	impersonation := &entity.Session{Id: uuid.New(), UserId: user.Id, ExpiresAt: time.Now().Add(time.Hour), ImpersonatorId: uuid.NullUUID{UUID: adminId, Valid: true}}
+	revoked := time.Now()
+	tests := []struct {
+		name       string
+		token      string
+		wantActive bool
+		wantActor  uuid.UUID
+		wantErr    bool
+		mocks      func()
+	}{
+		{
+			name:  "malformed token",
+			token: "abc",
+			mocks: func() {},
+		},
+		{
+			name:  "expired token",
+			token: token(user, session, TokenTypeAccess, -1),
+			mocks: func() {},
+		},
+		{
+			name:  "refresh token",
+			token: token(user, session, TokenTypeRefresh, 60),
+			mocks: func() {},
+		},
+		{
+			name:  "deactivated user",
+			token: token(user, session, TokenTypeAccess, 60),
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(&entity.User{Id: user.Id, IsActive: false}, nil)
+			},
+		},
+		{
+			name:  "deleted user",
+			token: token(user, session, TokenTypeAccess, 60),
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(nil, repository.ErrUserNotFound)
+			},
+		},
+		{
+			name:    "error get user",
+			token:   token(user, session, TokenTypeAccess, 60),
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(nil, errors.New("any error"))
+			},
+		},
+		{
+			name:  "revoked session",
+			token: token(user, session, TokenTypeAccess, 60),
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().GetByID(gomock.Any(), session.Id).Return(&entity.Session{Id: session.Id, UserId: user.Id, ExpiresAt: session.ExpiresAt, RevokedAt: &revoked}, nil)
+			},
+		},
+		{
+			name:  "session of another user",
+			token: token(user, session, TokenTypeAccess, 60),
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().GetByID(gomock.Any(), session.Id).Return(&entity.Session{Id: session.Id, UserId: uuid.New(), ExpiresAt: session.ExpiresAt}, nil)
+			},
+		},
+		{
+			name:    "error get session",
+			token:   token(user, session, TokenTypeAccess, 60),
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().GetByID(gomock.Any(), session.Id).Return(nil, errors.New("any error"))
+			},
+		},
+		{
+			name:       "active",
+			token:      token(user, session, TokenTypeAccess, 60),
+			wantActive: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().GetByID(gomock.Any(), session.Id).Return(session, nil)
+			},
+		},
+		{
+			name:       "active impersonation",
+			token:      token(user, impersonation, TokenTypeAccess, 15),
+			wantActive: true,
+			wantActor:  adminId,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().GetByID(gomock.Any(), impersonation.Id).Return(impersonation, nil)
+			},
+		},
+		{
+			name:       "active without session",
+			token:      token(user, nil, TokenTypeAccess, 60),
+			wantActive: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.IntrospectToken(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.IntrospectToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Active != tt.wantActive {
+				t.Errorf("Service.IntrospectToken() active = %v, want %v", got.Active, tt.wantActive)
+			}
+			if tt.wantActive && (got.UserId != user.Id || len(got.Roles) != 1 || got.Roles[0] != "customer" || got.ExpiresAt.Before(time.Now())) {
+				t.Errorf("Service.IntrospectToken() = %+v, want active token of %v", got, user.Id)
+			}
+			if got.ActorId != tt.wantActor {
+				t.Errorf("Service.IntrospectToken() actor = %v, want %v", got.ActorId, tt.wantActor)
+			}
+			if !tt.wantActive && got.UserId != uuid.Nil {
+				t.Errorf("Service.IntrospectToken() = %+v, want nothing disclosed on inactive token", got)
+			}
+		})
+	}
+}
+
+func TestService_AuthenticateAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	auth := NewAuthClient("secret", DefaultAuthConfig())
+	s := &Service{userRepository: mockUser, sessionRepo: mockSession, auth: auth}
+	user := &entity.User{Id: uuid.New(), IsActive: true}
+	session := &entity.Session{Id: uuid.New(), UserId: user.Id, ExpiresAt: time.Now().Add(time.Hour)}
+	accessToken, err := auth.GenerateToken(context.Background(), user, session, TokenTypeAccess, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revoked := time.Now()
+	tests := []struct {
+		name     string
+		token    string
+		wantCode codes.Code
+		mocks    func()
+	}{
+		{
+			name:     "malformed token",
+			token:    "abc",
+			wantCode: codes.Unauthenticated,
+			mocks:    func() {},
+		},
+		{
+			name:     "deactivated user",
+			token:    accessToken,
+			wantCode: codes.PermissionDenied,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(&entity.User{Id: user.Id}, nil)
+			},
+		},
+		{
+			name:     "revoked session",
+			token:    accessToken,
+			wantCode: codes.Unauthenticated,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().GetByID(gomock.Any(), session.Id).Return(&entity.Session{Id: session.Id, UserId: user.Id, ExpiresAt: session.ExpiresAt, RevokedAt: &revoked}, nil)
+			},
+		},
+		{
+			name:     "error get session",
+			token:    accessToken,
+			wantCode: codes.Internal,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().GetByID(gomock.Any(), session.Id).Return(nil, errors.New("any error"))
+			},
+		},
+		{
+			name:     "active",
+			token:    accessToken,
+			wantCode: codes.OK,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+				mockSession.EXPECT().GetByID(gomock.Any(), session.Id).Return(session, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.AuthenticateAccessToken(context.Background(), tt.token)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Service.AuthenticateAccessToken() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err == nil && (got.UserId != user.Id || got.SessionId != session.Id) {
+				t.Errorf("Service.AuthenticateAccessToken() = %+v, want session %v of %v", got, session.Id, user.Id)
+			}
+		})
+	}
+}
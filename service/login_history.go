@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/handler/middleware"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultLoginHistoryPageSize = 20
+	maxLoginHistoryPageSize     = 100
+)
+
+const newDeviceNotice = "Akun Mitra anda baru saja login dari perangkat baru: %s (alamat IP %s) pada %s. Jika ini bukan anda, segera ganti kata sandi anda."
+
+// recordLoginAttempt adds an attempt to sign in as user to its login history, failureReason is empty
+// for successful logins. The user is notified of a successful login from a device never seen before,
+// except on its very first login. Like audit events, failing writes are only logged.
+func (s *Service) recordLoginAttempt(ctx context.Context, user *entity.User, method string, failureReason string) {
+	device := middleware.DeviceFromContext(ctx)
+	attempt := &entity.LoginAttempt{
+		UserId:            user.Id,
+		Method:            method,
+		Succeeded:         failureReason == "",
+		FailureReason:     failureReason,
+		DeviceName:        truncate(device.Name, 255),
+		UserAgent:         truncate(device.UserAgent, 512),
+		IpAddress:         truncate(device.IpAddress, 64),
+		DeviceFingerprint: device.Fingerprint(),
+		CreatedAt:         time.Now(),
+	}
+	log := logger.FromContext(ctx).WithField("user_id", user.Id.String())
+
+	newDevice := false
+	if attempt.Succeeded {
+		var err error
+		if newDevice, err = s.isNewDevice(ctx, user.Id, attempt.DeviceFingerprint); err != nil {
+			log.WithError(err).Error("failed to check the device of a login")
+		}
+	}
+	if err := s.loginHistoryRepo.Create(ctx, attempt); err != nil {
+		log.WithError(err).Error("failed to record login attempt")
+	}
+	if newDevice {
+		if err := s.notifyNewDevice(ctx, user, attempt); err != nil {
+			log.WithError(err).Error("failed to queue new device notice")
+		}
+	}
+}
+
+// isNewDevice reports whether userId, having signed in before, never did from the device with fingerprint
+func (s *Service) isNewDevice(ctx context.Context, userId uuid.UUID, fingerprint string) (bool, error) {
+	known, err := s.loginHistoryRepo.HasSucceeded(ctx, userId, fingerprint)
+	if err != nil || known {
+		return false, err
+	}
+	return s.loginHistoryRepo.HasSucceeded(ctx, userId, "")
+}
+
+func (s *Service) notifyNewDevice(ctx context.Context, user *entity.User, attempt *entity.LoginAttempt) error {
+	device := attempt.DeviceName
+	if device == "" {
+		device = attempt.UserAgent
+	}
+	message, err := entity.NewOutboxMessage(entity.OutboxTopicNotice, &entity.Notice{
+		Channel: entity.OtpChannelEmail,
+		Name:    user.Name,
+		Email:   user.Email,
+		Subject: "Login baru ke akun Mitra",
+		Message: fmt.Sprintf(newDeviceNotice, device, attempt.IpAddress, attempt.CreatedAt.Format("02 Jan 2006 15:04 MST")),
+	})
+	if err != nil {
+		return err
+	}
+	return s.outbox.Create(ctx, message)
+}
+
+// GetMyLoginHistory returns the page of login attempts on the account of userId, newest first, and the count of all of them
+func (s *Service) GetMyLoginHistory(ctx context.Context, userId uuid.UUID, page int, pageSize int) ([]*entity.LoginAttempt, int64, error) {
+	if pageSize <= 0 {
+		pageSize = defaultLoginHistoryPageSize
+	}
+	pageSize = min(pageSize, maxLoginHistoryPageSize)
+	page = max(page, 1)
+	attempts, total, err := s.loginHistoryRepo.ListByUserID(ctx, userId, page, pageSize)
+	if err != nil {
+		return nil, 0, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return attempts, total, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestService_recordLoginAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLoginHistory := mock.NewMockLoginHistory(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	s := &Service{loginHistoryRepo: mockLoginHistory, outbox: mockOutbox}
+	user := &entity.User{Id: uuid.New(), Name: "test", Email: "test@mail.com"}
+	device := entity.Device{Name: "Pixel 8", UserAgent: "okhttp/4.12"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-device-name", device.Name,
+		"user-agent", device.UserAgent,
+	))
+	tests := []struct {
+		name          string
+		failureReason string
+		mocks         func()
+	}{
+		{
+			name:          "failed attempt",
+			failureReason: "wrong_password",
+			mocks: func() {
+				mockLoginHistory.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, attempt *entity.LoginAttempt) error {
+					if attempt.Succeeded || attempt.FailureReason != "wrong_password" || attempt.DeviceFingerprint != device.Fingerprint() {
+						t.Errorf("Service.recordLoginAttempt() recorded %+v", attempt)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "known device",
+			mocks: func() {
+				mockLoginHistory.EXPECT().HasSucceeded(gomock.Any(), user.Id, device.Fingerprint()).Return(true, nil)
+				mockLoginHistory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "first login",
+			mocks: func() {
+				mockLoginHistory.EXPECT().HasSucceeded(gomock.Any(), user.Id, device.Fingerprint()).Return(false, nil)
+				mockLoginHistory.EXPECT().HasSucceeded(gomock.Any(), user.Id, "").Return(false, nil)
+				mockLoginHistory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "new device notified",
+			mocks: func() {
+				mockLoginHistory.EXPECT().HasSucceeded(gomock.Any(), user.Id, device.Fingerprint()).Return(false, nil)
+				mockLoginHistory.EXPECT().HasSucceeded(gomock.Any(), user.Id, "").Return(true, nil)
+				mockLoginHistory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, message *entity.OutboxMessage) error {
+					if message.Topic != entity.OutboxTopicNotice {
+						t.Errorf("Service.recordLoginAttempt() queued %v, want a notice", message.Topic)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "history unavailable",
+			mocks: func() {
+				mockLoginHistory.EXPECT().HasSucceeded(gomock.Any(), user.Id, device.Fingerprint()).Return(false, errors.New("any error"))
+				mockLoginHistory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("any error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			s.recordLoginAttempt(ctx, user, entity.LoginMethodPassword, tt.failureReason)
+		})
+	}
+}
+
+func TestService_GetMyLoginHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLoginHistory := mock.NewMockLoginHistory(ctrl)
+	s := &Service{loginHistoryRepo: mockLoginHistory}
+	userId := uuid.New()
+
+	mockLoginHistory.EXPECT().ListByUserID(gomock.Any(), userId, 1, defaultLoginHistoryPageSize).Return([]*entity.LoginAttempt{}, int64(0), nil)
+	if _, _, err := s.GetMyLoginHistory(context.Background(), userId, 0, 0); err != nil {
+		t.Errorf("Service.GetMyLoginHistory() error = %v", err)
+	}
+	mockLoginHistory.EXPECT().ListByUserID(gomock.Any(), userId, 2, maxLoginHistoryPageSize).Return(nil, int64(0), errors.New("any error"))
+	if _, _, err := s.GetMyLoginHistory(context.Background(), userId, 2, 1000); err == nil {
+		t.Errorf("Service.GetMyLoginHistory() error = nil, want error")
+	}
+}
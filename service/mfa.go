@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/tools"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	mfaIssuer              = "Mitra"
+	mfaChallengeExpiration = 5 * time.Minute
+	recoveryCodeCount      = 10
+)
+
+// EnrollMfa generates a new totp secret for the user. 2FA stays disabled until the
+// secret is confirmed with ConfirmMfa, enrolling again replaces a pending secret.
+func (s *Service) EnrollMfa(ctx context.Context, userId uuid.UUID) (*entity.MfaEnrollment, error) {
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsMfaEnabled {
+		return nil, mfaEnabledError()
+	}
+	if s.cipher == nil {
+		return nil, util.NewError(codes.Unavailable, pbErr.ErrorCode_UNKNOWN.String(), "2FA belum tersedia")
+	}
+
+	secret, err := tools.GenerateTotpSecret()
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	encrypted, err := s.cipher.Encrypt([]byte(secret))
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if err := s.mfaRepo.Save(ctx, &entity.UserMfa{UserId: user.Id, Secret: encrypted}); err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return &entity.MfaEnrollment{
+		Secret: secret,
+		Uri:    tools.TotpUri(mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMfa enables 2FA once the user proves the authenticator app produces codes for the
+// enrolled secret. The returned recovery codes are not stored in clear and cannot be shown again.
+func (s *Service) ConfirmMfa(ctx context.Context, userId uuid.UUID, code string) ([]string, error) {
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsMfaEnabled {
+		return nil, mfaEnabledError()
+	}
+	secret, err := s.mfaSecret(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !tools.ValidateTotp(secret, code, time.Now()) {
+		return nil, mfaInvalidError()
+	}
+
+	recoveryCodes, hashed, err := generateRecoveryCodes(user.Id)
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.EnableMfa(ctx, user.Id); err != nil {
+			return err
+		}
+		return s.mfaRepo.ReplaceRecoveryCodes(ctx, user.Id, hashed)
+	})
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditProfileChanged, user.Id, user.Id, map[string]string{"field": "mfa"})
+	return recoveryCodes, nil
+}
+
+// CreateMfaChallenge returns the token a user whose password was checked exchanges,
+// along with a second factor, for access tokens with VerifyMfa
+func (s *Service) CreateMfaChallenge(ctx context.Context, userId uuid.UUID) (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	token := base64.RawURLEncoding.EncodeToString(random)
+	if err := s.redis.Set(ctx, tools.MfaChallengeRedisPrefix+token, userId.String(), mfaChallengeExpiration); err != nil {
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return token, nil
+}
+
+// VerifyMfa completes the login started by CreateMfaChallenge with a totp code or an unused
+// recovery code. A challenge is dropped once used, a totp code is accepted once and, as for otps,
+// the user cannot complete any challenge for otpLockout after maxOtpAttempts wrong codes.
+func (s *Service) VerifyMfa(ctx context.Context, mfaToken string, code string) (*entity.User, error) {
+	challengeKey := tools.MfaChallengeRedisPrefix + mfaToken
+	storedId, err := s.redis.GetStringKey(ctx, challengeKey)
+	if err != nil {
+		return nil, mfaChallengeInvalidError()
+	}
+	userId, err := uuid.Parse(storedId)
+	if err != nil {
+		return nil, mfaChallengeInvalidError()
+	}
+	// counted per user, a new challenge only needs the password
+	attemptsKey := tools.MfaAttemptsRedisPrefix + userId.String()
+	attempts, err := s.redis.Incr(ctx, attemptsKey, otpLockout)
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if attempts > maxOtpAttempts {
+		if err := s.redis.Del(ctx, challengeKey); err != nil {
+			logger.FromContext(ctx).WithError(err).Warn("failed to drop mfa challenge after too many attempts")
+		}
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_OTP_TOO_MANY_ATTEMPTS.String()
+		ErrorMessage = "Terlalu banyak percobaan, silahkan coba lagi nanti"
+		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.mfaSecret(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if step, ok := tools.MatchTotp(secret, code, time.Now()); ok {
+		err := s.mfaRepo.UseTotpStep(ctx, user.Id, step)
+		if errors.Is(err, repository.ErrTotpReplayed) {
+			s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": "mfa_replayed"})
+			s.recordLoginAttempt(ctx, user, entity.LoginMethodPasswordMfa, "mfa_replayed")
+			return nil, mfaInvalidError()
+		}
+		if err != nil {
+			return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+		}
+	} else {
+		err := s.mfaRepo.UseRecoveryCode(ctx, user.Id, hashRecoveryCode(code))
+		if errors.Is(err, repository.ErrRecoveryCodeInvalid) {
+			s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": "mfa_invalid"})
+			s.recordLoginAttempt(ctx, user, entity.LoginMethodPasswordMfa, "mfa_invalid")
+			return nil, mfaInvalidError()
+		}
+		if err != nil {
+			return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+		}
+		logger.FromContext(ctx).WithField("user_id", user.Id.String()).Info("mfa recovery code used")
+	}
+	if err := checkUserActive(user); err != nil {
+		return nil, err
+	}
+
+	// a challenge that cannot be deleted could be replayed, refuse it
+	if err := s.redis.Del(ctx, challengeKey, attemptsKey); err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditLoginSucceeded, user.Id, user.Id, map[string]string{"method": entity.LoginMethodPasswordMfa})
+	s.recordLoginAttempt(ctx, user, entity.LoginMethodPasswordMfa, "")
+	return user, nil
+}
+
+// mfaSecret returns the decrypted totp secret of userId
+func (s *Service) mfaSecret(ctx context.Context, userId uuid.UUID) (string, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userId)
+	if err != nil {
+		if errors.Is(err, repository.ErrMfaNotFound) {
+			ErrorCode = codes.FailedPrecondition
+			ErrorCodeDetail = pbErr.ErrorCode_AUTH_MFA_NOT_ENROLLED.String()
+			ErrorMessage = "Silahkan lakukan pendaftaran 2FA terlebih dahulu"
+			return "", util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		}
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if s.cipher == nil {
+		return "", util.NewError(codes.Unavailable, pbErr.ErrorCode_UNKNOWN.String(), "2FA belum tersedia")
+	}
+	secret, err := s.cipher.Decrypt(mfa.Secret)
+	if err != nil {
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return string(secret), nil
+}
+
+// generateRecoveryCodes returns recoveryCodeCount codes formatted as xxxxx-xxxxx with their hashes
+func generateRecoveryCodes(userId uuid.UUID) ([]string, []*entity.MfaRecoveryCode, error) {
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	hashed := make([]*entity.MfaRecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		random := make([]byte, 8)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(random)[:10])
+		code = code[:5] + "-" + code[5:]
+		recoveryCodes = append(recoveryCodes, code)
+		hashed = append(hashed, &entity.MfaRecoveryCode{
+			UserId:   userId,
+			CodeHash: hashRecoveryCode(code),
+		})
+	}
+	return recoveryCodes, hashed, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely
+func hashRecoveryCode(code string) string {
+	code = strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func mfaEnabledError() error {
+	ErrorCode = codes.InvalidArgument
+	ErrorCodeDetail = pbErr.ErrorCode_AUTH_MFA_ALREADY_ENABLED.String()
+	ErrorMessage = "2FA sudah aktif"
+	return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+}
+
+func mfaInvalidError() error {
+	ErrorCode = codes.InvalidArgument
+	ErrorCodeDetail = pbErr.ErrorCode_AUTH_MFA_INVALID.String()
+	ErrorMessage = "Kode 2FA tidak sesuai"
+	return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+}
+
+func mfaChallengeInvalidError() error {
+	ErrorCode = codes.Unauthenticated
+	ErrorCodeDetail = pbErr.ErrorCode_AUTH_MFA_CHALLENGE_INVALID.String()
+	ErrorMessage = "Sesi login telah berakhir, silahkan login kembali"
+	return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+}
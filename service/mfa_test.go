@@ -0,0 +1,328 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/tools"
+	mockRedis "github.com/Mitra-Apps/be-user-service/config/tools/redis/mock"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func testCipher(t *testing.T) tools.CipherInterface {
+	cipher, err := tools.NewAesGcm(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cipher
+}
+
+func TestService_EnrollMfa(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockMfa := mock.NewMockMfa(ctrl)
+	cipher := testCipher(t)
+	userId := uuid.New()
+	user := &entity.User{Id: userId, Email: "test@mail.com"}
+	var saved *entity.UserMfa
+	tests := []struct {
+		name    string
+		s       *Service
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error mfa already enabled",
+			s:       &Service{userRepository: mockUser, mfaRepo: mockMfa, cipher: cipher},
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId, IsMfaEnabled: true}, nil)
+			},
+		},
+		{
+			name:    "error no encryption key",
+			s:       &Service{userRepository: mockUser, mfaRepo: mockMfa},
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(user, nil)
+			},
+		},
+		{
+			name:    "error save secret",
+			s:       &Service{userRepository: mockUser, mfaRepo: mockMfa, cipher: cipher},
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(user, nil)
+				mockMfa.EXPECT().Save(gomock.Any(), gomock.Any()).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			s:       &Service{userRepository: mockUser, mfaRepo: mockMfa, cipher: cipher},
+			wantErr: false,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(user, nil)
+				mockMfa.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, mfa *entity.UserMfa) error {
+					saved = mfa
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := tt.s.EnrollMfa(context.Background(), userId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.EnrollMfa() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Secret == "" || !regexp.MustCompile(`^otpauth://totp/Mitra:test@mail.com\?.*secret=`+got.Secret).MatchString(got.Uri) {
+				t.Errorf("Service.EnrollMfa() = %v, want otpauth uri of the secret", got)
+			}
+			if saved == nil || saved.UserId != userId || saved.Secret == got.Secret {
+				t.Errorf("Service.EnrollMfa() saved %v, want the encrypted secret of user %v", saved, userId)
+			}
+		})
+	}
+}
+
+func TestService_ConfirmMfa(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockMfa := mock.NewMockMfa(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	cipher := testCipher(t)
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		mfaRepo:        mockMfa,
+		transactor:     mockTransactor,
+		cipher:         cipher,
+	}
+	userId := uuid.New()
+	secret, _ := tools.GenerateTotpSecret()
+	encrypted, _ := cipher.Encrypt([]byte(secret))
+	code, _ := tools.GenerateTotp(secret, time.Now())
+	inTransaction := func() {
+		mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error not enrolled",
+			code:    code,
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId}, nil)
+				mockMfa.EXPECT().GetByUserID(gomock.Any(), userId).Return(nil, repository.ErrMfaNotFound)
+			},
+		},
+		{
+			name:    "error wrong code",
+			code:    "000000",
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId}, nil)
+				mockMfa.EXPECT().GetByUserID(gomock.Any(), userId).Return(&entity.UserMfa{UserId: userId, Secret: encrypted}, nil)
+			},
+		},
+		{
+			name:    "error enable mfa",
+			code:    code,
+			wantErr: true,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId}, nil)
+				mockMfa.EXPECT().GetByUserID(gomock.Any(), userId).Return(&entity.UserMfa{UserId: userId, Secret: encrypted}, nil)
+				inTransaction()
+				mockUser.EXPECT().EnableMfa(gomock.Any(), userId).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:    "success",
+			code:    code,
+			wantErr: false,
+			mocks: func() {
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId}, nil)
+				mockMfa.EXPECT().GetByUserID(gomock.Any(), userId).Return(&entity.UserMfa{UserId: userId, Secret: encrypted}, nil)
+				inTransaction()
+				mockUser.EXPECT().EnableMfa(gomock.Any(), userId).Return(nil)
+				mockMfa.EXPECT().ReplaceRecoveryCodes(gomock.Any(), userId, gomock.Len(recoveryCodeCount)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.ConfirmMfa(context.Background(), userId, tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.ConfirmMfa() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != recoveryCodeCount {
+				t.Errorf("Service.ConfirmMfa() = %v recovery codes, want %v", len(got), recoveryCodeCount)
+			}
+		})
+	}
+}
+
+func TestService_VerifyMfa(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockMfa := mock.NewMockMfa(ctrl)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	redisRecord := redis.EXPECT()
+	cipher := testCipher(t)
+	s := &Service{
+		auditRepo:        newMockAudit(ctrl),
+		loginHistoryRepo: newMockLoginHistory(ctrl),
+		userRepository:   mockUser,
+		mfaRepo:          mockMfa,
+		redis:            redis,
+		cipher:           cipher,
+	}
+	userId := uuid.New()
+	secret, _ := tools.GenerateTotpSecret()
+	encrypted, _ := cipher.Encrypt([]byte(secret))
+	code, _ := tools.GenerateTotp(secret, time.Now())
+	step, _ := tools.MatchTotp(secret, code, time.Now())
+	challengeKey := "mfa-challenge:token"
+	attemptsKey := "mfa-attempts:" + userId.String()
+	challenge := func(attempts int64) {
+		redisRecord.GetStringKey(gomock.Any(), challengeKey).Return(userId.String(), nil)
+		redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(attempts, nil)
+	}
+	enrolled := func() {
+		mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId, IsActive: true, IsMfaEnabled: true}, nil)
+		mockMfa.EXPECT().GetByUserID(gomock.Any(), userId).Return(&entity.UserMfa{UserId: userId, Secret: encrypted}, nil)
+	}
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error expired challenge",
+			code:    code,
+			wantErr: true,
+			mocks: func() {
+				redisRecord.GetStringKey(gomock.Any(), challengeKey).Return("", errors.New("redis: nil"))
+			},
+		},
+		{
+			name:    "error too many attempts",
+			code:    code,
+			wantErr: true,
+			mocks: func() {
+				challenge(maxOtpAttempts + 1)
+				redisRecord.Del(gomock.Any(), challengeKey).Return(nil)
+			},
+		},
+		{
+			name:    "error wrong code",
+			code:    "000000",
+			wantErr: true,
+			mocks: func() {
+				challenge(1)
+				enrolled()
+				mockMfa.EXPECT().UseRecoveryCode(gomock.Any(), userId, hashRecoveryCode("000000")).Return(repository.ErrRecoveryCodeInvalid)
+			},
+		},
+		{
+			name:    "success with recovery code",
+			code:    "ABCDE-fghij",
+			wantErr: false,
+			mocks: func() {
+				challenge(1)
+				enrolled()
+				mockMfa.EXPECT().UseRecoveryCode(gomock.Any(), userId, hashRecoveryCode("abcdefghij")).Return(nil)
+				redisRecord.Del(gomock.Any(), challengeKey, attemptsKey).Return(nil)
+			},
+		},
+		{
+			name:    "error replayed totp",
+			code:    code,
+			wantErr: true,
+			mocks: func() {
+				challenge(1)
+				enrolled()
+				mockMfa.EXPECT().UseTotpStep(gomock.Any(), userId, step).Return(repository.ErrTotpReplayed)
+			},
+		},
+		{
+			name:    "error saving the totp step",
+			code:    code,
+			wantErr: true,
+			mocks: func() {
+				challenge(1)
+				enrolled()
+				mockMfa.EXPECT().UseTotpStep(gomock.Any(), userId, step).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:    "success with totp",
+			code:    code,
+			wantErr: false,
+			mocks: func() {
+				challenge(2)
+				enrolled()
+				mockMfa.EXPECT().UseTotpStep(gomock.Any(), userId, step).Return(nil)
+				redisRecord.Del(gomock.Any(), challengeKey, attemptsKey).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.VerifyMfa(context.Background(), "token", tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.VerifyMfa() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Id != userId {
+				t.Errorf("Service.VerifyMfa() = %v, want user %v", got.Id, userId)
+			}
+		})
+	}
+}
+
+func TestService_CreateMfaChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	s := &Service{redis: redis}
+	userId := uuid.New()
+
+	redis.EXPECT().Set(gomock.Any(), gomock.Any(), userId.String(), mfaChallengeExpiration).Return(nil).Times(2)
+	first, err := s.CreateMfaChallenge(context.Background(), userId)
+	if err != nil {
+		t.Fatalf("Service.CreateMfaChallenge() error = %v", err)
+	}
+	second, _ := s.CreateMfaChallenge(context.Background(), userId)
+	if len(first) < 40 || first == second {
+		t.Errorf("Service.CreateMfaChallenge() = %v, %v, want distinct random tokens", first, second)
+	}
+
+	redis.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("any error"))
+	if _, err := s.CreateMfaChallenge(context.Background(), userId); err == nil {
+		t.Errorf("Service.CreateMfaChallenge() error = nil, want error")
+	}
+}
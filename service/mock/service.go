@@ -15,6 +15,7 @@ import (
 
 	user "github.com/Mitra-Apps/be-user-service/domain/proto/user"
 	entity "github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +42,51 @@ func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AuthenticateAccessToken mocks base method.
+func (m *MockServiceInterface) AuthenticateAccessToken(ctx context.Context, token string) (*entity.TokenIntrospection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAccessToken", ctx, token)
+	ret0, _ := ret[0].(*entity.TokenIntrospection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAccessToken indicates an expected call of AuthenticateAccessToken.
+func (mr *MockServiceInterfaceMockRecorder) AuthenticateAccessToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAccessToken", reflect.TypeOf((*MockServiceInterface)(nil).AuthenticateAccessToken), ctx, token)
+}
+
+// AuthenticateOAuthClient mocks base method.
+func (m *MockServiceInterface) AuthenticateOAuthClient(ctx context.Context, clientId, clientSecret string) (*entity.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateOAuthClient", ctx, clientId, clientSecret)
+	ret0, _ := ret[0].(*entity.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateOAuthClient indicates an expected call of AuthenticateOAuthClient.
+func (mr *MockServiceInterfaceMockRecorder) AuthenticateOAuthClient(ctx, clientId, clientSecret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateOAuthClient", reflect.TypeOf((*MockServiceInterface)(nil).AuthenticateOAuthClient), ctx, clientId, clientSecret)
+}
+
+// AuthenticateServiceClient mocks base method.
+func (m *MockServiceInterface) AuthenticateServiceClient(ctx context.Context, apiKey string) (*entity.ServiceClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateServiceClient", ctx, apiKey)
+	ret0, _ := ret[0].(*entity.ServiceClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateServiceClient indicates an expected call of AuthenticateServiceClient.
+func (mr *MockServiceInterfaceMockRecorder) AuthenticateServiceClient(ctx, apiKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateServiceClient", reflect.TypeOf((*MockServiceInterface)(nil).AuthenticateServiceClient), ctx, apiKey)
+}
+
 // ChangePassword mocks base method.
 func (m *MockServiceInterface) ChangePassword(ctx context.Context, req *user.ChangePasswordRequest) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +102,125 @@ func (mr *MockServiceInterfaceMockRecorder) ChangePassword(ctx, req any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockServiceInterface)(nil).ChangePassword), ctx, req)
 }
 
+// CheckSessionActive mocks base method.
+func (m *MockServiceInterface) CheckSessionActive(ctx context.Context, userId, sessionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSessionActive", ctx, userId, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSessionActive indicates an expected call of CheckSessionActive.
+func (mr *MockServiceInterfaceMockRecorder) CheckSessionActive(ctx, userId, sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSessionActive", reflect.TypeOf((*MockServiceInterface)(nil).CheckSessionActive), ctx, userId, sessionId)
+}
+
+// CheckUserActive mocks base method.
+func (m *MockServiceInterface) CheckUserActive(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUserActive", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckUserActive indicates an expected call of CheckUserActive.
+func (mr *MockServiceInterfaceMockRecorder) CheckUserActive(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserActive", reflect.TypeOf((*MockServiceInterface)(nil).CheckUserActive), ctx, userId)
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockServiceInterface) ConfirmEmailChange(ctx context.Context, userId uuid.UUID, otp int) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, userId, otp)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockServiceInterfaceMockRecorder) ConfirmEmailChange(ctx, userId, otp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockServiceInterface)(nil).ConfirmEmailChange), ctx, userId, otp)
+}
+
+// ConfirmMfa mocks base method.
+func (m *MockServiceInterface) ConfirmMfa(ctx context.Context, userId uuid.UUID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMfa", ctx, userId, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmMfa indicates an expected call of ConfirmMfa.
+func (mr *MockServiceInterfaceMockRecorder) ConfirmMfa(ctx, userId, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMfa", reflect.TypeOf((*MockServiceInterface)(nil).ConfirmMfa), ctx, userId, code)
+}
+
+// CreateAuthorizationCode mocks base method.
+func (m *MockServiceInterface) CreateAuthorizationCode(ctx context.Context, authorization *entity.AuthorizationCode) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthorizationCode", ctx, authorization)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthorizationCode indicates an expected call of CreateAuthorizationCode.
+func (mr *MockServiceInterfaceMockRecorder) CreateAuthorizationCode(ctx, authorization any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthorizationCode", reflect.TypeOf((*MockServiceInterface)(nil).CreateAuthorizationCode), ctx, authorization)
+}
+
+// CreateMfaChallenge mocks base method.
+func (m *MockServiceInterface) CreateMfaChallenge(ctx context.Context, userId uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMfaChallenge", ctx, userId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMfaChallenge indicates an expected call of CreateMfaChallenge.
+func (mr *MockServiceInterfaceMockRecorder) CreateMfaChallenge(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMfaChallenge", reflect.TypeOf((*MockServiceInterface)(nil).CreateMfaChallenge), ctx, userId)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockServiceInterface) CreateOAuthClient(ctx context.Context, adminId uuid.UUID, name string, redirectUris []string, confidential bool) (*entity.OAuthClient, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", ctx, adminId, name, redirectUris, confidential)
+	ret0, _ := ret[0].(*entity.OAuthClient)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockServiceInterfaceMockRecorder) CreateOAuthClient(ctx, adminId, name, redirectUris, confidential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockServiceInterface)(nil).CreateOAuthClient), ctx, adminId, name, redirectUris, confidential)
+}
+
+// CreateOAuthSession mocks base method.
+func (m *MockServiceInterface) CreateOAuthSession(ctx context.Context, userId uuid.UUID, clientId string, device entity.Device) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthSession", ctx, userId, clientId, device)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOAuthSession indicates an expected call of CreateOAuthSession.
+func (mr *MockServiceInterfaceMockRecorder) CreateOAuthSession(ctx, userId, clientId, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthSession", reflect.TypeOf((*MockServiceInterface)(nil).CreateOAuthSession), ctx, userId, clientId, device)
+}
+
 // CreateRole mocks base method.
 func (m *MockServiceInterface) CreateRole(ctx context.Context, role *entity.Role) error {
 	m.ctrl.T.Helper()
@@ -70,6 +235,110 @@ func (mr *MockServiceInterfaceMockRecorder) CreateRole(ctx, role any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockServiceInterface)(nil).CreateRole), ctx, role)
 }
 
+// CreateServiceClient mocks base method.
+func (m *MockServiceInterface) CreateServiceClient(ctx context.Context, adminId uuid.UUID, name string, scopes []string) (*entity.ServiceClient, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceClient", ctx, adminId, name, scopes)
+	ret0, _ := ret[0].(*entity.ServiceClient)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateServiceClient indicates an expected call of CreateServiceClient.
+func (mr *MockServiceInterfaceMockRecorder) CreateServiceClient(ctx, adminId, name, scopes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceClient", reflect.TypeOf((*MockServiceInterface)(nil).CreateServiceClient), ctx, adminId, name, scopes)
+}
+
+// CreateSession mocks base method.
+func (m *MockServiceInterface) CreateSession(ctx context.Context, userId uuid.UUID, device entity.Device) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, userId, device)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockServiceInterfaceMockRecorder) CreateSession(ctx, userId, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockServiceInterface)(nil).CreateSession), ctx, userId, device)
+}
+
+// DeactivateUser mocks base method.
+func (m *MockServiceInterface) DeactivateUser(ctx context.Context, adminId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", ctx, adminId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockServiceInterfaceMockRecorder) DeactivateUser(ctx, adminId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockServiceInterface)(nil).DeactivateUser), ctx, adminId, userId)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockServiceInterface) DeleteAccount(ctx context.Context, userId uuid.UUID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userId, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockServiceInterfaceMockRecorder) DeleteAccount(ctx, userId, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockServiceInterface)(nil).DeleteAccount), ctx, userId, password)
+}
+
+// EnrollMfa mocks base method.
+func (m *MockServiceInterface) EnrollMfa(ctx context.Context, userId uuid.UUID) (*entity.MfaEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMfa", ctx, userId)
+	ret0, _ := ret[0].(*entity.MfaEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMfa indicates an expected call of EnrollMfa.
+func (mr *MockServiceInterfaceMockRecorder) EnrollMfa(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMfa", reflect.TypeOf((*MockServiceInterface)(nil).EnrollMfa), ctx, userId)
+}
+
+// ExchangeAuthorizationCode mocks base method.
+func (m *MockServiceInterface) ExchangeAuthorizationCode(ctx context.Context, clientId, code, redirectUri, codeVerifier string) (*entity.AuthorizationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeAuthorizationCode", ctx, clientId, code, redirectUri, codeVerifier)
+	ret0, _ := ret[0].(*entity.AuthorizationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeAuthorizationCode indicates an expected call of ExchangeAuthorizationCode.
+func (mr *MockServiceInterfaceMockRecorder) ExchangeAuthorizationCode(ctx, clientId, code, redirectUri, codeVerifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeAuthorizationCode", reflect.TypeOf((*MockServiceInterface)(nil).ExchangeAuthorizationCode), ctx, clientId, code, redirectUri, codeVerifier)
+}
+
+// ExportUserData mocks base method.
+func (m *MockServiceInterface) ExportUserData(ctx context.Context, userId uuid.UUID) (*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserData", ctx, userId)
+	ret0, _ := ret[0].(*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *MockServiceInterfaceMockRecorder) ExportUserData(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*MockServiceInterface)(nil).ExportUserData), ctx, userId)
+}
+
 // GetAll mocks base method.
 func (m *MockServiceInterface) GetAll(ctx context.Context) ([]*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +354,37 @@ func (mr *MockServiceInterfaceMockRecorder) GetAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockServiceInterface)(nil).GetAll), ctx)
 }
 
+// GetMyLoginHistory mocks base method.
+func (m *MockServiceInterface) GetMyLoginHistory(ctx context.Context, userId uuid.UUID, page, pageSize int) ([]*entity.LoginAttempt, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyLoginHistory", ctx, userId, page, pageSize)
+	ret0, _ := ret[0].([]*entity.LoginAttempt)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMyLoginHistory indicates an expected call of GetMyLoginHistory.
+func (mr *MockServiceInterfaceMockRecorder) GetMyLoginHistory(ctx, userId, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyLoginHistory", reflect.TypeOf((*MockServiceInterface)(nil).GetMyLoginHistory), ctx, userId, page, pageSize)
+}
+
+// GetOAuthClient mocks base method.
+func (m *MockServiceInterface) GetOAuthClient(ctx context.Context, clientId string) (*entity.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClient", ctx, clientId)
+	ret0, _ := ret[0].(*entity.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClient indicates an expected call of GetOAuthClient.
+func (mr *MockServiceInterfaceMockRecorder) GetOAuthClient(ctx, clientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClient", reflect.TypeOf((*MockServiceInterface)(nil).GetOAuthClient), ctx, clientId)
+}
+
 // GetRole mocks base method.
 func (m *MockServiceInterface) GetRole(ctx context.Context) ([]entity.Role, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +400,112 @@ func (mr *MockServiceInterfaceMockRecorder) GetRole(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockServiceInterface)(nil).GetRole), ctx)
 }
 
+// GetUser mocks base method.
+func (m *MockServiceInterface) GetUser(ctx context.Context, userId uuid.UUID) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userId)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockServiceInterfaceMockRecorder) GetUser(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockServiceInterface)(nil).GetUser), ctx, userId)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockServiceInterface) GetUserByEmail(ctx context.Context, email string) (*entity.UserSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*entity.UserSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockServiceInterfaceMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockServiceInterface)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUsersByIds mocks base method.
+func (m *MockServiceInterface) GetUsersByIds(ctx context.Context, ids []uuid.UUID) ([]*entity.UserSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIds", ctx, ids)
+	ret0, _ := ret[0].([]*entity.UserSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIds indicates an expected call of GetUsersByIds.
+func (mr *MockServiceInterfaceMockRecorder) GetUsersByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIds", reflect.TypeOf((*MockServiceInterface)(nil).GetUsersByIds), ctx, ids)
+}
+
+// IntrospectToken mocks base method.
+func (m *MockServiceInterface) IntrospectToken(ctx context.Context, token string) (*entity.TokenIntrospection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntrospectToken", ctx, token)
+	ret0, _ := ret[0].(*entity.TokenIntrospection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntrospectToken indicates an expected call of IntrospectToken.
+func (mr *MockServiceInterfaceMockRecorder) IntrospectToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntrospectToken", reflect.TypeOf((*MockServiceInterface)(nil).IntrospectToken), ctx, token)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockServiceInterface) ListAuditEvents(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", ctx, filter)
+	ret0, _ := ret[0].([]*entity.AuditEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockServiceInterfaceMockRecorder) ListAuditEvents(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockServiceInterface)(nil).ListAuditEvents), ctx, filter)
+}
+
+// ListServiceClients mocks base method.
+func (m *MockServiceInterface) ListServiceClients(ctx context.Context) ([]*entity.ServiceClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceClients", ctx)
+	ret0, _ := ret[0].([]*entity.ServiceClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServiceClients indicates an expected call of ListServiceClients.
+func (mr *MockServiceInterfaceMockRecorder) ListServiceClients(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceClients", reflect.TypeOf((*MockServiceInterface)(nil).ListServiceClients), ctx)
+}
+
+// ListSessions mocks base method.
+func (m *MockServiceInterface) ListSessions(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userId)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockServiceInterfaceMockRecorder) ListSessions(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockServiceInterface)(nil).ListSessions), ctx, userId)
+}
+
 // Login mocks base method.
 func (m *MockServiceInterface) Login(ctx context.Context, payload entity.LoginRequest) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -115,34 +521,221 @@ func (mr *MockServiceInterfaceMockRecorder) Login(ctx, payload any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockServiceInterface)(nil).Login), ctx, payload)
 }
 
-// Register mocks base method.
-func (m *MockServiceInterface) Register(ctx context.Context, req *user.UserRegisterRequest) (*entity.OtpMailReq, error) {
+// LoginWithGoogle mocks base method.
+func (m *MockServiceInterface) LoginWithGoogle(ctx context.Context, idToken string) (*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", ctx, req)
-	ret0, _ := ret[0].(*entity.OtpMailReq)
+	ret := m.ctrl.Call(m, "LoginWithGoogle", ctx, idToken)
+	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// LoginWithGoogle indicates an expected call of LoginWithGoogle.
+func (mr *MockServiceInterfaceMockRecorder) LoginWithGoogle(ctx, idToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithGoogle", reflect.TypeOf((*MockServiceInterface)(nil).LoginWithGoogle), ctx, idToken)
+}
+
+// LoginWithPhoneOtp mocks base method.
+func (m *MockServiceInterface) LoginWithPhoneOtp(ctx context.Context, phoneNumber string, otp int) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginWithPhoneOtp", ctx, phoneNumber, otp)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginWithPhoneOtp indicates an expected call of LoginWithPhoneOtp.
+func (mr *MockServiceInterfaceMockRecorder) LoginWithPhoneOtp(ctx, phoneNumber, otp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithPhoneOtp", reflect.TypeOf((*MockServiceInterface)(nil).LoginWithPhoneOtp), ctx, phoneNumber, otp)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockServiceInterface) ReactivateUser(ctx context.Context, adminId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", ctx, adminId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockServiceInterfaceMockRecorder) ReactivateUser(ctx, adminId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockServiceInterface)(nil).ReactivateUser), ctx, adminId, userId)
+}
+
+// RefreshSession mocks base method.
+func (m *MockServiceInterface) RefreshSession(ctx context.Context, sessionId, refreshTokenId uuid.UUID, clientId string) (*entity.User, *entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, sessionId, refreshTokenId, clientId)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(*entity.Session)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockServiceInterfaceMockRecorder) RefreshSession(ctx, sessionId, refreshTokenId, clientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockServiceInterface)(nil).RefreshSession), ctx, sessionId, refreshTokenId, clientId)
+}
+
+// Register mocks base method.
+func (m *MockServiceInterface) Register(ctx context.Context, req *user.UserRegisterRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // Register indicates an expected call of Register.
 func (mr *MockServiceInterfaceMockRecorder) Register(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockServiceInterface)(nil).Register), ctx, req)
 }
 
+// RequestEmailChange mocks base method.
+func (m *MockServiceInterface) RequestEmailChange(ctx context.Context, userId uuid.UUID, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailChange", ctx, userId, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockServiceInterfaceMockRecorder) RequestEmailChange(ctx, userId, newEmail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockServiceInterface)(nil).RequestEmailChange), ctx, userId, newEmail)
+}
+
+// RequestPhoneLoginOtp mocks base method.
+func (m *MockServiceInterface) RequestPhoneLoginOtp(ctx context.Context, phoneNumber, channel string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPhoneLoginOtp", ctx, phoneNumber, channel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPhoneLoginOtp indicates an expected call of RequestPhoneLoginOtp.
+func (mr *MockServiceInterfaceMockRecorder) RequestPhoneLoginOtp(ctx, phoneNumber, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPhoneLoginOtp", reflect.TypeOf((*MockServiceInterface)(nil).RequestPhoneLoginOtp), ctx, phoneNumber, channel)
+}
+
 // ResendOTP mocks base method.
-func (m *MockServiceInterface) ResendOTP(ctx context.Context, email string) (*entity.OtpMailReq, error) {
+func (m *MockServiceInterface) ResendOTP(ctx context.Context, email, channel string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendOTP", ctx, email, channel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendOTP indicates an expected call of ResendOTP.
+func (mr *MockServiceInterfaceMockRecorder) ResendOTP(ctx, email, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendOTP", reflect.TypeOf((*MockServiceInterface)(nil).ResendOTP), ctx, email, channel)
+}
+
+// RevokeServiceClient mocks base method.
+func (m *MockServiceInterface) RevokeServiceClient(ctx context.Context, adminId, clientId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeServiceClient", ctx, adminId, clientId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeServiceClient indicates an expected call of RevokeServiceClient.
+func (mr *MockServiceInterfaceMockRecorder) RevokeServiceClient(ctx, adminId, clientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeServiceClient", reflect.TypeOf((*MockServiceInterface)(nil).RevokeServiceClient), ctx, adminId, clientId)
+}
+
+// RevokeSession mocks base method.
+func (m *MockServiceInterface) RevokeSession(ctx context.Context, userId, sessionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userId, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockServiceInterfaceMockRecorder) RevokeSession(ctx, userId, sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockServiceInterface)(nil).RevokeSession), ctx, userId, sessionId)
+}
+
+// SendPhoneVerificationOtp mocks base method.
+func (m *MockServiceInterface) SendPhoneVerificationOtp(ctx context.Context, userId uuid.UUID, channel string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPhoneVerificationOtp", ctx, userId, channel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPhoneVerificationOtp indicates an expected call of SendPhoneVerificationOtp.
+func (mr *MockServiceInterfaceMockRecorder) SendPhoneVerificationOtp(ctx, userId, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPhoneVerificationOtp", reflect.TypeOf((*MockServiceInterface)(nil).SendPhoneVerificationOtp), ctx, userId, channel)
+}
+
+// SetUserRoles mocks base method.
+func (m *MockServiceInterface) SetUserRoles(ctx context.Context, adminId, userId uuid.UUID, roleIds []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResendOTP", ctx, email)
-	ret0, _ := ret[0].(*entity.OtpMailReq)
+	ret := m.ctrl.Call(m, "SetUserRoles", ctx, adminId, userId, roleIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRoles indicates an expected call of SetUserRoles.
+func (mr *MockServiceInterfaceMockRecorder) SetUserRoles(ctx, adminId, userId, roleIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRoles", reflect.TypeOf((*MockServiceInterface)(nil).SetUserRoles), ctx, adminId, userId, roleIds)
+}
+
+// StartImpersonation mocks base method.
+func (m *MockServiceInterface) StartImpersonation(ctx context.Context, adminId, userId uuid.UUID, reason string, device entity.Device) (*entity.User, *entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImpersonation", ctx, adminId, userId, reason, device)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(*entity.Session)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StartImpersonation indicates an expected call of StartImpersonation.
+func (mr *MockServiceInterfaceMockRecorder) StartImpersonation(ctx, adminId, userId, reason, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImpersonation", reflect.TypeOf((*MockServiceInterface)(nil).StartImpersonation), ctx, adminId, userId, reason, device)
+}
+
+// StopImpersonation mocks base method.
+func (m *MockServiceInterface) StopImpersonation(ctx context.Context, adminId, userId, sessionId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopImpersonation", ctx, adminId, userId, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopImpersonation indicates an expected call of StopImpersonation.
+func (mr *MockServiceInterfaceMockRecorder) StopImpersonation(ctx, adminId, userId, sessionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopImpersonation", reflect.TypeOf((*MockServiceInterface)(nil).StopImpersonation), ctx, adminId, userId, sessionId)
+}
+
+// VerifyMfa mocks base method.
+func (m *MockServiceInterface) VerifyMfa(ctx context.Context, mfaToken, code string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMfa", ctx, mfaToken, code)
+	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ResendOTP indicates an expected call of ResendOTP.
-func (mr *MockServiceInterfaceMockRecorder) ResendOTP(ctx, email any) *gomock.Call {
+// VerifyMfa indicates an expected call of VerifyMfa.
+func (mr *MockServiceInterfaceMockRecorder) VerifyMfa(ctx, mfaToken, code any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendOTP", reflect.TypeOf((*MockServiceInterface)(nil).ResendOTP), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMfa", reflect.TypeOf((*MockServiceInterface)(nil).VerifyMfa), ctx, mfaToken, code)
 }
 
 // VerifyOTP mocks base method.
@@ -159,3 +752,18 @@ func (mr *MockServiceInterfaceMockRecorder) VerifyOTP(ctx, otp, redisKey any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyOTP", reflect.TypeOf((*MockServiceInterface)(nil).VerifyOTP), ctx, otp, redisKey)
 }
+
+// VerifyPhone mocks base method.
+func (m *MockServiceInterface) VerifyPhone(ctx context.Context, userId uuid.UUID, otp int) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPhone", ctx, userId, otp)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPhone indicates an expected call of VerifyPhone.
+func (mr *MockServiceInterfaceMockRecorder) VerifyPhone(ctx, userId, otp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPhone", reflect.TypeOf((*MockServiceInterface)(nil).VerifyPhone), ctx, userId, otp)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+)
+
+// Notifier delivers otp codes and account notices to a user through one channel
+type Notifier interface {
+	SendOtp(ctx context.Context, otp *entity.OtpNotification) error
+	SendNotice(ctx context.Context, notice *entity.Notice) error
+}
+
+// Notifiers holds the notifier configured for each otp channel
+type Notifiers map[string]Notifier
@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	utilPb "github.com/Mitra-Apps/be-utility-service/domain/proto/utility"
+)
+
+// ErrNoticeUnavailable is returned when an email notice is sent without a mail gateway
+var ErrNoticeUnavailable = permanentError("no mail gateway configured for notices")
+
+type Email struct {
+	mail    utilPb.MailServiceClient
+	notices *HTTP
+}
+
+// NewEmail sends otp codes with the mail service of the utility service. The utility
+// service only has otp mails, account notices go through the notices gateway, which may be nil.
+func NewEmail(mail utilPb.MailServiceClient, notices *HTTP) *Email {
+	return &Email{
+		mail:    mail,
+		notices: notices,
+	}
+}
+
+func (e *Email) SendOtp(ctx context.Context, otp *entity.OtpNotification) error {
+	_, err := e.mail.SendOtpMail(ctx, &utilPb.OtpMailReq{
+		Name:    otp.Name,
+		Email:   otp.Email,
+		OtpCode: int32(otp.OtpCode),
+	})
+	return err
+}
+
+func (e *Email) SendNotice(ctx context.Context, notice *entity.Notice) error {
+	if e.notices == nil {
+		return ErrNoticeUnavailable
+	}
+	return e.notices.SendNotice(ctx, notice)
+}
+
+// permanentError is a delivery failure the outbox dispatcher should not retry
+type permanentError string
+
+func (e permanentError) Error() string {
+	return string(e)
+}
+
+func (e permanentError) Permanent() bool {
+	return true
+}
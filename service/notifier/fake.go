@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+)
+
+// Fake keeps otp codes and notices in memory instead of sending them, for tests and local runs
+type Fake struct {
+	mu      sync.Mutex
+	err     error
+	sent    []*entity.OtpNotification
+	notices []*entity.Notice
+}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+// FailWith makes the next sends return err, nil restores successful sends
+func (f *Fake) FailWith(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *Fake) SendOtp(ctx context.Context, otp *entity.OtpNotification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, otp)
+	return nil
+}
+
+func (f *Fake) SendNotice(ctx context.Context, notice *entity.Notice) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.notices = append(f.notices, notice)
+	return nil
+}
+
+// Notices returns the notices sent so far
+func (f *Fake) Notices() []*entity.Notice {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*entity.Notice(nil), f.notices...)
+}
+
+// Sent returns the otp codes sent so far
+func (f *Fake) Sent() []*entity.OtpNotification {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*entity.OtpNotification(nil), f.sent...)
+}
+
+// Last returns the last otp sent to recipient, matched on email or phone number
+func (f *Fake) Last(recipient string) *entity.OtpNotification {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.sent) - 1; i >= 0; i-- {
+		if f.sent[i].Email == recipient || f.sent[i].PhoneNumber == recipient {
+			return f.sent[i]
+		}
+	}
+	return nil
+}
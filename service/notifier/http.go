@@ -0,0 +1,121 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+)
+
+const otpMessage = "Kode OTP Mitra anda %04d, berlaku 5 menit. Jangan berikan kode ini kepada siapapun."
+
+// HTTP sends otp codes and notices as text messages through a provider gateway. The gateway
+// receives a json body {"channel", "to", "subject", "message"} authenticated with a bearer token,
+// which fits the sms, whatsapp and mail gateways we use once fronted by their http api.
+type HTTP struct {
+	client  *http.Client
+	url     string
+	token   string
+	channel string
+}
+
+// NewSms sends otp codes by sms through the gateway at url
+func NewSms(client *http.Client, url, token string) *HTTP {
+	return newHTTP(client, url, token, entity.OtpChannelSms)
+}
+
+// NewWhatsapp sends otp codes by whatsapp through the gateway at url
+func NewWhatsapp(client *http.Client, url, token string) *HTTP {
+	return newHTTP(client, url, token, entity.OtpChannelWhatsapp)
+}
+
+// NewMail sends messages by email through the mail gateway at url
+func NewMail(client *http.Client, url, token string) *HTTP {
+	return newHTTP(client, url, token, entity.OtpChannelEmail)
+}
+
+func newHTTP(client *http.Client, url, token, channel string) *HTTP {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTP{
+		client:  client,
+		url:     url,
+		token:   token,
+		channel: channel,
+	}
+}
+
+type textMessage struct {
+	Channel string `json:"channel"`
+	To      string `json:"to"`
+	Subject string `json:"subject,omitempty"`
+	Message string `json:"message"`
+}
+
+func (h *HTTP) SendOtp(ctx context.Context, otp *entity.OtpNotification) error {
+	return h.send(ctx, &textMessage{
+		Channel: h.channel,
+		To:      h.recipient(otp.Email, otp.PhoneNumber),
+		Message: fmt.Sprintf(otpMessage, otp.OtpCode),
+	})
+}
+
+func (h *HTTP) SendNotice(ctx context.Context, notice *entity.Notice) error {
+	return h.send(ctx, &textMessage{
+		Channel: h.channel,
+		To:      h.recipient(notice.Email, notice.PhoneNumber),
+		Subject: notice.Subject,
+		Message: notice.Message,
+	})
+}
+
+func (h *HTTP) recipient(email, phoneNumber string) string {
+	if h.channel == entity.OtpChannelEmail {
+		return email
+	}
+	return InternationalPhoneNumber(phoneNumber)
+}
+
+func (h *HTTP) send(ctx context.Context, message *textMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+h.token)
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("%s gateway returned %d: %s", h.channel, res.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// InternationalPhoneNumber converts local indonesian numbers such as 0812... to +62812...
+func InternationalPhoneNumber(phoneNumber string) string {
+	phoneNumber = strings.TrimSpace(phoneNumber)
+	switch {
+	case strings.HasPrefix(phoneNumber, "+"):
+		return phoneNumber
+	case strings.HasPrefix(phoneNumber, "62"):
+		return "+" + phoneNumber
+	case strings.HasPrefix(phoneNumber, "0"):
+		return "+62" + phoneNumber[1:]
+	}
+	return phoneNumber
+}
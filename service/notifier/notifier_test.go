@@ -0,0 +1,163 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	utilPb "github.com/Mitra-Apps/be-utility-service/domain/proto/utility"
+	"google.golang.org/grpc"
+)
+
+type fakeMailClient struct {
+	utilPb.MailServiceClient
+	err  error
+	sent *utilPb.OtpMailReq
+}
+
+func (f *fakeMailClient) SendOtpMail(ctx context.Context, in *utilPb.OtpMailReq, opts ...grpc.CallOption) (*utilPb.SuccessResponse, error) {
+	f.sent = in
+	return &utilPb.SuccessResponse{}, f.err
+}
+
+func TestEmail_SendOtp(t *testing.T) {
+	otp := &entity.OtpNotification{
+		Channel: entity.OtpChannelEmail,
+		Name:    "test",
+		Email:   "test@mail.com",
+		OtpCode: 1234,
+	}
+	tests := []struct {
+		name    string
+		mailErr error
+		wantErr bool
+	}{
+		{
+			name:    "error mail service",
+			mailErr: errors.New("unavailable"),
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mail := &fakeMailClient{err: tt.mailErr}
+			if err := NewEmail(mail, nil).SendOtp(context.Background(), otp); (err != nil) != tt.wantErr {
+				t.Errorf("Email.SendOtp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if mail.sent == nil || mail.sent.Email != otp.Email || mail.sent.OtpCode != 1234 {
+				t.Errorf("Email.SendOtp() sent = %v, want otp 1234 to %v", mail.sent, otp.Email)
+			}
+		})
+	}
+}
+
+func TestHTTP_SendOtp(t *testing.T) {
+	otp := &entity.OtpNotification{
+		Channel:     entity.OtpChannelSms,
+		PhoneNumber: "08123456789",
+		OtpCode:     42,
+	}
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{
+			name:    "gateway rejected message",
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			status:  http.StatusAccepted,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got textMessage
+			var auth string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				json.NewDecoder(r.Body).Decode(&got)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewSms(server.Client(), server.URL, "secret").SendOtp(context.Background(), otp)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HTTP.SendOtp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			want := textMessage{
+				Channel: entity.OtpChannelSms,
+				To:      "+628123456789",
+				Message: "Kode OTP Mitra anda 0042, berlaku 5 menit. Jangan berikan kode ini kepada siapapun.",
+			}
+			if got != want {
+				t.Errorf("HTTP.SendOtp() sent = %v, want %v", got, want)
+			}
+			if auth != "Bearer secret" {
+				t.Errorf("HTTP.SendOtp() authorization = %v, want %v", auth, "Bearer secret")
+			}
+		})
+	}
+}
+
+func TestEmail_SendNotice(t *testing.T) {
+	notice := &entity.Notice{
+		Channel: entity.OtpChannelEmail,
+		Email:   "test@mail.com",
+		Subject: "subject",
+		Message: "message",
+	}
+	var got textMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	err := NewEmail(&fakeMailClient{}, nil).SendNotice(context.Background(), notice)
+	var permanent interface{ Permanent() bool }
+	if !errors.As(err, &permanent) || !permanent.Permanent() {
+		t.Errorf("Email.SendNotice() without gateway error = %v, want permanent error", err)
+	}
+
+	gateway := NewMail(server.Client(), server.URL, "secret")
+	if err := NewEmail(&fakeMailClient{}, gateway).SendNotice(context.Background(), notice); err != nil {
+		t.Errorf("Email.SendNotice() error = %v", err)
+	}
+	want := textMessage{
+		Channel: entity.OtpChannelEmail,
+		To:      "test@mail.com",
+		Subject: "subject",
+		Message: "message",
+	}
+	if got != want {
+		t.Errorf("Email.SendNotice() sent = %v, want %v", got, want)
+	}
+}
+
+func TestInternationalPhoneNumber(t *testing.T) {
+	tests := []struct {
+		phoneNumber string
+		want        string
+	}{
+		{phoneNumber: "08123456789", want: "+628123456789"},
+		{phoneNumber: "628123456789", want: "+628123456789"},
+		{phoneNumber: "+628123456789", want: "+628123456789"},
+		{phoneNumber: " 08123456789 ", want: "+628123456789"},
+	}
+	for _, tt := range tests {
+		if got := InternationalPhoneNumber(tt.phoneNumber); got != tt.want {
+			t.Errorf("InternationalPhoneNumber(%v) = %v, want %v", tt.phoneNumber, got, tt.want)
+		}
+	}
+}
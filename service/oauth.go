@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/tools"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// authorization codes are exchanged right after the redirect, a short life limits a leaked code
+const authorizationCodeExpiration = time.Minute
+
+// CreateOAuthClient registers a client of the openid connect provider. The secret of a
+// confidential client is only returned here, its hash is stored.
+func (s *Service) CreateOAuthClient(ctx context.Context, adminId uuid.UUID, name string, redirectUris []string, confidential bool) (*entity.OAuthClient, string, error) {
+	id, err := randomToken(16)
+	if err != nil {
+		return nil, "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	client := &entity.OAuthClient{
+		Id:           id,
+		Name:         name,
+		RedirectUris: redirectUris,
+		IsActive:     true,
+		CreatedBy:    adminId,
+	}
+	var secret string
+	if confidential {
+		if secret, err = randomToken(32); err != nil {
+			return nil, "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+		}
+		client.SecretHash = hashClientSecret(secret)
+	}
+	if err := s.oauthClientRepo.Create(ctx, client); err != nil {
+		return nil, "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditClientCreated, adminId, uuid.Nil, map[string]string{"client_id": client.Id, "name": client.Name})
+	return client, secret, nil
+}
+
+// GetOAuthClient returns the active client clientId
+func (s *Service) GetOAuthClient(ctx context.Context, clientId string) (*entity.OAuthClient, error) {
+	client, err := s.oauthClientRepo.GetByID(ctx, clientId)
+	if err != nil {
+		if errors.Is(err, repository.ErrOAuthClientNotFound) {
+			return nil, oauthClientInvalidError()
+		}
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return client, nil
+}
+
+// AuthenticateOAuthClient returns the client clientId once it proved its identity, confidential
+// clients with their secret and public clients by the PKCE verifier checked on code exchange
+func (s *Service) AuthenticateOAuthClient(ctx context.Context, clientId string, clientSecret string) (*entity.OAuthClient, error) {
+	client, err := s.GetOAuthClient(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+	if client.IsConfidential() && subtle.ConstantTimeCompare([]byte(hashClientSecret(clientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, oauthClientInvalidError()
+	}
+	return client, nil
+}
+
+// CreateAuthorizationCode stores authorization until it is exchanged and returns its code
+func (s *Service) CreateAuthorizationCode(ctx context.Context, authorization *entity.AuthorizationCode) (string, error) {
+	code, err := randomToken(32)
+	if err != nil {
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	// an authorization always encodes
+	value, _ := json.Marshal(authorization)
+	if err := s.redis.Set(ctx, tools.OAuthCodeRedisPrefix+code, value, authorizationCodeExpiration); err != nil {
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return code, nil
+}
+
+// ExchangeAuthorizationCode consumes code issued to clientId for redirectUri and checks codeVerifier
+// against its PKCE challenge. A code is used once, a failed exchange drops it as well.
+func (s *Service) ExchangeAuthorizationCode(ctx context.Context, clientId string, code string, redirectUri string, codeVerifier string) (*entity.AuthorizationCode, error) {
+	value, err := s.redis.GetDel(ctx, tools.OAuthCodeRedisPrefix+code)
+	if err != nil {
+		return nil, oauthGrantInvalidError("Kode otorisasi tidak valid atau sudah kedaluwarsa")
+	}
+	var authorization entity.AuthorizationCode
+	if err := json.Unmarshal([]byte(value), &authorization); err != nil {
+		logger.FromContext(ctx).WithError(err).Error("failed to decode authorization code")
+		return nil, oauthGrantInvalidError("Kode otorisasi tidak valid atau sudah kedaluwarsa")
+	}
+	if authorization.ClientId != clientId || authorization.RedirectUri != redirectUri {
+		return nil, oauthGrantInvalidError("Kode otorisasi tidak diterbitkan untuk aplikasi ini")
+	}
+	if !VerifyCodeChallenge(authorization.CodeChallenge, codeVerifier) {
+		return nil, oauthGrantInvalidError("Code verifier tidak sesuai")
+	}
+	return &authorization, nil
+}
+
+// GetUser returns the active user userId
+func (s *Service) GetUser(ctx context.Context, userId uuid.UUID) (*entity.User, error) {
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserActive(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// VerifyCodeChallenge reports whether verifier matches the S256 PKCE challenge
+func VerifyCodeChallenge(challenge string, verifier string) bool {
+	// RFC 7636 verifiers are 43 to 128 characters
+	if len(verifier) < 43 || len(verifier) > 128 || challenge == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(sum[:])), []byte(challenge)) == 1
+}
+
+// randomToken returns size random bytes, url safe encoded
+func randomToken(size int) (string, error) {
+	random := make([]byte, size)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+// hashClientSecret hashes secrets generated with enough entropy not to need a slow hash
+func hashClientSecret(secret string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(secret)))
+	return hex.EncodeToString(sum[:])
+}
+
+func oauthClientInvalidError() error {
+	ErrorCode = codes.Unauthenticated
+	ErrorCodeDetail = pbErr.ErrorCode_OAUTH_CLIENT_INVALID.String()
+	ErrorMessage = "Aplikasi tidak terdaftar"
+	return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+}
+
+func oauthGrantInvalidError(message string) error {
+	return util.NewError(codes.InvalidArgument, pbErr.ErrorCode_OAUTH_GRANT_INVALID.String(), message)
+}
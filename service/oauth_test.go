@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/config/tools"
+	mockRedis "github.com/Mitra-Apps/be-user-service/config/tools/redis/mock"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_CreateOAuthClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOAuthClient := mock.NewMockOAuthClient(ctrl)
+	s := &Service{
+		oauthClientRepo: mockOAuthClient,
+		auditRepo:       newMockAudit(ctrl),
+	}
+	adminId := uuid.New()
+	redirectUris := []string{"https://app.mitra.test/callback"}
+
+	var stored *entity.OAuthClient
+	mockOAuthClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, client *entity.OAuthClient) error {
+		stored = client
+		return nil
+	})
+	client, secret, err := s.CreateOAuthClient(context.Background(), adminId, "Mitra", redirectUris, true)
+	if err != nil {
+		t.Fatalf("Service.CreateOAuthClient() error = %v", err)
+	}
+	if client != stored || client.Id == "" || secret == "" || client.SecretHash != hashClientSecret(secret) || client.CreatedBy != adminId {
+		t.Errorf("Service.CreateOAuthClient() = %v, %s, want confidential client storing the secret hash", client, secret)
+	}
+
+	mockOAuthClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	client, secret, err = s.CreateOAuthClient(context.Background(), adminId, "Mitra", redirectUris, false)
+	if err != nil || secret != "" || client.IsConfidential() {
+		t.Errorf("Service.CreateOAuthClient() = %v, %s, %v, want public client", client, secret, err)
+	}
+
+	mockOAuthClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("any error"))
+	if _, _, err := s.CreateOAuthClient(context.Background(), adminId, "Mitra", redirectUris, false); err == nil {
+		t.Errorf("Service.CreateOAuthClient() error = nil, want error")
+	}
+}
+
+func TestService_AuthenticateOAuthClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOAuthClient := mock.NewMockOAuthClient(ctrl)
+	s := &Service{oauthClientRepo: mockOAuthClient}
+	confidential := &entity.OAuthClient{Id: "confidential", SecretHash: hashClientSecret("secret")}
+	public := &entity.OAuthClient{Id: "public"}
+	tests := []struct {
+		name     string
+		clientId string
+		secret   string
+		want     *entity.OAuthClient
+		wantErr  bool
+		mocks    func()
+	}{
+		{
+			name:     "error client not found",
+			clientId: "unknown",
+			wantErr:  true,
+			mocks: func() {
+				mockOAuthClient.EXPECT().GetByID(gomock.Any(), "unknown").Return(nil, repository.ErrOAuthClientNotFound)
+			},
+		},
+		{
+			name:     "error wrong secret",
+			clientId: "confidential",
+			secret:   "wrong",
+			wantErr:  true,
+			mocks: func() {
+				mockOAuthClient.EXPECT().GetByID(gomock.Any(), "confidential").Return(confidential, nil)
+			},
+		},
+		{
+			name:     "error missing secret",
+			clientId: "confidential",
+			wantErr:  true,
+			mocks: func() {
+				mockOAuthClient.EXPECT().GetByID(gomock.Any(), "confidential").Return(confidential, nil)
+			},
+		},
+		{
+			name:     "success confidential client",
+			clientId: "confidential",
+			secret:   "secret",
+			want:     confidential,
+			mocks: func() {
+				mockOAuthClient.EXPECT().GetByID(gomock.Any(), "confidential").Return(confidential, nil)
+			},
+		},
+		{
+			name:     "success public client",
+			clientId: "public",
+			want:     public,
+			mocks: func() {
+				mockOAuthClient.EXPECT().GetByID(gomock.Any(), "public").Return(public, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.AuthenticateOAuthClient(context.Background(), tt.clientId, tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Service.AuthenticateOAuthClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Service.AuthenticateOAuthClient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ExchangeAuthorizationCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	s := &Service{redis: redis}
+	verifier := strings.Repeat("v", 43)
+	sum := sha256.Sum256([]byte(verifier))
+	authorization := &entity.AuthorizationCode{
+		ClientId:      "client",
+		RedirectUri:   "https://app.mitra.test/callback",
+		UserId:        uuid.New(),
+		Scope:         "openid",
+		CodeChallenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}
+	stored, _ := json.Marshal(authorization)
+
+	redis.EXPECT().Set(gomock.Any(), gomock.Any(), stored, authorizationCodeExpiration).Return(nil)
+	code, err := s.CreateAuthorizationCode(context.Background(), authorization)
+	if err != nil || code == "" {
+		t.Fatalf("Service.CreateAuthorizationCode() = %s, %v", code, err)
+	}
+	key := tools.OAuthCodeRedisPrefix + code
+	tests := []struct {
+		name        string
+		clientId    string
+		redirectUri string
+		verifier    string
+		wantErr     bool
+		mocks       func()
+	}{
+		{
+			name:        "error code used or expired",
+			clientId:    "client",
+			redirectUri: authorization.RedirectUri,
+			verifier:    verifier,
+			wantErr:     true,
+			mocks: func() {
+				redis.EXPECT().GetDel(gomock.Any(), key).Return("", errors.New("redis: nil"))
+			},
+		},
+		{
+			name:        "error other client",
+			clientId:    "other",
+			redirectUri: authorization.RedirectUri,
+			verifier:    verifier,
+			wantErr:     true,
+			mocks: func() {
+				redis.EXPECT().GetDel(gomock.Any(), key).Return(string(stored), nil)
+			},
+		},
+		{
+			name:        "error other redirect uri",
+			clientId:    "client",
+			redirectUri: "https://app.mitra.test/other",
+			verifier:    verifier,
+			wantErr:     true,
+			mocks: func() {
+				redis.EXPECT().GetDel(gomock.Any(), key).Return(string(stored), nil)
+			},
+		},
+		{
+			name:        "error wrong verifier",
+			clientId:    "client",
+			redirectUri: authorization.RedirectUri,
+			verifier:    strings.Repeat("w", 43),
+			wantErr:     true,
+			mocks: func() {
+				redis.EXPECT().GetDel(gomock.Any(), key).Return(string(stored), nil)
+			},
+		},
+		{
+			name:        "success",
+			clientId:    "client",
+			redirectUri: authorization.RedirectUri,
+			verifier:    verifier,
+			mocks: func() {
+				redis.EXPECT().GetDel(gomock.Any(), key).Return(string(stored), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.ExchangeAuthorizationCode(context.Background(), tt.clientId, code, tt.redirectUri, tt.verifier)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Service.ExchangeAuthorizationCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got.UserId != authorization.UserId || got.Scope != authorization.Scope) {
+				t.Errorf("Service.ExchangeAuthorizationCode() = %v, want %v", got, authorization)
+			}
+		})
+	}
+}
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	// the example of RFC 7636 appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	if !VerifyCodeChallenge(challenge, verifier) {
+		t.Errorf("VerifyCodeChallenge() = false, want true")
+	}
+	if VerifyCodeChallenge(challenge, verifier[1:]) {
+		t.Errorf("VerifyCodeChallenge() = true for a short verifier, want false")
+	}
+	if VerifyCodeChallenge("", verifier) {
+		t.Errorf("VerifyCodeChallenge() = true without challenge, want false")
+	}
+}
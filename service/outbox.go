@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/metrics"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// how long a claimed message stays hidden from other dispatchers
+	outboxClaimLease = time.Minute
+	// timeout of a single delivery to a notifier
+	outboxDeliveryTimeout = 10 * time.Second
+)
+
+// errPermanent marks deliveries that would fail the same way on retry
+var errPermanent = errors.New("permanent delivery failure")
+
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+// DefaultOutboxConfig retries a message for about half an hour before dead-lettering it
+func DefaultOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		PollInterval: 2 * time.Second,
+		BatchSize:    20,
+		MaxAttempts:  10,
+		BaseBackoff:  5 * time.Second,
+		MaxBackoff:   15 * time.Minute,
+	}
+}
+
+// OutboxDispatcher delivers the messages written to the outbox. Failed deliveries are
+// retried with exponential backoff, messages still failing after MaxAttempts are
+// marked dead and left in the table for inspection. Payloads carry otps and contact
+// details, they are scrubbed once a message is sent or dead.
+type OutboxDispatcher struct {
+	outbox    repository.Outbox
+	notifiers Notifiers
+	publisher EventPublisher
+	config    OutboxConfig
+}
+
+func NewOutboxDispatcher(outbox repository.Outbox, notifiers Notifiers, publisher EventPublisher, config OutboxConfig) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		outbox:    outbox,
+		notifiers: notifiers,
+		publisher: publisher,
+		config:    config,
+	}
+}
+
+// Run polls the outbox until ctx is done
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DispatchPending(ctx); err != nil && ctx.Err() == nil {
+			logrus.WithError(err).Error("failed to dispatch outbox messages")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending delivers one batch of due messages and returns how many were delivered
+func (d *OutboxDispatcher) DispatchPending(ctx context.Context) (int, error) {
+	messages, err := d.outbox.ClaimDue(ctx, d.config.BatchSize, outboxClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, message := range messages {
+		err := d.deliver(ctx, message)
+		if err == nil {
+			delivered++
+		}
+		d.record(message, err)
+		if err := d.outbox.Save(ctx, message); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+func (d *OutboxDispatcher) deliver(ctx context.Context, message *entity.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, outboxDeliveryTimeout)
+	defer cancel()
+
+	switch message.Topic {
+	case entity.OutboxTopicOtp:
+		// the code is not accepted anymore, sending it would only confuse the user
+		if time.Since(message.CreatedAt) > otpExpiration {
+			return fmt.Errorf("%w: otp expired before delivery", errPermanent)
+		}
+		var payload entity.OtpNotification
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return fmt.Errorf("%w: %v", errPermanent, err)
+		}
+		notifier, ok := d.notifiers[payload.Channel]
+		if !ok {
+			return fmt.Errorf("%w: no notifier for channel %q", errPermanent, payload.Channel)
+		}
+		return notifier.SendOtp(ctx, &payload)
+	case entity.OutboxTopicNotice:
+		var payload entity.Notice
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return fmt.Errorf("%w: %v", errPermanent, err)
+		}
+		notifier, ok := d.notifiers[payload.Channel]
+		if !ok {
+			return fmt.Errorf("%w: no notifier for channel %q", errPermanent, payload.Channel)
+		}
+		return notifier.SendNotice(ctx, &payload)
+	case entity.OutboxTopicEvent:
+		var event entity.DomainEvent
+		if err := json.Unmarshal(message.Payload, &event); err != nil {
+			return fmt.Errorf("%w: %v", errPermanent, err)
+		}
+		if d.publisher == nil {
+			return fmt.Errorf("%w: no event publisher", errPermanent)
+		}
+		return d.publisher.Publish(ctx, &event)
+	}
+	return fmt.Errorf("%w: unknown topic %q", errPermanent, message.Topic)
+}
+
+// isPermanent reports whether a delivery failure should not be retried, notifiers mark
+// such errors with a Permanent method
+func isPermanent(err error) bool {
+	var permanent interface{ Permanent() bool }
+	return errors.Is(err, errPermanent) || errors.As(err, &permanent) && permanent.Permanent()
+}
+
+// record updates message with the outcome of a delivery attempt
+func (d *OutboxDispatcher) record(message *entity.OutboxMessage, err error) {
+	message.Attempts++
+	entry := logrus.WithFields(logrus.Fields{
+		"outbox_id": message.Id.String(),
+		"topic":     message.Topic,
+		"attempts":  message.Attempts,
+	})
+
+	if err == nil {
+		now := time.Now()
+		message.Status = entity.OutboxStatusSent
+		message.SentAt = &now
+		message.LastError = ""
+		message.Payload = scrubbedPayload()
+		metrics.OutboxDeliveryTotal.WithLabelValues(message.Topic, metrics.OutboxDelivered).Inc()
+		return
+	}
+
+	message.LastError = err.Error()
+	if isPermanent(err) || message.Attempts >= d.config.MaxAttempts {
+		message.Status = entity.OutboxStatusDead
+		message.Payload = scrubbedPayload()
+		metrics.OutboxDeliveryTotal.WithLabelValues(message.Topic, metrics.OutboxDead).Inc()
+		entry.WithError(err).Error("outbox message dead-lettered")
+		return
+	}
+	message.NextAttemptAt = time.Now().Add(d.backoff(message.Attempts))
+	metrics.OutboxDeliveryTotal.WithLabelValues(message.Topic, metrics.OutboxRetried).Inc()
+	entry.WithError(err).Warn("outbox delivery failed, retrying")
+}
+
+// scrubbedPayload replaces the payload of messages that will not be delivered again
+func scrubbedPayload() []byte {
+	return []byte("{}")
+}
+
+// backoff doubles the delay after each failed attempt, up to MaxBackoff
+func (d *OutboxDispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.BaseBackoff
+	for i := 1; i < attempts && delay < d.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.config.MaxBackoff {
+		delay = d.config.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/Mitra-Apps/be-user-service/service/notifier"
+	"github.com/Mitra-Apps/be-user-service/service/publisher"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxDispatcher_DispatchPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	config := DefaultOutboxConfig()
+	config.MaxAttempts = 3
+
+	otpMessage := func(attempts int) *entity.OutboxMessage {
+		m, err := entity.NewOutboxMessage(entity.OutboxTopicOtp, &entity.OtpNotification{
+			Channel: entity.OtpChannelEmail,
+			Name:    "test",
+			Email:   "test@mail.com",
+			OtpCode: 1234,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Attempts = attempts
+		return m
+	}
+	userId := uuid.New()
+	eventMessage := func() *entity.OutboxMessage {
+		event, err := entity.NewDomainEvent(userId, &events.UserVerified{UserId: userId.String(), EmailVerified: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := entity.NewOutboxMessage(entity.OutboxTopicEvent, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name          string
+		message       *entity.OutboxMessage
+		claimErr      error
+		mailErr       error
+		publishErr    error
+		wantDelivered int
+		wantErr       bool
+		wantStatus    string
+		wantAttempts  int
+	}{
+		{
+			name:     "error claiming messages",
+			claimErr: errors.New("any error"),
+			wantErr:  true,
+		},
+		{
+			name:          "delivered",
+			message:       otpMessage(0),
+			wantDelivered: 1,
+			wantStatus:    entity.OutboxStatusSent,
+			wantAttempts:  1,
+		},
+		{
+			name:         "failed delivery retried",
+			message:      otpMessage(0),
+			mailErr:      errors.New("unavailable"),
+			wantStatus:   entity.OutboxStatusPending,
+			wantAttempts: 1,
+		},
+		{
+			name:         "last failed attempt dead-lettered",
+			message:      otpMessage(2),
+			mailErr:      errors.New("unavailable"),
+			wantStatus:   entity.OutboxStatusDead,
+			wantAttempts: 3,
+		},
+		{
+			name: "expired otp dropped",
+			message: func() *entity.OutboxMessage {
+				m := otpMessage(1)
+				m.CreatedAt = time.Now().Add(-otpExpiration - time.Minute)
+				return m
+			}(),
+			wantStatus:   entity.OutboxStatusDead,
+			wantAttempts: 2,
+		},
+		{
+			name: "unavailable channel dead-lettered",
+			message: func() *entity.OutboxMessage {
+				m, _ := entity.NewOutboxMessage(entity.OutboxTopicOtp, &entity.OtpNotification{Channel: entity.OtpChannelSms})
+				return m
+			}(),
+			wantStatus:   entity.OutboxStatusDead,
+			wantAttempts: 1,
+		},
+		{
+			name: "notice delivered",
+			message: func() *entity.OutboxMessage {
+				m, _ := entity.NewOutboxMessage(entity.OutboxTopicNotice, &entity.Notice{Channel: entity.OtpChannelEmail, Email: "test@mail.com"})
+				return m
+			}(),
+			wantDelivered: 1,
+			wantStatus:    entity.OutboxStatusSent,
+			wantAttempts:  1,
+		},
+		{
+			name: "notice without mail gateway dead-lettered",
+			message: func() *entity.OutboxMessage {
+				m, _ := entity.NewOutboxMessage(entity.OutboxTopicNotice, &entity.Notice{Channel: entity.OtpChannelEmail, Email: "test@mail.com"})
+				return m
+			}(),
+			mailErr:      notifier.ErrNoticeUnavailable,
+			wantStatus:   entity.OutboxStatusDead,
+			wantAttempts: 1,
+		},
+		{
+			name:          "event published",
+			message:       eventMessage(),
+			wantDelivered: 1,
+			wantStatus:    entity.OutboxStatusSent,
+			wantAttempts:  1,
+		},
+		{
+			name:         "failed publication retried",
+			message:      eventMessage(),
+			publishErr:   errors.New("unavailable"),
+			wantStatus:   entity.OutboxStatusPending,
+			wantAttempts: 1,
+		},
+		{
+			name:         "malformed event dead-lettered",
+			message:      &entity.OutboxMessage{Topic: entity.OutboxTopicEvent, Payload: []byte("{"), Status: entity.OutboxStatusPending},
+			wantStatus:   entity.OutboxStatusDead,
+			wantAttempts: 1,
+		},
+		{
+			name:         "unknown topic dead-lettered",
+			message:      &entity.OutboxMessage{Topic: "unknown", Status: entity.OutboxStatusPending},
+			wantStatus:   entity.OutboxStatusDead,
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := notifier.NewFake()
+			email.FailWith(tt.mailErr)
+			published := publisher.NewMemory()
+			published.FailWith(tt.publishErr)
+			d := NewOutboxDispatcher(mockOutbox, Notifiers{entity.OtpChannelEmail: email}, published, config)
+			if tt.claimErr != nil {
+				mockOutbox.EXPECT().ClaimDue(gomock.Any(), config.BatchSize, gomock.Any()).Return(nil, tt.claimErr)
+			} else {
+				mockOutbox.EXPECT().ClaimDue(gomock.Any(), config.BatchSize, gomock.Any()).Return([]*entity.OutboxMessage{tt.message}, nil)
+				mockOutbox.EXPECT().Save(gomock.Any(), tt.message).Return(nil)
+			}
+
+			start := time.Now()
+			got, err := d.DispatchPending(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OutboxDispatcher.DispatchPending() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.wantDelivered {
+				t.Errorf("OutboxDispatcher.DispatchPending() = %v, want %v", got, tt.wantDelivered)
+			}
+			if tt.message == nil {
+				return
+			}
+			if tt.message.Status != tt.wantStatus {
+				t.Errorf("OutboxDispatcher.DispatchPending() status = %v, want %v", tt.message.Status, tt.wantStatus)
+			}
+			if tt.message.Attempts != tt.wantAttempts {
+				t.Errorf("OutboxDispatcher.DispatchPending() attempts = %v, want %v", tt.message.Attempts, tt.wantAttempts)
+			}
+			if scrubbed := string(tt.message.Payload) == "{}"; scrubbed != (tt.wantStatus != entity.OutboxStatusPending) {
+				t.Errorf("OutboxDispatcher.DispatchPending() payload = %s with status %v, want it scrubbed once sent or dead", tt.message.Payload, tt.wantStatus)
+			}
+			if tt.message.Topic == entity.OutboxTopicOtp && tt.wantStatus == entity.OutboxStatusDead && email.Last("test@mail.com") != nil {
+				t.Errorf("OutboxDispatcher.DispatchPending() sent a dead-lettered otp")
+			}
+			if tt.wantStatus == entity.OutboxStatusPending && !tt.message.NextAttemptAt.After(start) {
+				t.Errorf("OutboxDispatcher.DispatchPending() next attempt = %v, want after %v", tt.message.NextAttemptAt, start)
+			}
+			if sent := email.Last("test@mail.com"); tt.message.Topic == entity.OutboxTopicOtp && tt.wantStatus == entity.OutboxStatusSent && (sent == nil || sent.OtpCode != 1234) {
+				t.Errorf("OutboxDispatcher.DispatchPending() sent = %v, want otp 1234", sent)
+			}
+			if tt.message.Topic == entity.OutboxTopicNotice && tt.wantStatus == entity.OutboxStatusSent && len(email.Notices()) != 1 {
+				t.Errorf("OutboxDispatcher.DispatchPending() notices = %v, want 1", email.Notices())
+			}
+			if tt.message.Topic == entity.OutboxTopicEvent && tt.wantStatus == entity.OutboxStatusSent && published.Last("proto.events.v1.UserVerified", userId) == nil {
+				t.Errorf("OutboxDispatcher.DispatchPending() events = %v, want UserVerified of %v", published.Events(), userId)
+			}
+		})
+	}
+}
+
+func TestOutboxDispatcher_backoff(t *testing.T) {
+	d := NewOutboxDispatcher(nil, nil, nil, OutboxConfig{
+		BaseBackoff: time.Second,
+		MaxBackoff:  10 * time.Second,
+	})
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 50, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("OutboxDispatcher.backoff(%v) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"google.golang.org/grpc/codes"
+)
+
+// MaxPasswordLength is the longest password the api accepts
+const MaxPasswordLength = 128
+
+// minPersonalInfoLength ignores shorter name parts, they are too common to refuse
+const minPersonalInfoLength = 3
+
+// PasswordPolicy configures the passwords accepted on registration and password changes.
+// Zero values disable a rule, the zero policy accepts any password.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// MaxBytes bounds the utf-8 length of passwords, for hashing algorithms with a limit like bcrypt
+	MaxBytes int
+	// MinCharClasses is the number of classes among lowercase, uppercase, digits and symbols a password mixes
+	MinCharClasses int
+	// Banned lists passwords refused whatever their case
+	Banned []string
+	// RejectPersonalInfo refuses passwords containing the email or a name of the user
+	RejectPersonalInfo bool
+	// Breached refuses the passwords of known breaches when set
+	Breached BreachedPasswords
+}
+
+// BreachedPasswords tells whether a password appeared in a known breach
+type BreachedPasswords interface {
+	IsBreached(password string) bool
+}
+
+// commonPasswords are refused even without a banned list configured
+var commonPasswords = []string{
+	"password", "password1", "password123", "12345678", "123456789", "1234567890", "87654321",
+	"11111111", "00000000", "qwerty123", "qwertyuiop", "iloveyou", "bismillah", "indonesia",
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:          8,
+		MaxLength:          MaxPasswordLength,
+		MinCharClasses:     2,
+		Banned:             commonPasswords,
+		RejectPersonalInfo: true,
+	}
+}
+
+// Check returns the error of the first rule password breaks, email and name are those of its user
+func (p PasswordPolicy) Check(password string, email string, name string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return passwordPolicyError(fmt.Sprintf("Password minimal %d karakter", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return passwordPolicyError(fmt.Sprintf("Password maksimal %d karakter", p.MaxLength))
+	}
+	if p.MaxBytes > 0 && len(password) > p.MaxBytes {
+		return passwordPolicyError("Password terlalu panjang, gunakan password yang lebih pendek")
+	}
+	if charClasses(password) < p.MinCharClasses {
+		return passwordPolicyError(fmt.Sprintf("Password harus memuat minimal %d jenis karakter: huruf kecil, huruf besar, angka atau simbol", p.MinCharClasses))
+	}
+	if slices.ContainsFunc(p.Banned, func(banned string) bool { return strings.EqualFold(banned, password) }) {
+		return passwordPolicyError("Password terlalu umum, gunakan password lain")
+	}
+	if p.RejectPersonalInfo && containsPersonalInfo(password, email, name) {
+		return passwordPolicyError("Password tidak boleh memuat email atau nama")
+	}
+	if p.Breached != nil && p.Breached.IsBreached(password) {
+		return util.NewError(codes.InvalidArgument, pbErr.ErrorCode_AUTH_PASSWORD_BREACHED.String(), "Password pernah bocor dari situs lain, gunakan password lain")
+	}
+	return nil
+}
+
+func charClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+func containsPersonalInfo(password string, email string, name string) bool {
+	password = strings.ToLower(password)
+	localPart, _, _ := strings.Cut(strings.ToLower(email), "@")
+	parts := append(strings.Fields(strings.ToLower(name)), localPart)
+	return slices.ContainsFunc(parts, func(part string) bool {
+		return utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(password, part)
+	})
+}
+
+func passwordPolicyError(message string) error {
+	return util.NewError(codes.InvalidArgument, pbErr.ErrorCode_AUTH_PASSWORD_POLICY.String(), message)
+}
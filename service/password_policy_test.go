@@ -0,0 +1,114 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+)
+
+type breachedPasswords []string
+
+func (b breachedPasswords) IsBreached(password string) bool {
+	for _, breached := range b {
+		if breached == password {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	policy.Breached = breachedPasswords{"Jakarta2024!"}
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		wantCode string
+	}{
+		{
+			name:     "zero policy accepts any password",
+			password: "1",
+		},
+		{
+			name:     "too short",
+			policy:   policy,
+			password: "Ab1!",
+			wantCode: pbErr.ErrorCode_AUTH_PASSWORD_POLICY.String(),
+		},
+		{
+			name:     "too long",
+			policy:   policy,
+			password: strings.Repeat("Ab1!", 33),
+			wantCode: pbErr.ErrorCode_AUTH_PASSWORD_POLICY.String(),
+		},
+		{
+			name:     "too many bytes for the hashing algorithm",
+			policy:   PasswordPolicy{MaxBytes: 72},
+			password: strings.Repeat("é", 37),
+			wantCode: pbErr.ErrorCode_AUTH_PASSWORD_POLICY.String(),
+		},
+		{
+			name:     "within the bytes of the hashing algorithm",
+			policy:   PasswordPolicy{MaxBytes: 72},
+			password: strings.Repeat("é", 36),
+		},
+		{
+			name:     "length counts characters",
+			policy:   policy,
+			password: strings.Repeat("é", 8) + "1",
+		},
+		{
+			name:     "single character class",
+			policy:   policy,
+			password: "abcdefghij",
+			wantCode: pbErr.ErrorCode_AUTH_PASSWORD_POLICY.String(),
+		},
+		{
+			name:     "banned whatever the case",
+			policy:   policy,
+			password: "Password123",
+			wantCode: pbErr.ErrorCode_AUTH_PASSWORD_POLICY.String(),
+		},
+		{
+			name:     "contains the email",
+			policy:   policy,
+			password: "Budi.Santoso99",
+			wantCode: pbErr.ErrorCode_AUTH_PASSWORD_POLICY.String(),
+		},
+		{
+			name:     "contains a name",
+			policy:   policy,
+			password: "wijaya#2024",
+			wantCode: pbErr.ErrorCode_AUTH_PASSWORD_POLICY.String(),
+		},
+		{
+			name:     "short name parts are ignored",
+			policy:   policy,
+			password: "Rumah Al 2024",
+		},
+		{
+			name:     "breached",
+			policy:   policy,
+			password: "Jakarta2024!",
+			wantCode: pbErr.ErrorCode_AUTH_PASSWORD_BREACHED.String(),
+		},
+		{
+			name:     "success",
+			policy:   policy,
+			password: "kopi-susu-gula-aren",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password, "budi.santoso@mail.com", "Al Wijaya")
+			if (err != nil) != (tt.wantCode != "") {
+				t.Fatalf("PasswordPolicy.Check() error = %v, want code %q", err, tt.wantCode)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.wantCode) {
+				t.Errorf("PasswordPolicy.Check() error = %v, want code %q", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/metrics"
+	"github.com/Mitra-Apps/be-user-service/config/tools"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/handler/middleware"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *Service) SendPhoneVerificationOtp(ctx context.Context, userId uuid.UUID, channel string) error {
+	if err := s.checkOtpChannel(channel); err != nil {
+		return err
+	}
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.IsPhoneVerified {
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_OTP_ERROR_VERIFIED_USER.String()
+		ErrorMessage = "No. Telp sudah terverifikasi"
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	return s.issuePhoneOtp(ctx, user, channel, "phone_verification", tools.PhoneVerificationOtpRedisPrefix+user.Id.String())
+}
+
+func (s *Service) VerifyPhone(ctx context.Context, userId uuid.UUID, otp int) (*entity.User, error) {
+	user, err := s.getUserByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsPhoneVerified {
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_OTP_ERROR_VERIFIED_USER.String()
+		ErrorMessage = "No. Telp sudah terverifikasi"
+		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	if _, err := s.consumeOtp(ctx, otp, tools.PhoneVerificationOtpRedisPrefix+user.Id.String()); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPhoneNumber(ctx, user); err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditOtpVerified, user.Id, user.Id, map[string]string{"purpose": "phone_verification"})
+	return user, nil
+}
+
+func (s *Service) RequestPhoneLoginOtp(ctx context.Context, phoneNumber string, channel string) error {
+	if err := s.checkOtpChannel(channel); err != nil {
+		return err
+	}
+	user, err := s.getUserByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		return err
+	}
+	if !user.IsVerified {
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_LOGIN_USER_UNVERIFIED.String()
+		ErrorMessage = "Email sudah terdaftar, silahkan lakukan verifikasi OTP"
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	return s.issuePhoneOtp(ctx, user, channel, "phone_login", tools.PhoneLoginOtpRedisPrefix+user.PhoneNumber)
+}
+
+// LoginWithPhoneOtp signs in the owner of phoneNumber with the code sent by RequestPhoneLoginOtp,
+// which also proves the phone number when it was not verified yet
+func (s *Service) LoginWithPhoneOtp(ctx context.Context, phoneNumber string, otp int) (*entity.User, error) {
+	user, err := s.getUserByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.consumeOtp(ctx, otp, tools.PhoneLoginOtpRedisPrefix+user.PhoneNumber); err != nil {
+		return nil, err
+	}
+	if err := checkUserActive(user); err != nil {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginInactive).Inc()
+		s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": metrics.LoginInactive})
+		s.recordLoginAttempt(ctx, user, entity.LoginMethodPhoneOtp, metrics.LoginInactive)
+		return nil, err
+	}
+	if !user.IsPhoneVerified {
+		if err := s.verifyPhoneNumber(ctx, user); err != nil {
+			metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
+			return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+		}
+	}
+	metrics.LoginTotal.WithLabelValues(metrics.LoginSuccess).Inc()
+	s.audit(ctx, entity.AuditLoginSucceeded, user.Id, user.Id, map[string]string{"method": entity.LoginMethodPhoneOtp})
+	s.recordLoginAttempt(ctx, user, entity.LoginMethodPhoneOtp, "")
+	return user, nil
+}
+
+// verifyPhoneNumber marks the phone number of user as verified and announces it
+func (s *Service) verifyPhoneNumber(ctx context.Context, user *entity.User) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.VerifyPhoneNumber(ctx, user.Id); err != nil {
+			return err
+		}
+		return s.publishEvent(ctx, user.Id, &events.UserVerified{
+			UserId:        user.Id.String(),
+			PhoneVerified: true,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	user.IsPhoneVerified = true
+	return nil
+}
+
+func (s *Service) getUserByID(ctx context.Context, userId uuid.UUID) (*entity.User, error) {
+	user, err := s.userRepository.GetByID(ctx, userId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, util.NewError(codes.NotFound, pbErr.ErrorCode_RECORD_NOT_FOUND.String(), "User tidak ditemukan")
+		}
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return user, nil
+}
+
+func (s *Service) getUserByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error) {
+	user, err := s.userRepository.GetByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			metrics.LoginTotal.WithLabelValues(metrics.LoginNotFound).Inc()
+			ErrorCode = codes.NotFound
+			ErrorCodeDetail = pbErr.ErrorCode_AUTH_LOGIN_NOT_FOUND.String()
+			ErrorMessage = "No. Telp belum terdaftar, mohon registrasi"
+			return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		}
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return user, nil
+}
+
+// issuePhoneOtp sends a new otp to the phone number of user. The wrong codes counted for redisKey
+// are kept, a new code does not lift a lockout.
+func (s *Service) issuePhoneOtp(ctx context.Context, user *entity.User, channel string, purpose string, redisKey string) error {
+	if err := s.throttleOtp(ctx, tools.PhoneOtpCooldownRedisPrefix+user.PhoneNumber); err != nil {
+		return err
+	}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.issueOtp(ctx, user, channel, purpose, redisKey)
+	})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("failed to issue phone otp")
+		ErrorCode = codes.Internal
+		ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
+		ErrorMessage = "Gagal mengirim kode OTP"
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	s.audit(ctx, entity.AuditOtpSent, middleware.GetUserIDValue(ctx), user.Id, map[string]string{"purpose": purpose, "channel": channel})
+	return nil
+}
+
+// throttleOtp refuses a code within otpCooldown of the previous one counted at cooldownKey, and
+// more than maxOtpsPerIp codes requested from the client ip within otpIpWindow
+func (s *Service) throttleOtp(ctx context.Context, cooldownKey string) error {
+	sent, err := s.redis.Incr(ctx, cooldownKey, otpCooldown)
+	if err != nil {
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if sent > 1 {
+		return otpRateLimitedError()
+	}
+	ip := middleware.DeviceFromContext(ctx).IpAddress
+	if ip == "" {
+		return nil
+	}
+	sent, err = s.redis.Incr(ctx, tools.OtpIpRedisPrefix+ip, otpIpWindow)
+	if err != nil {
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if sent > maxOtpsPerIp {
+		return otpRateLimitedError()
+	}
+	return nil
+}
+
+func otpRateLimitedError() error {
+	return util.NewError(codes.ResourceExhausted, pbErr.ErrorCode_AUTH_OTP_RATE_LIMITED.String(), "Terlalu banyak permintaan kode OTP, silahkan coba lagi nanti")
+}
+
+// consumeOtp checks otp against the code stored at redisKey, deletes the code once used and
+// returns the channel it was sent through.
+// The code is dropped after maxOtpAttempts wrong guesses and no code for redisKey is accepted until
+// otpLockout has passed, so a 4 digit code cannot be brute forced by requesting new ones.
+func (s *Service) consumeOtp(ctx context.Context, otp int, redisKey string) (string, error) {
+	attemptsKey := redisKey + tools.OtpAttemptsRedisSuffix
+	attempts, err := s.redis.Incr(ctx, attemptsKey, otpLockout)
+	if err != nil {
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if attempts > maxOtpAttempts {
+		if err := s.redis.Del(ctx, redisKey); err != nil {
+			logger.FromContext(ctx).WithError(err).Warn("failed to drop otp after too many attempts")
+		}
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_OTP_TOO_MANY_ATTEMPTS.String()
+		ErrorMessage = "Terlalu banyak percobaan, silahkan minta kode OTP baru"
+		return "", util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+
+	channel, err := verifyOtpFromRedis(ctx, s, otp, redisKey)
+	if err != nil {
+		return "", err
+	}
+	// a code that cannot be deleted could be replayed, refuse it
+	if err := s.redis.Del(ctx, redisKey, attemptsKey); err != nil {
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return channel, nil
+}
@@ -0,0 +1,478 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	mockRedis "github.com/Mitra-Apps/be-user-service/config/tools/redis/mock"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/Mitra-Apps/be-user-service/service/notifier"
+	r "github.com/go-redis/redis"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/peer"
+)
+
+func TestService_SendPhoneVerificationOtp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockUserRecord := mockUser.EXPECT()
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	redisRecord := redis.EXPECT()
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	notifiers := Notifiers{
+		entity.OtpChannelEmail: notifier.NewFake(),
+		entity.OtpChannelSms:   notifier.NewFake(),
+	}
+	inTransaction := func() *gomock.Call {
+		return mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		redis:          redis,
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+		notifiers:      notifiers,
+	}
+	userId := uuid.New()
+	user := &entity.User{
+		Id:          userId,
+		Name:        "test",
+		PhoneNumber: "08123456789",
+	}
+	tests := []struct {
+		name    string
+		channel string
+		wantErr bool
+		mocks   []*gomock.Call
+	}{
+		{
+			name:    "error unavailable channel",
+			channel: entity.OtpChannelWhatsapp,
+			wantErr: true,
+		},
+		{
+			name:    "error user not found",
+			channel: entity.OtpChannelSms,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(nil, repository.ErrUserNotFound),
+			},
+		},
+		{
+			name:    "error phone already verified",
+			channel: entity.OtpChannelSms,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId, IsPhoneVerified: true}, nil),
+			},
+		},
+		{
+			name:    "error code sent less than a minute ago",
+			channel: entity.OtpChannelSms,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+				redisRecord.Incr(gomock.Any(), "otp-cooldown:phone:"+user.PhoneNumber, otpCooldown).Return(int64(2), nil),
+			},
+		},
+		{
+			name:    "error store otp",
+			channel: entity.OtpChannelSms,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+				redisRecord.Incr(gomock.Any(), "otp-cooldown:phone:"+user.PhoneNumber, otpCooldown).Return(int64(1), nil),
+				inTransaction(),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+				redisRecord.Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("any error")),
+			},
+		},
+		{
+			name:    "success",
+			channel: entity.OtpChannelSms,
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(user, nil),
+				redisRecord.Incr(gomock.Any(), "otp-cooldown:phone:"+user.PhoneNumber, otpCooldown).Return(int64(1), nil),
+				inTransaction(),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+				redisRecord.Set(gomock.Any(), "otp:phone-verification:"+userId.String(), gomock.Any(), gomock.Any()).Return(nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.SendPhoneVerificationOtp(context.Background(), userId, tt.channel); (err != nil) != tt.wantErr {
+				t.Errorf("Service.SendPhoneVerificationOtp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_VerifyPhone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockUserRecord := mockUser.EXPECT()
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	redisRecord := redis.EXPECT()
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	s := &Service{
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		redis:          redis,
+	}
+	userId := uuid.New()
+	redisKey := "otp:phone-verification:" + userId.String()
+	attemptsKey := redisKey + ":attempts"
+	storedOtp := `{"OTP":"1234","Channel":"sms"}`
+	tests := []struct {
+		name    string
+		otp     int
+		wantErr bool
+		mocks   []*gomock.Call
+	}{
+		{
+			name:    "error get user",
+			otp:     1234,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(nil, errors.New("any error")),
+			},
+		},
+		{
+			name:    "error too many attempts",
+			otp:     1234,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId}, nil),
+				redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(maxOtpAttempts+1), nil),
+				redisRecord.Del(gomock.Any(), redisKey).Return(nil),
+			},
+		},
+		{
+			name:    "error wrong otp",
+			otp:     4321,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId}, nil),
+				redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(1), nil),
+				redisRecord.GetStringKey(gomock.Any(), redisKey).Return(storedOtp, nil),
+			},
+		},
+		{
+			name:    "error delete used otp",
+			otp:     1234,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId}, nil),
+				redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(1), nil),
+				redisRecord.GetStringKey(gomock.Any(), redisKey).Return(storedOtp, nil),
+				redisRecord.Del(gomock.Any(), redisKey, attemptsKey).Return(errors.New("any error")),
+			},
+		},
+		{
+			name:    "success",
+			otp:     1234,
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId}, nil),
+				redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(1), nil),
+				redisRecord.GetStringKey(gomock.Any(), redisKey).Return(storedOtp, nil),
+				redisRecord.Del(gomock.Any(), redisKey, attemptsKey).Return(nil),
+				mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					}),
+				mockUserRecord.VerifyPhoneNumber(gomock.Any(), userId).Return(nil),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.VerifyPhone(context.Background(), userId, tt.otp)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.VerifyPhone() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !got.IsPhoneVerified {
+				t.Errorf("Service.VerifyPhone() IsPhoneVerified = %v, want true", got.IsPhoneVerified)
+			}
+		})
+	}
+}
+
+func TestService_RequestPhoneLoginOtp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockUserRecord := mockUser.EXPECT()
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	redisRecord := redis.EXPECT()
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	inTransaction := func() *gomock.Call {
+		return mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	s := &Service{
+		auditRepo:      newMockAudit(ctrl),
+		userRepository: mockUser,
+		redis:          redis,
+		outbox:         mockOutbox,
+		transactor:     mockTransactor,
+		notifiers:      Notifiers{entity.OtpChannelWhatsapp: notifier.NewFake()},
+	}
+	phoneNumber := "08123456789"
+	user := &entity.User{PhoneNumber: phoneNumber, IsVerified: true}
+	fromIp := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 40000}})
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+		mocks   []*gomock.Call
+	}{
+		{
+			name:    "error phone number not registered",
+			ctx:     context.Background(),
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(nil, repository.ErrUserNotFound),
+			},
+		},
+		{
+			name:    "error code sent less than a minute ago",
+			ctx:     context.Background(),
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(user, nil),
+				redisRecord.Incr(gomock.Any(), "otp-cooldown:phone:"+phoneNumber, otpCooldown).Return(int64(2), nil),
+			},
+		},
+		{
+			name:    "error too many codes requested from the ip",
+			ctx:     fromIp,
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(user, nil),
+				redisRecord.Incr(gomock.Any(), "otp-cooldown:phone:"+phoneNumber, otpCooldown).Return(int64(1), nil),
+				redisRecord.Incr(gomock.Any(), "otp-sent:ip:203.0.113.7", otpIpWindow).Return(int64(maxOtpsPerIp+1), nil),
+			},
+		},
+		{
+			name:    "error unverified user",
+			ctx:     context.Background(),
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(&entity.User{PhoneNumber: phoneNumber}, nil),
+			},
+		},
+		{
+			name:    "success",
+			ctx:     fromIp,
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(user, nil),
+				redisRecord.Incr(gomock.Any(), "otp-cooldown:phone:"+phoneNumber, otpCooldown).Return(int64(1), nil),
+				redisRecord.Incr(gomock.Any(), "otp-sent:ip:203.0.113.7", otpIpWindow).Return(int64(maxOtpsPerIp), nil),
+				inTransaction(),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+				redisRecord.Set(gomock.Any(), "otp:phone-login:"+phoneNumber, gomock.Any(), gomock.Any()).Return(nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.RequestPhoneLoginOtp(tt.ctx, phoneNumber, entity.OtpChannelWhatsapp); (err != nil) != tt.wantErr {
+				t.Errorf("Service.RequestPhoneLoginOtp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_LoginWithPhoneOtp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockUserRecord := mockUser.EXPECT()
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	redisRecord := redis.EXPECT()
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	s := &Service{
+		outbox:           mockOutbox,
+		transactor:       mockTransactor,
+		auditRepo:        newMockAudit(ctrl),
+		loginHistoryRepo: newMockLoginHistory(ctrl),
+		userRepository:   mockUser,
+		redis:            redis,
+	}
+	userId := uuid.New()
+	phoneNumber := "08123456789"
+	redisKey := "otp:phone-login:" + phoneNumber
+	attemptsKey := redisKey + ":attempts"
+	storedOtp := `{"OTP":"1234","Channel":"sms"}`
+	tests := []struct {
+		name    string
+		wantErr bool
+		mocks   []*gomock.Call
+	}{
+		{
+			name:    "error phone number not registered",
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(nil, repository.ErrUserNotFound),
+			},
+		},
+		{
+			name:    "error expired otp",
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(&entity.User{Id: userId, PhoneNumber: phoneNumber, IsActive: true}, nil),
+				redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(1), nil),
+				redisRecord.GetStringKey(gomock.Any(), redisKey).Return("", errors.New("redis: nil")),
+			},
+		},
+		{
+			name:    "success marks phone verified",
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(&entity.User{Id: userId, PhoneNumber: phoneNumber, IsActive: true}, nil),
+				redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(2), nil),
+				redisRecord.GetStringKey(gomock.Any(), redisKey).Return(storedOtp, nil),
+				redisRecord.Del(gomock.Any(), redisKey, attemptsKey).Return(nil),
+				mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					}),
+				mockUserRecord.VerifyPhoneNumber(gomock.Any(), userId).Return(nil),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+			},
+		},
+		{
+			name:    "success verified phone",
+			wantErr: false,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByPhoneNumber(gomock.Any(), phoneNumber).Return(&entity.User{Id: userId, PhoneNumber: phoneNumber, IsActive: true, IsPhoneVerified: true}, nil),
+				redisRecord.Incr(gomock.Any(), attemptsKey, otpLockout).Return(int64(1), nil),
+				redisRecord.GetStringKey(gomock.Any(), redisKey).Return(storedOtp, nil),
+				redisRecord.Del(gomock.Any(), redisKey, attemptsKey).Return(nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.LoginWithPhoneOtp(context.Background(), phoneNumber, 1234)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.LoginWithPhoneOtp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Id != userId || !got.IsPhoneVerified) {
+				t.Errorf("Service.LoginWithPhoneOtp() = %v, want verified user %v", got, userId)
+			}
+		})
+	}
+}
+
+func TestService_PhoneLoginOtpLockoutSurvivesReissue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	s := &Service{
+		auditRepo:        newMockAudit(ctrl),
+		loginHistoryRepo: newMockLoginHistory(ctrl),
+		userRepository:   mockUser,
+		redis:            redis,
+		outbox:           mockOutbox,
+		transactor:       mockTransactor,
+		notifiers:        Notifiers{entity.OtpChannelSms: notifier.NewFake()},
+	}
+	phoneNumber := "08123456789"
+	user := &entity.User{Id: uuid.New(), PhoneNumber: phoneNumber, IsVerified: true, IsActive: true, IsPhoneVerified: true}
+	mockUser.EXPECT().GetByPhoneNumber(gomock.Any(), phoneNumber).Return(user, nil).AnyTimes()
+	mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	// redis keeping its values in memory
+	values := map[string]string{}
+	counters := map[string]int64{}
+	redis.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+			values[key] = string(value.([]byte))
+			return nil
+		}).AnyTimes()
+	redis.EXPECT().GetStringKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string) (string, error) {
+			value, ok := values[key]
+			if !ok {
+				return "", r.Nil
+			}
+			return value, nil
+		}).AnyTimes()
+	redis.EXPECT().Incr(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+			counters[key]++
+			return counters[key], nil
+		}).AnyTimes()
+	redis.EXPECT().Del(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, keys ...string) error {
+			for _, key := range keys {
+				delete(values, key)
+				delete(counters, key)
+			}
+			return nil
+		}).AnyTimes()
+	issuedOtp := func() int {
+		var stored struct{ OTP string }
+		json.Unmarshal([]byte(values["otp:phone-login:"+phoneNumber]), &stored)
+		otp, _ := strconv.Atoi(stored.OTP)
+		return otp
+	}
+
+	if err := s.RequestPhoneLoginOtp(context.Background(), phoneNumber, entity.OtpChannelSms); err != nil {
+		t.Fatalf("Service.RequestPhoneLoginOtp() error = %v", err)
+	}
+	if err := s.RequestPhoneLoginOtp(context.Background(), phoneNumber, entity.OtpChannelSms); err == nil {
+		t.Errorf("Service.RequestPhoneLoginOtp() within the cooldown error = nil, want rate limited")
+	}
+	// another 4 digit code
+	wrongOtp := issuedOtp()%9000 + 1000
+	for i := 0; i < maxOtpAttempts; i++ {
+		if _, err := s.LoginWithPhoneOtp(context.Background(), phoneNumber, wrongOtp); err == nil {
+			t.Fatalf("Service.LoginWithPhoneOtp() with a wrong code error = nil")
+		}
+	}
+
+	// once the cooldown expired a new code is sent, it cannot be guessed further
+	delete(counters, "otp-cooldown:phone:"+phoneNumber)
+	if err := s.RequestPhoneLoginOtp(context.Background(), phoneNumber, entity.OtpChannelSms); err != nil {
+		t.Fatalf("Service.RequestPhoneLoginOtp() reissue error = %v", err)
+	}
+	_, err := s.LoginWithPhoneOtp(context.Background(), phoneNumber, issuedOtp())
+	if err == nil || !strings.Contains(err.Error(), pbErr.ErrorCode_AUTH_OTP_TOO_MANY_ATTEMPTS.String()) {
+		t.Errorf("Service.LoginWithPhoneOtp() after a reissue error = %v, want %v", err, pbErr.ErrorCode_AUTH_OTP_TOO_MANY_ATTEMPTS)
+	}
+}
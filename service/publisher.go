@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+)
+
+// EventPublisher publishes domain events to the other services
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entity.DomainEvent) error
+}
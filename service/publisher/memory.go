@@ -0,0 +1,56 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/google/uuid"
+)
+
+// Memory keeps published events in memory, for tests and local runs
+type Memory struct {
+	mu     sync.Mutex
+	err    error
+	events []*entity.DomainEvent
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// FailWith makes the next publications return err, nil restores successful publications
+func (m *Memory) FailWith(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+func (m *Memory) Publish(ctx context.Context, event *entity.DomainEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+// Events returns the events published so far
+func (m *Memory) Events() []*entity.DomainEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*entity.DomainEvent(nil), m.events...)
+}
+
+// Last returns the last event of type eventType published about userId
+func (m *Memory) Last(eventType string, userId uuid.UUID) *entity.DomainEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.events) - 1; i >= 0; i-- {
+		if m.events[i].Type == eventType && m.events[i].UserId == userId {
+			return m.events[i]
+		}
+	}
+	return nil
+}
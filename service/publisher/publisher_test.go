@@ -0,0 +1,76 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/config/tools/redis/mock"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRedisStream_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	redis := mock.NewMockRedisInterface(ctrl)
+	userId := uuid.New()
+	event, err := entity.NewDomainEvent(userId, &events.UserRegistered{UserId: userId.String(), Email: "test@mail.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		redisErr error
+		wantErr  bool
+	}{
+		{
+			name:     "error redis",
+			redisErr: errors.New("unavailable"),
+			wantErr:  true,
+		},
+		{
+			name:    "success",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redis.EXPECT().XAdd(gomock.Any(), "user.events", int64(10), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+					if values["id"] != event.Id.String() || values["type"] != "proto.events.v1.UserRegistered" || values["user_id"] != userId.String() {
+						t.Errorf("RedisStream.Publish() values = %v, want event %v", values, event.Id)
+					}
+					return "1-0", tt.redisErr
+				})
+			if err := NewRedisStream(redis, "user.events", 10).Publish(context.Background(), event); (err != nil) != tt.wantErr {
+				t.Errorf("RedisStream.Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemory_Publish(t *testing.T) {
+	m := NewMemory()
+	userId := uuid.New()
+	event, err := entity.NewDomainEvent(userId, &events.UserVerified{UserId: userId.String(), EmailVerified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.FailWith(errors.New("unavailable"))
+	if err := m.Publish(context.Background(), event); err == nil {
+		t.Errorf("Memory.Publish() error = nil, want unavailable")
+	}
+	m.FailWith(nil)
+	if err := m.Publish(context.Background(), event); err != nil {
+		t.Errorf("Memory.Publish() error = %v", err)
+	}
+	if got := m.Last("proto.events.v1.UserVerified", userId); got != event || len(m.Events()) != 1 {
+		t.Errorf("Memory.Last() = %v, want %v", got, event)
+	}
+	if got := m.Last("proto.events.v1.UserVerified", uuid.New()); got != nil {
+		t.Errorf("Memory.Last() = %v, want nil for another user", got)
+	}
+}
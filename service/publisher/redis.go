@@ -0,0 +1,39 @@
+package publisher
+
+import (
+	"context"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/tools/redis"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+)
+
+// DefaultStreamMaxLen bounds the stream, consumers lagging further behind miss events
+const DefaultStreamMaxLen = 100000
+
+// RedisStream appends events to a redis stream consumers read with consumer groups. Every entry
+// holds the fields id, type, user_id, occurred_at (RFC 3339) and payload, the protobuf encoded event.
+type RedisStream struct {
+	redis  redis.RedisInterface
+	stream string
+	maxLen int64
+}
+
+func NewRedisStream(redis redis.RedisInterface, stream string, maxLen int64) *RedisStream {
+	return &RedisStream{
+		redis:  redis,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (r *RedisStream) Publish(ctx context.Context, event *entity.DomainEvent) error {
+	_, err := r.redis.XAdd(ctx, r.stream, r.maxLen, map[string]interface{}{
+		"id":          event.Id.String(),
+		"type":        event.Type,
+		"user_id":     event.UserId.String(),
+		"occurred_at": event.OccurredAt.Format(time.RFC3339Nano),
+		"payload":     event.Payload,
+	})
+	return err
+}
@@ -8,15 +8,29 @@ import (
 	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 )
 
 type Service struct {
-	userRepository repository.User
-	roleRepo       repository.Role
-	hashing        tools.BcryptInterface
-	redis          redis.RedisInterface
-	auth           Authentication
+	userRepository       repository.User
+	roleRepo             repository.Role
+	mfaRepo              repository.Mfa
+	sessionRepo          repository.Session
+	auditRepo            repository.Audit
+	loginHistoryRepo     repository.LoginHistory
+	externalIdentityRepo repository.ExternalIdentity
+	oauthClientRepo      repository.OAuthClient
+	serviceClientRepo    repository.ServiceClient
+	outbox               repository.Outbox
+	transactor           repository.Transactor
+	hashing              tools.PasswordHasher
+	cipher               tools.CipherInterface
+	redis                redis.RedisInterface
+	auth                 Authentication
+	notifiers            Notifiers
+	socialLogin          SocialLogin
+	passwordPolicy       PasswordPolicy
 }
 
 var (
@@ -28,26 +42,93 @@ var (
 func New(
 	userRepository repository.User,
 	roleRepo repository.Role,
-	hashing tools.BcryptInterface,
+	mfaRepo repository.Mfa,
+	sessionRepo repository.Session,
+	auditRepo repository.Audit,
+	loginHistoryRepo repository.LoginHistory,
+	externalIdentityRepo repository.ExternalIdentity,
+	oauthClientRepo repository.OAuthClient,
+	serviceClientRepo repository.ServiceClient,
+	outbox repository.Outbox,
+	transactor repository.Transactor,
+	hashing tools.PasswordHasher,
+	cipher tools.CipherInterface,
 	redis redis.RedisInterface,
-	auth Authentication) *Service {
+	auth Authentication,
+	notifiers Notifiers,
+	socialLogin SocialLogin,
+	passwordPolicy PasswordPolicy) *Service {
 	return &Service{
-		userRepository: userRepository,
-		roleRepo:       roleRepo,
-		hashing:        hashing,
-		redis:          redis,
-		auth:           auth,
+		userRepository:       userRepository,
+		roleRepo:             roleRepo,
+		mfaRepo:              mfaRepo,
+		sessionRepo:          sessionRepo,
+		auditRepo:            auditRepo,
+		loginHistoryRepo:     loginHistoryRepo,
+		externalIdentityRepo: externalIdentityRepo,
+		oauthClientRepo:      oauthClientRepo,
+		serviceClientRepo:    serviceClientRepo,
+		outbox:               outbox,
+		transactor:           transactor,
+		hashing:              hashing,
+		cipher:               cipher,
+		redis:                redis,
+		auth:                 auth,
+		notifiers:            notifiers,
+		socialLogin:          socialLogin,
+		passwordPolicy:       passwordPolicy,
 	}
 }
 
 //go:generate mockgen -source=service.go -destination=mock/service.go -package=mock
 type ServiceInterface interface {
 	GetAll(ctx context.Context) ([]*entity.User, error)
+	GetUsersByIds(ctx context.Context, ids []uuid.UUID) ([]*entity.UserSummary, error)
+	GetUserByEmail(ctx context.Context, email string) (*entity.UserSummary, error)
+	GetUser(ctx context.Context, userId uuid.UUID) (*entity.User, error)
 	Login(ctx context.Context, payload entity.LoginRequest) (*entity.User, error)
-	Register(ctx context.Context, req *pb.UserRegisterRequest) (*entity.OtpMailReq, error)
+	Register(ctx context.Context, req *pb.UserRegisterRequest) error
 	CreateRole(ctx context.Context, role *entity.Role) error
 	GetRole(ctx context.Context) ([]entity.Role, error)
 	VerifyOTP(ctx context.Context, otp int, redisKey string) (user *entity.User, err error)
-	ResendOTP(ctx context.Context, email string) (*entity.OtpMailReq, error)
+	ResendOTP(ctx context.Context, email string, channel string) error
 	ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*entity.User, error)
+	SendPhoneVerificationOtp(ctx context.Context, userId uuid.UUID, channel string) error
+	VerifyPhone(ctx context.Context, userId uuid.UUID, otp int) (*entity.User, error)
+	RequestPhoneLoginOtp(ctx context.Context, phoneNumber string, channel string) error
+	LoginWithPhoneOtp(ctx context.Context, phoneNumber string, otp int) (*entity.User, error)
+	LoginWithGoogle(ctx context.Context, idToken string) (*entity.User, error)
+	RequestEmailChange(ctx context.Context, userId uuid.UUID, newEmail string) error
+	ConfirmEmailChange(ctx context.Context, userId uuid.UUID, otp int) (*entity.User, error)
+	EnrollMfa(ctx context.Context, userId uuid.UUID) (*entity.MfaEnrollment, error)
+	ConfirmMfa(ctx context.Context, userId uuid.UUID, code string) (recoveryCodes []string, err error)
+	CreateMfaChallenge(ctx context.Context, userId uuid.UUID) (mfaToken string, err error)
+	VerifyMfa(ctx context.Context, mfaToken string, code string) (*entity.User, error)
+	CreateSession(ctx context.Context, userId uuid.UUID, device entity.Device) (*entity.Session, error)
+	CreateOAuthSession(ctx context.Context, userId uuid.UUID, clientId string, device entity.Device) (*entity.Session, error)
+	RefreshSession(ctx context.Context, sessionId uuid.UUID, refreshTokenId uuid.UUID, clientId string) (*entity.User, *entity.Session, error)
+	ListSessions(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error)
+	AuthenticateAccessToken(ctx context.Context, token string) (*entity.TokenIntrospection, error)
+	IntrospectToken(ctx context.Context, token string) (*entity.TokenIntrospection, error)
+	RevokeSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error
+	DeactivateUser(ctx context.Context, adminId uuid.UUID, userId uuid.UUID) error
+	ReactivateUser(ctx context.Context, adminId uuid.UUID, userId uuid.UUID) error
+	SetUserRoles(ctx context.Context, adminId uuid.UUID, userId uuid.UUID, roleIds []string) error
+	DeleteAccount(ctx context.Context, userId uuid.UUID, password string) error
+	CheckUserActive(ctx context.Context, userId uuid.UUID) error
+	ExportUserData(ctx context.Context, userId uuid.UUID) (*entity.DataExport, error)
+	ListAuditEvents(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEvent, int64, error)
+	CreateOAuthClient(ctx context.Context, adminId uuid.UUID, name string, redirectUris []string, confidential bool) (*entity.OAuthClient, string, error)
+	GetOAuthClient(ctx context.Context, clientId string) (*entity.OAuthClient, error)
+	AuthenticateOAuthClient(ctx context.Context, clientId string, clientSecret string) (*entity.OAuthClient, error)
+	CreateAuthorizationCode(ctx context.Context, authorization *entity.AuthorizationCode) (string, error)
+	ExchangeAuthorizationCode(ctx context.Context, clientId string, code string, redirectUri string, codeVerifier string) (*entity.AuthorizationCode, error)
+	CreateServiceClient(ctx context.Context, adminId uuid.UUID, name string, scopes []string) (*entity.ServiceClient, string, error)
+	ListServiceClients(ctx context.Context) ([]*entity.ServiceClient, error)
+	RevokeServiceClient(ctx context.Context, adminId uuid.UUID, clientId uuid.UUID) error
+	AuthenticateServiceClient(ctx context.Context, apiKey string) (*entity.ServiceClient, error)
+	StartImpersonation(ctx context.Context, adminId uuid.UUID, userId uuid.UUID, reason string, device entity.Device) (*entity.User, *entity.Session, error)
+	StopImpersonation(ctx context.Context, adminId uuid.UUID, userId uuid.UUID, sessionId uuid.UUID) error
+	CheckSessionActive(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error
+	GetMyLoginHistory(ctx context.Context, userId uuid.UUID, page int, pageSize int) ([]*entity.LoginAttempt, int64, error)
 }
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// api keys read mk_<key prefix>_<secret>, the hex prefix finds the client of a key
+const apiKeyPrefixLength = 16
+
+// CreateServiceClient registers a service calling the rpcs granted by scopes. Its api key is only
+// returned here, its hash is stored.
+func (s *Service) CreateServiceClient(ctx context.Context, adminId uuid.UUID, name string, scopes []string) (*entity.ServiceClient, string, error) {
+	random := make([]byte, apiKeyPrefixLength/2)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	prefix := hex.EncodeToString(random)
+	apiKey := entity.APIKeyPrefix + prefix + "_" + secret
+	client := &entity.ServiceClient{
+		Name:      name,
+		KeyPrefix: prefix,
+		KeyHash:   hashClientSecret(apiKey),
+		Scopes:    scopes,
+		CreatedBy: adminId,
+	}
+	if err := s.serviceClientRepo.Create(ctx, client); err != nil {
+		return nil, "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditApiKeyCreated, adminId, uuid.Nil, map[string]string{
+		"client_id": client.Id.String(),
+		"name":      client.Name,
+		"scopes":    strings.Join(scopes, " "),
+	})
+	return client, apiKey, nil
+}
+
+func (s *Service) ListServiceClients(ctx context.Context) ([]*entity.ServiceClient, error) {
+	clients, err := s.serviceClientRepo.List(ctx)
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return clients, nil
+}
+
+// RevokeServiceClient disables the api key of clientId, calls made with it are refused right away
+func (s *Service) RevokeServiceClient(ctx context.Context, adminId uuid.UUID, clientId uuid.UUID) error {
+	if err := s.serviceClientRepo.Revoke(ctx, clientId); err != nil {
+		if errors.Is(err, repository.ErrServiceClientNotFound) {
+			ErrorCode = codes.NotFound
+			ErrorCodeDetail = pbErr.ErrorCode_SERVICE_CLIENT_NOT_FOUND.String()
+			ErrorMessage = "Service client tidak ditemukan"
+			return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		}
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditApiKeyRevoked, adminId, uuid.Nil, map[string]string{"client_id": clientId.String()})
+	return nil
+}
+
+// AuthenticateServiceClient returns the client of apiKey unless the key is unknown or revoked
+func (s *Service) AuthenticateServiceClient(ctx context.Context, apiKey string) (*entity.ServiceClient, error) {
+	rest, ok := strings.CutPrefix(apiKey, entity.APIKeyPrefix)
+	if !ok || len(rest) <= apiKeyPrefixLength || rest[apiKeyPrefixLength] != '_' {
+		return nil, apiKeyInvalidError()
+	}
+	client, err := s.serviceClientRepo.GetByKeyPrefix(ctx, rest[:apiKeyPrefixLength])
+	if err != nil {
+		if errors.Is(err, repository.ErrServiceClientNotFound) {
+			return nil, apiKeyInvalidError()
+		}
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	if client.IsRevoked() || subtle.ConstantTimeCompare([]byte(hashClientSecret(apiKey)), []byte(client.KeyHash)) != 1 {
+		return nil, apiKeyInvalidError()
+	}
+	return client, nil
+}
+
+func apiKeyInvalidError() error {
+	ErrorCode = codes.Unauthenticated
+	ErrorCodeDetail = pbErr.ErrorCode_AUTH_API_KEY_INVALID.String()
+	ErrorMessage = "API key tidak valid"
+	return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_CreateServiceClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockServiceClient := mock.NewMockServiceClient(ctrl)
+	s := &Service{
+		serviceClientRepo: mockServiceClient,
+		auditRepo:         newMockAudit(ctrl),
+	}
+	adminId := uuid.New()
+	scopes := []string{entity.ScopeUsersRead}
+
+	mockServiceClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	client, apiKey, err := s.CreateServiceClient(context.Background(), adminId, "order", scopes)
+	if err != nil {
+		t.Fatalf("Service.CreateServiceClient() error = %v", err)
+	}
+	if !strings.HasPrefix(apiKey, entity.APIKeyPrefix+client.KeyPrefix+"_") || client.KeyHash != hashClientSecret(apiKey) || !client.HasScope(entity.ScopeUsersRead) {
+		t.Errorf("Service.CreateServiceClient() = %v, %s, want client storing the hash of the key", client, apiKey)
+	}
+
+	mockServiceClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("any error"))
+	if _, _, err := s.CreateServiceClient(context.Background(), adminId, "order", scopes); err == nil {
+		t.Errorf("Service.CreateServiceClient() error = nil, want error")
+	}
+}
+
+func TestService_AuthenticateServiceClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockServiceClient := mock.NewMockServiceClient(ctrl)
+	s := &Service{serviceClientRepo: mockServiceClient}
+	apiKey := entity.APIKeyPrefix + "0123456789abcdef_secret"
+	client := &entity.ServiceClient{Id: uuid.New(), KeyPrefix: "0123456789abcdef", KeyHash: hashClientSecret(apiKey)}
+	revokedAt := time.Now()
+	revoked := &entity.ServiceClient{Id: uuid.New(), KeyPrefix: "0123456789abcdef", KeyHash: hashClientSecret(apiKey), RevokedAt: &revokedAt}
+	tests := []struct {
+		name    string
+		apiKey  string
+		want    *entity.ServiceClient
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error not an api key",
+			apiKey:  "eyJhbGciOiJIUzI1NiJ9",
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "error malformed api key",
+			apiKey:  entity.APIKeyPrefix + "0123456789abcdefsecret",
+			wantErr: true,
+			mocks:   func() {},
+		},
+		{
+			name:    "error unknown api key",
+			apiKey:  apiKey,
+			wantErr: true,
+			mocks: func() {
+				mockServiceClient.EXPECT().GetByKeyPrefix(gomock.Any(), "0123456789abcdef").Return(nil, repository.ErrServiceClientNotFound)
+			},
+		},
+		{
+			name:    "error wrong secret",
+			apiKey:  entity.APIKeyPrefix + "0123456789abcdef_other",
+			wantErr: true,
+			mocks: func() {
+				mockServiceClient.EXPECT().GetByKeyPrefix(gomock.Any(), "0123456789abcdef").Return(client, nil)
+			},
+		},
+		{
+			name:    "error revoked",
+			apiKey:  apiKey,
+			wantErr: true,
+			mocks: func() {
+				mockServiceClient.EXPECT().GetByKeyPrefix(gomock.Any(), "0123456789abcdef").Return(revoked, nil)
+			},
+		},
+		{
+			name:   "success",
+			apiKey: apiKey,
+			want:   client,
+			mocks: func() {
+				mockServiceClient.EXPECT().GetByKeyPrefix(gomock.Any(), "0123456789abcdef").Return(client, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.AuthenticateServiceClient(context.Background(), tt.apiKey)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Service.AuthenticateServiceClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Service.AuthenticateServiceClient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_RevokeServiceClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockServiceClient := mock.NewMockServiceClient(ctrl)
+	mockAudit := mock.NewMockAudit(ctrl)
+	s := &Service{
+		serviceClientRepo: mockServiceClient,
+		auditRepo:         mockAudit,
+	}
+	adminId := uuid.New()
+	clientId := uuid.New()
+
+	mockServiceClient.EXPECT().Revoke(gomock.Any(), clientId).Return(repository.ErrServiceClientNotFound)
+	if err := s.RevokeServiceClient(context.Background(), adminId, clientId); err == nil {
+		t.Errorf("Service.RevokeServiceClient() error = nil, want not found")
+	}
+
+	mockServiceClient.EXPECT().Revoke(gomock.Any(), clientId).Return(nil)
+	mockAudit.EXPECT().Create(gomock.Any(), gomock.Cond(func(x any) bool {
+		event := x.(*entity.AuditEvent)
+		return event.Action == entity.AuditApiKeyRevoked && event.ActorId.UUID == adminId
+	})).Return(nil)
+	if err := s.RevokeServiceClient(context.Background(), adminId, clientId); err != nil {
+		t.Errorf("Service.RevokeServiceClient() error = %v", err)
+	}
+}
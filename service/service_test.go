@@ -6,23 +6,48 @@ import (
 
 	"github.com/Mitra-Apps/be-user-service/config/tools"
 	"github.com/Mitra-Apps/be-user-service/config/tools/redis"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
 	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
 	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/Mitra-Apps/be-user-service/service/notifier"
 
 	"go.uber.org/mock/gomock"
 )
 
 func TestNew(t *testing.T) {
 	type args struct {
-		userRepository repository.User
-		roleRepo       repository.Role
-		hashing        tools.BcryptInterface
-		redis          redis.RedisInterface
-		auth           Authentication
+		userRepository       repository.User
+		roleRepo             repository.Role
+		mfaRepo              repository.Mfa
+		sessionRepo          repository.Session
+		auditRepo            repository.Audit
+		loginHistoryRepo     repository.LoginHistory
+		externalIdentityRepo repository.ExternalIdentity
+		oauthClientRepo      repository.OAuthClient
+		serviceClientRepo    repository.ServiceClient
+		outbox               repository.Outbox
+		transactor           repository.Transactor
+		hashing              tools.PasswordHasher
+		cipher               tools.CipherInterface
+		redis                redis.RedisInterface
+		auth                 Authentication
+		notifiers            Notifiers
+		socialLogin          SocialLogin
+		passwordPolicy       PasswordPolicy
 	}
 	ctrl := gomock.NewController(t)
 	mockUser := mock.NewMockUser(ctrl)
 	mockRole := mock.NewMockRole(ctrl)
+	mockMfa := mock.NewMockMfa(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	mockAudit := mock.NewMockAudit(ctrl)
+	mockLoginHistory := mock.NewMockLoginHistory(ctrl)
+	mockExternalIdentity := mock.NewMockExternalIdentity(ctrl)
+	mockOAuthClient := mock.NewMockOAuthClient(ctrl)
+	mockServiceClient := mock.NewMockServiceClient(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	notifiers := Notifiers{entity.OtpChannelEmail: notifier.NewFake()}
 	tests := []struct {
 		name string
 		args args
@@ -31,20 +56,59 @@ func TestNew(t *testing.T) {
 		{
 			name: "construct interface",
 			args: args{
-				userRepository: mockUser,
-				roleRepo:       mockRole,
+				userRepository:       mockUser,
+				roleRepo:             mockRole,
+				mfaRepo:              mockMfa,
+				sessionRepo:          mockSession,
+				auditRepo:            mockAudit,
+				loginHistoryRepo:     mockLoginHistory,
+				externalIdentityRepo: mockExternalIdentity,
+				oauthClientRepo:      mockOAuthClient,
+				serviceClientRepo:    mockServiceClient,
+				outbox:               mockOutbox,
+				transactor:           mockTransactor,
+				notifiers:            notifiers,
+				socialLogin:          SocialLogin{DefaultRole: "customer"},
+				passwordPolicy:       DefaultPasswordPolicy(),
 			},
 			want: &Service{
-				userRepository: mockUser,
-				roleRepo:       mockRole,
+				userRepository:       mockUser,
+				roleRepo:             mockRole,
+				mfaRepo:              mockMfa,
+				sessionRepo:          mockSession,
+				auditRepo:            mockAudit,
+				loginHistoryRepo:     mockLoginHistory,
+				externalIdentityRepo: mockExternalIdentity,
+				oauthClientRepo:      mockOAuthClient,
+				serviceClientRepo:    mockServiceClient,
+				outbox:               mockOutbox,
+				transactor:           mockTransactor,
+				notifiers:            notifiers,
+				socialLogin:          SocialLogin{DefaultRole: "customer"},
+				passwordPolicy:       DefaultPasswordPolicy(),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := New(tt.args.userRepository, tt.args.roleRepo, tt.args.hashing, tt.args.redis, tt.args.auth); !reflect.DeepEqual(got, tt.want) {
+			if got := New(tt.args.userRepository, tt.args.roleRepo, tt.args.mfaRepo, tt.args.sessionRepo, tt.args.auditRepo, tt.args.loginHistoryRepo, tt.args.externalIdentityRepo, tt.args.oauthClientRepo, tt.args.serviceClientRepo, tt.args.outbox, tt.args.transactor, tt.args.hashing, tt.args.cipher, tt.args.redis, tt.args.auth, tt.args.notifiers, tt.args.socialLogin, tt.args.passwordPolicy); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("New() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+// newMockAudit accepts any audit event, tests checking the recorded events set their own expectations
+func newMockAudit(ctrl *gomock.Controller) *mock.MockAudit {
+	mockAudit := mock.NewMockAudit(ctrl)
+	mockAudit.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return mockAudit
+}
+
+// newMockLoginHistory accepts any login attempt from known devices
+func newMockLoginHistory(ctrl *gomock.Controller) *mock.MockLoginHistory {
+	mockLoginHistory := mock.NewMockLoginHistory(ctrl)
+	mockLoginHistory.EXPECT().HasSucceeded(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockLoginHistory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return mockLoginHistory
+}
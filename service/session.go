@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// sessionLifetime is how long a session can go without refreshing its tokens
+const sessionLifetime = 30 * 24 * time.Hour
+
+// CreateSession records a login of userId from device
+func (s *Service) CreateSession(ctx context.Context, userId uuid.UUID, device entity.Device) (*entity.Session, error) {
+	return s.createSession(ctx, userId, "", device)
+}
+
+// CreateOAuthSession records a login of userId to the oauth client clientId from device
+func (s *Service) CreateOAuthSession(ctx context.Context, userId uuid.UUID, clientId string, device entity.Device) (*entity.Session, error) {
+	return s.createSession(ctx, userId, clientId, device)
+}
+
+func (s *Service) createSession(ctx context.Context, userId uuid.UUID, clientId string, device entity.Device) (*entity.Session, error) {
+	now := time.Now()
+	session := &entity.Session{
+		UserId:         userId,
+		OAuthClientId:  clientId,
+		DeviceName:     truncate(device.Name, 255),
+		UserAgent:      truncate(device.UserAgent, 512),
+		IpAddress:      truncate(device.IpAddress, 64),
+		RefreshTokenId: uuid.New(),
+		CreatedAt:      now,
+		LastSeenAt:     now,
+		ExpiresAt:      now.Add(sessionLifetime),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return session, nil
+}
+
+// RefreshSession rotates the refresh token id of a session. A refresh token presented after it
+// was already rotated has leaked, the whole session is revoked then. clientId is the oauth client
+// refreshing the session, empty for the first party apps, it must be the one the session was opened for.
+func (s *Service) RefreshSession(ctx context.Context, sessionId uuid.UUID, refreshTokenId uuid.UUID, clientId string) (*entity.User, *entity.Session, error) {
+	session, err := s.sessionRepo.GetByID(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return nil, nil, sessionInvalidError()
+		}
+		return nil, nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	now := time.Now()
+	// impersonations end with their single access token
+	if !session.IsActive(now) || session.ImpersonatorId.Valid {
+		return nil, nil, sessionInvalidError()
+	}
+	if session.OAuthClientId != clientId {
+		logger.FromContext(ctx).WithField("session_id", session.Id.String()).Warn("refresh token presented by another client")
+		return nil, nil, sessionInvalidError()
+	}
+	if session.RefreshTokenId != refreshTokenId {
+		s.revokeReusedSession(ctx, session)
+		return nil, nil, sessionInvalidError()
+	}
+
+	user, err := s.getUserByID(ctx, session.UserId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkUserActive(user); err != nil {
+		return nil, nil, err
+	}
+	session.RefreshTokenId = uuid.New()
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(sessionLifetime)
+	if err := s.sessionRepo.Rotate(ctx, session, refreshTokenId); err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			// a concurrent refresh used the same token first
+			s.revokeReusedSession(ctx, session)
+			return nil, nil, sessionInvalidError()
+		}
+		return nil, nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return user, session, nil
+}
+
+// ListSessions returns the active sessions of userId
+func (s *Service) ListSessions(ctx context.Context, userId uuid.UUID) ([]*entity.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, userId)
+	if err != nil {
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs sessionId of userId out, its refresh and access tokens are refused right away
+func (s *Service) RevokeSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error {
+	if err := s.sessionRepo.Revoke(ctx, userId, sessionId); err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			ErrorCode = codes.NotFound
+			ErrorCodeDetail = pbErr.ErrorCode_AUTH_SESSION_INVALID.String()
+			ErrorMessage = "Sesi tidak ditemukan"
+			return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		}
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	return nil
+}
+
+func (s *Service) revokeReusedSession(ctx context.Context, session *entity.Session) {
+	log := logger.FromContext(ctx).WithField("session_id", session.Id.String())
+	log.Warn("refresh token reused, revoking session")
+	if err := s.sessionRepo.Revoke(ctx, session.UserId, session.Id); err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+		log.WithError(err).Error("failed to revoke session")
+	}
+}
+
+// truncate cuts s to at most n characters, device details come from client headers
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+func sessionInvalidError() error {
+	ErrorCode = codes.Unauthenticated
+	ErrorCodeDetail = pbErr.ErrorCode_AUTH_SESSION_INVALID.String()
+	ErrorMessage = "Sesi telah berakhir, silahkan login kembali"
+	return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_CreateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSession := mock.NewMockSession(ctrl)
+	s := &Service{sessionRepo: mockSession}
+	userId := uuid.New()
+	device := entity.Device{Name: "Pixel 8", UserAgent: "okhttp/4.12", IpAddress: "203.0.113.7"}
+
+	mockSession.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	got, err := s.CreateSession(context.Background(), userId, device)
+	if err != nil {
+		t.Fatalf("Service.CreateSession() error = %v", err)
+	}
+	if got.UserId != userId || got.DeviceName != device.Name || got.RefreshTokenId == uuid.Nil || !got.IsActive(time.Now()) {
+		t.Errorf("Service.CreateSession() = %v, want active session of %v from %v", got, userId, device)
+	}
+
+	mockSession.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("any error"))
+	if _, err := s.CreateSession(context.Background(), userId, device); err == nil {
+		t.Errorf("Service.CreateSession() error = nil, want error")
+	}
+
+	mockSession.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	got, err = s.CreateOAuthSession(context.Background(), userId, "client", device)
+	if err != nil || got.UserId != userId || got.OAuthClientId != "client" {
+		t.Errorf("Service.CreateOAuthSession() = %v, %v, want session of %v for client", got, err, userId)
+	}
+}
+
+func TestService_RefreshSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockSession := mock.NewMockSession(ctrl)
+	s := &Service{
+		userRepository: mockUser,
+		sessionRepo:    mockSession,
+	}
+	userId := uuid.New()
+	sessionId := uuid.New()
+	refreshTokenId := uuid.New()
+	newSession := func() *entity.Session {
+		return &entity.Session{
+			Id:             sessionId,
+			UserId:         userId,
+			RefreshTokenId: refreshTokenId,
+			ExpiresAt:      time.Now().Add(time.Hour),
+		}
+	}
+	revokedAt := time.Now()
+	tests := []struct {
+		name           string
+		refreshTokenId uuid.UUID
+		clientId       string
+		wantErr        bool
+		mocks          func()
+	}{
+		{
+			name:           "error session not found",
+			refreshTokenId: refreshTokenId,
+			wantErr:        true,
+			mocks: func() {
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(nil, repository.ErrSessionNotFound)
+			},
+		},
+		{
+			name:           "error session revoked",
+			refreshTokenId: refreshTokenId,
+			wantErr:        true,
+			mocks: func() {
+				session := newSession()
+				session.RevokedAt = &revokedAt
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(session, nil)
+			},
+		},
+		{
+			name:           "error impersonation session",
+			refreshTokenId: refreshTokenId,
+			wantErr:        true,
+			mocks: func() {
+				session := newSession()
+				session.ImpersonatorId = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(session, nil)
+			},
+		},
+		{
+			name:           "error session of an oauth client refreshed by the app",
+			refreshTokenId: refreshTokenId,
+			wantErr:        true,
+			mocks: func() {
+				session := newSession()
+				session.OAuthClientId = "client"
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(session, nil)
+			},
+		},
+		{
+			name:           "error session refreshed by another oauth client",
+			refreshTokenId: refreshTokenId,
+			clientId:       "other",
+			wantErr:        true,
+			mocks: func() {
+				session := newSession()
+				session.OAuthClientId = "client"
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(session, nil)
+			},
+		},
+		{
+			name:           "error session of the app refreshed by an oauth client",
+			refreshTokenId: refreshTokenId,
+			clientId:       "client",
+			wantErr:        true,
+			mocks: func() {
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(newSession(), nil)
+			},
+		},
+		{
+			name:           "error reused refresh token revokes session",
+			refreshTokenId: uuid.New(),
+			wantErr:        true,
+			mocks: func() {
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(newSession(), nil)
+				mockSession.EXPECT().Revoke(gomock.Any(), userId, sessionId).Return(nil)
+			},
+		},
+		{
+			name:           "error concurrent refresh revokes session",
+			refreshTokenId: refreshTokenId,
+			wantErr:        true,
+			mocks: func() {
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(newSession(), nil)
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId, IsActive: true}, nil)
+				mockSession.EXPECT().Rotate(gomock.Any(), gomock.Any(), refreshTokenId).Return(repository.ErrSessionNotFound)
+				mockSession.EXPECT().Revoke(gomock.Any(), userId, sessionId).Return(nil)
+			},
+		},
+		{
+			name:           "success",
+			refreshTokenId: refreshTokenId,
+			wantErr:        false,
+			mocks: func() {
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(newSession(), nil)
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId, IsActive: true}, nil)
+				mockSession.EXPECT().Rotate(gomock.Any(), gomock.Any(), refreshTokenId).Return(nil)
+			},
+		},
+		{
+			name:           "success oauth client",
+			refreshTokenId: refreshTokenId,
+			clientId:       "client",
+			wantErr:        false,
+			mocks: func() {
+				session := newSession()
+				session.OAuthClientId = "client"
+				mockSession.EXPECT().GetByID(gomock.Any(), sessionId).Return(session, nil)
+				mockUser.EXPECT().GetByID(gomock.Any(), userId).Return(&entity.User{Id: userId, IsActive: true}, nil)
+				mockSession.EXPECT().Rotate(gomock.Any(), gomock.Any(), refreshTokenId).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			user, session, err := s.RefreshSession(context.Background(), sessionId, tt.refreshTokenId, tt.clientId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.RefreshSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if user.Id != userId || session.RefreshTokenId == refreshTokenId {
+				t.Errorf("Service.RefreshSession() = %v, %v, want user %v and a rotated refresh token id", user, session, userId)
+			}
+		})
+	}
+}
+
+func TestService_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSession := mock.NewMockSession(ctrl)
+	s := &Service{sessionRepo: mockSession}
+	userId := uuid.New()
+	sessionId := uuid.New()
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr bool
+	}{
+		{
+			name:    "error session not found",
+			repoErr: repository.ErrSessionNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "error revoke",
+			repoErr: errors.New("any error"),
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSession.EXPECT().Revoke(gomock.Any(), userId, sessionId).Return(tt.repoErr)
+			if err := s.RevokeSession(context.Background(), userId, sessionId); (err != nil) != tt.wantErr {
+				t.Errorf("Service.RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/metrics"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// SocialLogin configures signing in with the accounts of external identity providers
+type SocialLogin struct {
+	// Google verifies google id tokens, google login is disabled when nil
+	Google IdentityVerifier
+	// DefaultRole is the name of the role given to the users created on their first social login
+	DefaultRole string
+}
+
+// LoginWithGoogle signs in the user linked to the google account idToken was issued to. On its first
+// login the account is linked to the user registered with its email, or to a new user when there is none.
+func (s *Service) LoginWithGoogle(ctx context.Context, idToken string) (*entity.User, error) {
+	if s.socialLogin.Google == nil {
+		return nil, util.NewError(codes.Unavailable, pbErr.ErrorCode_UNKNOWN.String(), "Login dengan Google belum tersedia")
+	}
+	claims, err := s.socialLogin.Google.Verify(ctx, idToken)
+	if err != nil {
+		if isInvalidIdToken(err) {
+			metrics.LoginTotal.WithLabelValues(metrics.LoginInvalidToken).Inc()
+			s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, uuid.Nil, map[string]string{"reason": metrics.LoginInvalidToken, "method": entity.LoginMethodGoogle})
+			ErrorCode = codes.Unauthenticated
+			ErrorCodeDetail = pbErr.ErrorCode_AUTH_SOCIAL_TOKEN_INVALID.String()
+			ErrorMessage = "Token Google tidak valid"
+			return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		}
+		metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
+		logger.FromContext(ctx).WithError(err).Error("failed to verify google id token")
+		return nil, util.NewError(codes.Unavailable, pbErr.ErrorCode_UNKNOWN.String(), "Gagal memverifikasi akun Google")
+	}
+
+	user, err := s.socialLoginUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserActive(user); err != nil {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginInactive).Inc()
+		s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": metrics.LoginInactive})
+		s.recordLoginAttempt(ctx, user, entity.LoginMethodGoogle, metrics.LoginInactive)
+		return nil, err
+	}
+	metrics.LoginTotal.WithLabelValues(metrics.LoginSuccess).Inc()
+	s.audit(ctx, entity.AuditLoginSucceeded, user.Id, user.Id, map[string]string{"method": entity.LoginMethodGoogle})
+	s.recordLoginAttempt(ctx, user, entity.LoginMethodGoogle, "")
+	return user, nil
+}
+
+// socialLoginUser returns the user linked to the account of claims, linking the account on its first login
+func (s *Service) socialLoginUser(ctx context.Context, claims *entity.ExternalClaims) (*entity.User, error) {
+	identity, err := s.externalIdentityRepo.GetByProviderSubject(ctx, claims.Provider, claims.Subject)
+	if err == nil {
+		return s.getUserByID(ctx, identity.UserId)
+	}
+	if !errors.Is(err, repository.ErrExternalIdentityNotFound) {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+
+	// only an email verified by the provider proves the account owns it
+	if claims.Email == "" || !claims.EmailVerified {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginUnverified).Inc()
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_SOCIAL_EMAIL_UNVERIFIED.String()
+		ErrorMessage = "Email akun Google belum terverifikasi"
+		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	user, err := s.userRepository.GetByEmail(ctx, claims.Email)
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+		return s.createSocialUser(ctx, claims)
+	case err != nil:
+		metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+
+	// whoever registered an unverified email may not own it, linking would leave them
+	// a password to the account once the email gets verified
+	if !user.IsVerified {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginUnverified).Inc()
+		s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": metrics.LoginUnverified, "method": entity.LoginMethodGoogle})
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_LOGIN_USER_UNVERIFIED.String()
+		ErrorMessage = "Email sudah terdaftar, silahkan lakukan verifikasi OTP"
+		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	if err := s.externalIdentityRepo.Create(ctx, newExternalIdentity(user.Id, claims)); err != nil {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	s.audit(ctx, entity.AuditIdentityLinked, user.Id, user.Id, map[string]string{"provider": claims.Provider})
+	return user, nil
+}
+
+// createSocialUser registers the owner of the account of claims with the default role, the email
+// is verified by the provider and the user has no password
+func (s *Service) createSocialUser(ctx context.Context, claims *entity.ExternalClaims) (*entity.User, error) {
+	roleId, err := s.defaultRoleId(ctx)
+	if err != nil {
+		metrics.RegistrationTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		return nil, err
+	}
+	name := claims.Name
+	if name == "" {
+		name = claims.Email
+	}
+	userId := uuid.New()
+	user := &entity.User{
+		Id:         userId,
+		CreatedBy:  userId,
+		Email:      claims.Email,
+		Username:   claims.Email,
+		Name:       name,
+		IsVerified: true,
+	}
+	roleIds := []string{roleId}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Create(ctx, user, roleIds); err != nil {
+			return err
+		}
+		if err := s.externalIdentityRepo.Create(ctx, newExternalIdentity(user.Id, claims)); err != nil {
+			return err
+		}
+		if err := s.publishEvent(ctx, user.Id, &events.UserRegistered{
+			UserId:  user.Id.String(),
+			Email:   user.Email,
+			Name:    user.Name,
+			RoleIds: roleIds,
+		}); err != nil {
+			return err
+		}
+		return s.publishEvent(ctx, user.Id, &events.UserVerified{
+			UserId:        user.Id.String(),
+			EmailVerified: true,
+		})
+	})
+	if err != nil {
+		metrics.RegistrationTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	metrics.RegistrationTotal.WithLabelValues(metrics.OutcomeSuccess).Inc()
+	s.audit(ctx, entity.AuditUserRegistered, user.Id, user.Id, map[string]string{"roles": roleId, "provider": claims.Provider})
+	return user, nil
+}
+
+// defaultRoleId returns the id of the active role named as the default role of social logins
+func (s *Service) defaultRoleId(ctx context.Context) (string, error) {
+	roles, err := s.roleRepo.GetRole(ctx)
+	if err != nil {
+		return "", util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	for _, role := range roles {
+		if role.IsActive && role.RoleName == s.socialLogin.DefaultRole {
+			return strconv.Itoa(int(role.ID)), nil
+		}
+	}
+	logger.FromContext(ctx).WithField("role", s.socialLogin.DefaultRole).Error("default role of social logins not found")
+	return "", util.NewError(codes.Internal, pbErr.ErrorCode_RECORD_NOT_FOUND.String(), "Role tidak ditemukan")
+}
+
+func newExternalIdentity(userId uuid.UUID, claims *entity.ExternalClaims) *entity.ExternalIdentity {
+	return &entity.ExternalIdentity{
+		UserId:   userId,
+		Provider: claims.Provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// fakeVerifier returns claims for any token, or err
+type fakeVerifier struct {
+	claims *entity.ExternalClaims
+	err    error
+}
+
+func (f *fakeVerifier) Verify(ctx context.Context, idToken string) (*entity.ExternalClaims, error) {
+	return f.claims, f.err
+}
+
+type invalidIdToken struct{}
+
+func (invalidIdToken) Error() string      { return "invalid id token" }
+func (invalidIdToken) InvalidToken() bool { return true }
+
+func TestService_LoginWithGoogle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	mockRole := mock.NewMockRole(ctrl)
+	mockIdentity := mock.NewMockExternalIdentity(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	inTransaction := func() *gomock.Call {
+		return mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	claims := &entity.ExternalClaims{
+		Provider:      entity.IdentityProviderGoogle,
+		Subject:       "108123456789",
+		Email:         "test@gmail.com",
+		EmailVerified: true,
+		Name:          "Test",
+	}
+	user := &entity.User{Id: uuid.New(), Email: "test@gmail.com", IsActive: true, IsVerified: true}
+	roles := []entity.Role{
+		{Model: gorm.Model{ID: 1}, RoleName: "merchant", IsActive: true},
+		{Model: gorm.Model{ID: 2}, RoleName: "customer", IsActive: true},
+	}
+	linked := func(userId uuid.UUID) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			identity := x.(*entity.ExternalIdentity)
+			return (userId == uuid.Nil || identity.UserId == userId) && identity.Provider == claims.Provider &&
+				identity.Subject == claims.Subject && identity.Email == claims.Email
+		})
+	}
+	tests := []struct {
+		name     string
+		verifier IdentityVerifier
+		wantCode codes.Code
+		wantUser func(got *entity.User) bool
+		mocks    func()
+	}{
+		{
+			name:     "error google login disabled",
+			wantCode: codes.Unavailable,
+			mocks:    func() {},
+		},
+		{
+			name:     "error invalid token",
+			verifier: &fakeVerifier{err: invalidIdToken{}},
+			wantCode: codes.Unauthenticated,
+			mocks:    func() {},
+		},
+		{
+			name:     "error keys unavailable",
+			verifier: &fakeVerifier{err: errors.New("fetch jwks: status 500")},
+			wantCode: codes.Unavailable,
+			mocks:    func() {},
+		},
+		{
+			name:     "linked account",
+			verifier: &fakeVerifier{claims: claims},
+			wantUser: func(got *entity.User) bool { return got == user },
+			mocks: func() {
+				mockIdentity.EXPECT().GetByProviderSubject(gomock.Any(), "google", claims.Subject).Return(&entity.ExternalIdentity{UserId: user.Id}, nil)
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(user, nil)
+			},
+		},
+		{
+			name:     "error linked account deactivated",
+			verifier: &fakeVerifier{claims: claims},
+			wantCode: codes.PermissionDenied,
+			mocks: func() {
+				mockIdentity.EXPECT().GetByProviderSubject(gomock.Any(), "google", claims.Subject).Return(&entity.ExternalIdentity{UserId: user.Id}, nil)
+				mockUser.EXPECT().GetByID(gomock.Any(), user.Id).Return(&entity.User{Id: user.Id, IsVerified: true}, nil)
+			},
+		},
+		{
+			name:     "error email not verified by google",
+			verifier: &fakeVerifier{claims: &entity.ExternalClaims{Provider: "google", Subject: claims.Subject, Email: claims.Email}},
+			wantCode: codes.InvalidArgument,
+			mocks: func() {
+				mockIdentity.EXPECT().GetByProviderSubject(gomock.Any(), "google", claims.Subject).Return(nil, repository.ErrExternalIdentityNotFound)
+			},
+		},
+		{
+			name:     "error registered email not verified",
+			verifier: &fakeVerifier{claims: claims},
+			wantCode: codes.InvalidArgument,
+			mocks: func() {
+				mockIdentity.EXPECT().GetByProviderSubject(gomock.Any(), "google", claims.Subject).Return(nil, repository.ErrExternalIdentityNotFound)
+				mockUser.EXPECT().GetByEmail(gomock.Any(), claims.Email).Return(&entity.User{Id: user.Id, IsActive: true}, nil)
+			},
+		},
+		{
+			name:     "links registered user",
+			verifier: &fakeVerifier{claims: claims},
+			wantUser: func(got *entity.User) bool { return got == user },
+			mocks: func() {
+				mockIdentity.EXPECT().GetByProviderSubject(gomock.Any(), "google", claims.Subject).Return(nil, repository.ErrExternalIdentityNotFound)
+				mockUser.EXPECT().GetByEmail(gomock.Any(), claims.Email).Return(user, nil)
+				mockIdentity.EXPECT().Create(gomock.Any(), linked(user.Id)).Return(nil)
+			},
+		},
+		{
+			name:     "error default role missing",
+			verifier: &fakeVerifier{claims: claims},
+			wantCode: codes.Internal,
+			mocks: func() {
+				mockIdentity.EXPECT().GetByProviderSubject(gomock.Any(), "google", claims.Subject).Return(nil, repository.ErrExternalIdentityNotFound)
+				mockUser.EXPECT().GetByEmail(gomock.Any(), claims.Email).Return(nil, repository.ErrUserNotFound)
+				mockRole.EXPECT().GetRole(gomock.Any()).Return(roles[:1], nil)
+			},
+		},
+		{
+			name:     "error creating user",
+			verifier: &fakeVerifier{claims: claims},
+			wantCode: codes.Internal,
+			mocks: func() {
+				mockIdentity.EXPECT().GetByProviderSubject(gomock.Any(), "google", claims.Subject).Return(nil, repository.ErrExternalIdentityNotFound)
+				mockUser.EXPECT().GetByEmail(gomock.Any(), claims.Email).Return(nil, repository.ErrUserNotFound)
+				mockRole.EXPECT().GetRole(gomock.Any()).Return(roles, nil)
+				inTransaction()
+				mockUser.EXPECT().Create(gomock.Any(), gomock.Any(), []string{"2"}).Return(nil)
+				mockIdentity.EXPECT().Create(gomock.Any(), linked(uuid.Nil)).Return(errors.New("any error"))
+			},
+		},
+		{
+			name:     "creates verified user",
+			verifier: &fakeVerifier{claims: claims},
+			wantUser: func(got *entity.User) bool {
+				return got.Email == claims.Email && got.Username == claims.Email && got.Name == claims.Name &&
+					got.IsVerified && got.Password == "" && got.PhoneNumber == "" && got.CreatedBy == got.Id
+			},
+			mocks: func() {
+				mockIdentity.EXPECT().GetByProviderSubject(gomock.Any(), "google", claims.Subject).Return(nil, repository.ErrExternalIdentityNotFound)
+				mockUser.EXPECT().GetByEmail(gomock.Any(), claims.Email).Return(nil, repository.ErrUserNotFound)
+				mockRole.EXPECT().GetRole(gomock.Any()).Return(roles, nil)
+				inTransaction()
+				mockUser.EXPECT().Create(gomock.Any(), gomock.Any(), []string{"2"}).DoAndReturn(func(ctx context.Context, created *entity.User, roleIds []string) error {
+					// the database marks new users active
+					created.IsActive = true
+					return nil
+				})
+				mockIdentity.EXPECT().Create(gomock.Any(), linked(uuid.Nil)).Return(nil)
+				// registered and verified events
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			s := &Service{
+				userRepository:       mockUser,
+				roleRepo:             mockRole,
+				externalIdentityRepo: mockIdentity,
+				outbox:               mockOutbox,
+				transactor:           mockTransactor,
+				auditRepo:            newMockAudit(ctrl),
+				loginHistoryRepo:     newMockLoginHistory(ctrl),
+				socialLogin:          SocialLogin{DefaultRole: "customer"},
+			}
+			if tt.verifier != nil {
+				s.socialLogin.Google = tt.verifier
+			}
+			got, err := s.LoginWithGoogle(context.Background(), "idToken")
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Service.LoginWithGoogle() error = %v, want code %v", err, tt.wantCode)
+				return
+			}
+			if tt.wantUser != nil && (got == nil || !tt.wantUser(got)) {
+				t.Errorf("Service.LoginWithGoogle() = %+v", got)
+			}
+		})
+	}
+}
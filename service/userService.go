@@ -3,22 +3,38 @@ package service
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"log"
+	"errors"
 	"math/rand"
 	"strconv"
 	"strings"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/metrics"
 	"github.com/Mitra-Apps/be-user-service/config/tools"
 	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
 	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
 	"github.com/Mitra-Apps/be-user-service/handler/middleware"
 	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+)
+
+const (
+	otpExpiration = 5 * time.Minute
+	// wrong codes accepted for a single phone otp before it is dropped
+	maxOtpAttempts = 5
+	// window the wrong codes are counted in, whatever the codes issued meanwhile
+	otpLockout = time.Hour
+	// delay before another code is sent to the same phone number or for the same email change
+	otpCooldown = time.Minute
+	// codes requested from a single ip within otpIpWindow
+	maxOtpsPerIp = 10
+	otpIpWindow  = time.Hour
 )
 
 func (s *Service) GetAll(ctx context.Context) ([]*entity.User, error) {
@@ -33,11 +49,14 @@ func (s *Service) Login(ctx context.Context, payload entity.LoginRequest) (*enti
 
 	user, err := s.userRepository.GetByEmail(ctx, payload.Email)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			metrics.LoginTotal.WithLabelValues(metrics.LoginNotFound).Inc()
+			s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, uuid.Nil, map[string]string{"reason": metrics.LoginNotFound})
 			ErrorCode = codes.NotFound
 			ErrorCodeDetail = pbErr.ErrorCode_AUTH_LOGIN_NOT_FOUND.String()
 			ErrorMessage = "Email belum terdaftar, mohon registrasi"
 		} else {
+			metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
 			ErrorCode = codes.Internal
 			ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
 			ErrorMessage = err.Error()
@@ -46,19 +65,28 @@ func (s *Service) Login(ctx context.Context, payload entity.LoginRequest) (*enti
 	}
 
 	if user.WrongPasswordCounter >= 3 {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginLocked).Inc()
+		s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": metrics.LoginLocked})
+		s.recordLoginAttempt(ctx, user, entity.LoginMethodPassword, metrics.LoginLocked)
 		ErrorCode = codes.InvalidArgument
 		ErrorCodeDetail = pbErr.ErrorCode_AUTH_LOGIN_PASSWORD_INCORRECT_3X.String()
 		ErrorMessage = "Anda telah melebihi limit kesalahan kata sandi, mohon ganti sandi anda"
 		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
 
-	if err := s.hashing.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
+	if err := s.hashing.Compare(user.Password, []byte(payload.Password)); err != nil {
 		user.WrongPasswordCounter++
 		if err = s.userRepository.Save(ctx, user); err != nil {
+			metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
 			return nil, util.NewError(codes.Internal, codes.Unknown.String(), err.Error())
 		}
+		metrics.LoginTotal.WithLabelValues(metrics.LoginWrongPassword).Inc()
+		s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": metrics.LoginWrongPassword})
+		s.recordLoginAttempt(ctx, user, entity.LoginMethodPassword, metrics.LoginWrongPassword)
 		ErrorCode = codes.InvalidArgument
 		if user.WrongPasswordCounter >= 3 {
+			metrics.LockoutTotal.Inc()
+			s.audit(ctx, entity.AuditUserLockedOut, uuid.Nil, user.Id, nil)
 			ErrorCodeDetail = pbErr.ErrorCode_AUTH_LOGIN_PASSWORD_INCORRECT_3X.String()
 			ErrorMessage = "Anda telah melebihi limit kesalahan kata sandi, mohon ganti sandi anda"
 			return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
@@ -68,7 +96,17 @@ func (s *Service) Login(ctx context.Context, payload entity.LoginRequest) (*enti
 		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
 
+	if err := checkUserActive(user); err != nil {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginInactive).Inc()
+		s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": metrics.LoginInactive})
+		s.recordLoginAttempt(ctx, user, entity.LoginMethodPassword, metrics.LoginInactive)
+		return nil, err
+	}
+
 	if !user.IsVerified {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginUnverified).Inc()
+		s.audit(ctx, entity.AuditLoginFailed, uuid.Nil, user.Id, map[string]string{"reason": metrics.LoginUnverified})
+		s.recordLoginAttempt(ctx, user, entity.LoginMethodPassword, metrics.LoginUnverified)
 		ErrorCode = codes.InvalidArgument
 		ErrorCodeDetail = pbErr.ErrorCode_AUTH_LOGIN_USER_UNVERIFIED.String()
 		ErrorMessage = "Email sudah terdaftar, silahkan lakukan verifikasi OTP"
@@ -76,23 +114,50 @@ func (s *Service) Login(ctx context.Context, payload entity.LoginRequest) (*enti
 	}
 
 	user.WrongPasswordCounter = 0
+	// hashes of an older algorithm or weaker parameters are upgraded while the password is at hand
+	if s.hashing.NeedsRehash(user.Password) {
+		if hashedPassword, err := s.hashing.Hash([]byte(payload.Password)); err != nil {
+			logger.FromContext(ctx).WithError(err).Warn("failed to rehash password")
+		} else {
+			user.Password = hashedPassword
+		}
+	}
 	if err = s.userRepository.Save(ctx, user); err != nil {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginError).Inc()
 		return nil, util.NewError(codes.Internal, codes.Unknown.String(), err.Error())
 	}
 
+	metrics.LoginTotal.WithLabelValues(metrics.LoginSuccess).Inc()
+	// logins with 2FA are recorded once the second factor is verified
+	if !user.IsMfaEnabled {
+		s.audit(ctx, entity.AuditLoginSucceeded, user.Id, user.Id, map[string]string{"method": entity.LoginMethodPassword})
+		s.recordLoginAttempt(ctx, user, entity.LoginMethodPassword, "")
+	}
 	return user, nil
 }
 
-func (s *Service) Register(ctx context.Context, req *pb.UserRegisterRequest) (*entity.OtpMailReq, error) {
+func (s *Service) Register(ctx context.Context, req *pb.UserRegisterRequest) error {
+	channel := entity.OtpChannelFromProto(req.OtpChannel)
+	if err := s.checkOtpChannel(channel); err != nil {
+		return err
+	}
+	if err := s.passwordPolicy.Check(req.Password, req.Email, req.Name); err != nil {
+		return err
+	}
+
 	//hashing password
-	hashedPassword, err := s.hashing.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := s.hashing.Hash([]byte(req.Password))
 	if err != nil {
-		return nil, err
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
 	}
 
+	// users register themselves, the id is known upfront to record it as creator
+	userId := uuid.New()
 	user := &entity.User{
+		Id:          userId,
+		CreatedBy:   userId,
 		Email:       req.Email,
-		Password:    string(hashedPassword),
+		Password:    hashedPassword,
 		Username:    req.Email,
 		PhoneNumber: req.PhoneNumber,
 		Name:        req.Name,
@@ -100,67 +165,85 @@ func (s *Service) Register(ctx context.Context, req *pb.UserRegisterRequest) (*e
 	}
 
 	data, err := s.userRepository.GetByEmail(ctx, req.Email)
-	if err != nil && !strings.Contains(err.Error(), "not found") {
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
 		ErrorCode = codes.Internal
 		ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
 		ErrorMessage = err.Error()
-		return nil, util.NewError(codes.Internal, codes.Internal.String(), err.Error())
+		return util.NewError(codes.Internal, codes.Internal.String(), err.Error())
 	}
 
 	if data != nil {
 		switch data.IsVerified {
 		case false:
-			ErrorCode = codes.InvalidArgument
+			ErrorCode = codes.AlreadyExists
 			ErrorCodeDetail = pbErr.ErrorCode_AUTH_REGISTER_USER_UNVERIFIED.String()
 			ErrorMessage = "Email sudah terdaftar, mohon ke halaman login."
 		case true:
-			ErrorCode = codes.InvalidArgument
+			ErrorCode = codes.AlreadyExists
 			ErrorCodeDetail = pbErr.ErrorCode_AUTH_REGISTER_USER_VERIFIED.String()
 			ErrorMessage = "Email dan/atau No. Telp sudah terdaftar."
 		}
-		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
-	}
-
-	if err := s.userRepository.Create(ctx, user, req.RoleId); err != nil {
-		ErrorCode = codes.Internal
-		ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
-		ErrorMessage = err.Error()
-		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
-	}
-
-	otp := generateRandom4DigitNumber()
-	otpString := strconv.Itoa(otp)
-	redisPayload := map[string]interface{}{
-		"OTP": otpString,
-	}
-	redisKey := tools.OtpRedisPrefix + req.Email
-	jsonData, err := json.Marshal(redisPayload)
-	if err != nil {
-		fmt.Println("Error marshalling JSON:", err)
-		return nil, util.NewError(codes.Internal, codes.Internal.String(), err.Error())
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
 
-	err = s.redis.Set(ctx, redisKey, jsonData, time.Minute*5)
+	// the user, the otp mail and the otp are stored together so a failure never leaves
+	// a registered user without a code on its way
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Create(ctx, user, req.RoleId); err != nil {
+			return err
+		}
+		if err := s.issueOtp(ctx, user, channel, "register", tools.OtpRedisPrefix+user.Email); err != nil {
+			return err
+		}
+		return s.publishEvent(ctx, user.Id, &events.UserRegistered{
+			UserId:      user.Id.String(),
+			Email:       user.Email,
+			PhoneNumber: user.PhoneNumber,
+			Name:        user.Name,
+			RoleIds:     req.RoleId,
+		})
+	})
 	if err != nil {
-		log.Print("Error Set Value to Redis")
-		return nil, util.NewError(codes.Internal, codes.Internal.String(), err.Error())
-	}
-
-	sendOtpReq := &entity.OtpMailReq{
-		Name:    user.Name,
-		Email:   user.Email,
-		OtpCode: otp,
+		metrics.RegistrationTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
+		switch {
+		case errors.Is(err, repository.ErrDuplicateEmail):
+			ErrorCode = codes.AlreadyExists
+			ErrorCodeDetail = pbErr.ErrorCode_AUTH_REGISTER_USER_VERIFIED.String()
+			ErrorMessage = "Email dan/atau No. Telp sudah terdaftar."
+		case errors.Is(err, repository.ErrDuplicatePhone):
+			ErrorCode = codes.AlreadyExists
+			ErrorCodeDetail = pbErr.ErrorCode_AUTH_REGISTER_PHONE_REGISTERED.String()
+			ErrorMessage = "No. Telp sudah terdaftar."
+		case errors.Is(err, repository.ErrRoleNotFound):
+			ErrorCode = codes.NotFound
+			ErrorCodeDetail = pbErr.ErrorCode_RECORD_NOT_FOUND.String()
+			ErrorMessage = "Role tidak ditemukan"
+		default:
+			ErrorCode = codes.Internal
+			ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
+			ErrorMessage = err.Error()
+		}
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
+	metrics.RegistrationTotal.WithLabelValues(metrics.OutcomeSuccess).Inc()
+	s.audit(ctx, entity.AuditUserRegistered, user.Id, user.Id, map[string]string{"roles": strings.Join(req.RoleId, ",")})
+	s.audit(ctx, entity.AuditOtpSent, user.Id, user.Id, map[string]string{"purpose": "register", "channel": channel})
 
-	return sendOtpReq, nil
+	return nil
 }
 
 func (s *Service) CreateRole(ctx context.Context, role *entity.Role) error {
-	return s.roleRepo.Create(ctx, role)
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return err
+	}
+	s.audit(ctx, entity.AuditRoleCreated, middleware.GetUserIDValue(ctx), uuid.Nil, map[string]string{
+		"role_id":   strconv.Itoa(int(role.ID)),
+		"role_name": role.RoleName,
+	})
+	return nil
 }
 
 func (s *Service) GetRole(ctx context.Context) ([]entity.Role, error) {
-	fmt.Println("get role service", middleware.GetUserIDValue(ctx))
 	roles, err := s.roleRepo.GetRole(ctx)
 	if err != nil {
 		return nil, err
@@ -173,9 +256,15 @@ func (s *Service) VerifyOTP(ctx context.Context, otp int, redisKey string) (user
 	email := strings.Replace(redisKey, tools.OtpRedisPrefix, "", -1)
 	user, err = s.userRepository.GetByEmail(ctx, email)
 	if err != nil {
-		ErrorCode = codes.Internal
-		ErrorCodeDetail = pbErr.ErrorCode_RECORD_NOT_FOUND.String()
-		ErrorMessage = "Verify User Error"
+		if errors.Is(err, repository.ErrUserNotFound) {
+			ErrorCode = codes.NotFound
+			ErrorCodeDetail = pbErr.ErrorCode_RECORD_NOT_FOUND.String()
+			ErrorMessage = "Email belum terdaftar, mohon registrasi"
+		} else {
+			ErrorCode = codes.Internal
+			ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
+			ErrorMessage = "Verify User Error"
+		}
 		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
 	if user.IsVerified {
@@ -185,86 +274,152 @@ func (s *Service) VerifyOTP(ctx context.Context, otp int, redisKey string) (user
 		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
 
-	if err := verifyOtpFromRedis(s, otp, redisKey); err != nil {
+	channel, err := s.consumeOtp(ctx, otp, redisKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserActive(user); err != nil {
 		return nil, err
 	}
 
-	if _, err = s.userRepository.VerifyUserByEmail(ctx, user.Email); err != nil {
+	// a code received by sms or whatsapp proves the phone number as well
+	phoneVerified := channel == entity.OtpChannelSms || channel == entity.OtpChannelWhatsapp
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.userRepository.VerifyUserByEmail(ctx, user.Email); err != nil {
+			return err
+		}
+		if phoneVerified {
+			if err := s.userRepository.VerifyPhoneNumber(ctx, user.Id); err != nil {
+				return err
+			}
+		}
+		return s.publishEvent(ctx, user.Id, &events.UserVerified{
+			UserId:        user.Id.String(),
+			EmailVerified: true,
+			PhoneVerified: phoneVerified,
+		})
+	})
+	if err != nil {
 		ErrorCode = codes.Internal
 		ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
 		ErrorMessage = "Update User Error"
 		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
+	if phoneVerified {
+		user.IsPhoneVerified = true
+	}
+	s.audit(ctx, entity.AuditOtpVerified, user.Id, user.Id, map[string]string{"purpose": "register", "channel": channel})
 
 	return user, nil
 }
 
-func verifyOtpFromRedis(s *Service, otp int, redisKey string) error {
-	storedJSON, err := s.redis.GetStringKey(s.redis.GetContext(), redisKey)
+// verifyOtpFromRedis checks otp against the code stored at redisKey
+// and returns the channel the code was sent through
+func verifyOtpFromRedis(ctx context.Context, s *Service, otp int, redisKey string) (string, error) {
+	storedJSON, err := s.redis.GetStringKey(ctx, redisKey)
 	if err != nil {
+		metrics.OtpVerificationTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
 		ErrorCode = codes.InvalidArgument
 		ErrorCodeDetail = pbErr.ErrorCode_AUTH_OTP_INVALID.String()
 		ErrorMessage = "Kode OTP Tidak Berlaku"
-		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		return "", util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
 
-	fmt.Println("Retrieved JSON string from Redis:", storedJSON)
 	var retrievedObject map[string]interface{}
 
 	if err := json.Unmarshal([]byte(storedJSON), &retrievedObject); err != nil {
+		metrics.OtpVerificationTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
 		ErrorCode = codes.Internal
 		ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
 		ErrorMessage = "Unmarshal Error"
-		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		return "", util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
 	if retrievedObject["OTP"] != strconv.Itoa(otp) {
+		metrics.OtpVerificationTotal.WithLabelValues(metrics.OutcomeFailure).Inc()
 		ErrorCode = codes.InvalidArgument
 		ErrorCodeDetail = pbErr.ErrorCode_AUTH_OTP_INVALID.String()
 		ErrorMessage = "Kode OTP Tidak Berlaku"
-		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		return "", util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
-	return nil
+	metrics.OtpVerificationTotal.WithLabelValues(metrics.OutcomeSuccess).Inc()
+	channel, _ := retrievedObject["Channel"].(string)
+	return channel, nil
 }
 
-func (s *Service) ResendOTP(ctx context.Context, email string) (*entity.OtpMailReq, error) {
-	otp := generateRandom4DigitNumber()
-	otpString := strconv.Itoa(otp)
-	redisPayload := map[string]interface{}{
-		"OTP": otpString,
+func (s *Service) ResendOTP(ctx context.Context, email string, channel string) error {
+	if err := s.checkOtpChannel(channel); err != nil {
+		return err
 	}
-	redisKey := tools.OtpRedisPrefix + email
 
 	user, err := s.userRepository.GetByEmail(ctx, email)
 	if err != nil {
-		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_RECORD_NOT_FOUND.String(), err.Error())
-	}
-	// Marshal the JSON data
-	jsonData, err := json.Marshal(redisPayload)
-	if err != nil {
-		fmt.Println("Error marshalling JSON:", err)
-		return nil, util.NewError(codes.Internal, codes.Internal.String(), err.Error())
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return util.NewError(codes.NotFound, pbErr.ErrorCode_RECORD_NOT_FOUND.String(), "Email belum terdaftar, mohon registrasi")
+		}
+		return util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
 	}
-	err = s.redis.Set(ctx, redisKey, jsonData, time.Minute*5)
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.issueOtp(ctx, user, channel, "resend", tools.OtpRedisPrefix+user.Email)
+	})
 	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("failed to issue otp")
 		ErrorCode = codes.Internal
 		ErrorCodeDetail = pbErr.ErrorCode_UNKNOWN.String()
-		ErrorMessage = "Set Value Redis Error"
-		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+		ErrorMessage = "Gagal mengirim kode OTP"
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
+	s.audit(ctx, entity.AuditOtpSent, uuid.Nil, user.Id, map[string]string{"purpose": "resend", "channel": channel})
 
-	sendOtpReq := &entity.OtpMailReq{
-		Name:    user.Name,
-		Email:   user.Email,
-		OtpCode: otp,
+	return nil
+}
+
+func (s *Service) checkOtpChannel(channel string) error {
+	if _, ok := s.notifiers[channel]; !ok {
+		ErrorCode = codes.InvalidArgument
+		ErrorCodeDetail = pbErr.ErrorCode_AUTH_OTP_CHANNEL_UNAVAILABLE.String()
+		ErrorMessage = "Metode pengiriman OTP tidak tersedia"
+		return util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	}
+	return nil
+}
+
+// issueOtp queues the notification carrying a new otp for user then stores the otp in redis at redisKey.
+// Called within a transaction, the queued notification is dropped when redis fails.
+func (s *Service) issueOtp(ctx context.Context, user *entity.User, channel string, purpose string, redisKey string) error {
+	otp := generateRandom4DigitNumber()
+	message, err := entity.NewOutboxMessage(entity.OutboxTopicOtp, &entity.OtpNotification{
+		Channel:     channel,
+		Name:        user.Name,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		OtpCode:     otp,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.outbox.Create(ctx, message); err != nil {
+		return err
 	}
 
-	return sendOtpReq, nil
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"OTP":     strconv.Itoa(otp),
+		"Channel": channel,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.redis.Set(ctx, redisKey, jsonData, otpExpiration); err != nil {
+		return err
+	}
+	metrics.OtpSentTotal.WithLabelValues(purpose, channel).Inc()
+	return nil
 }
 
 func (s *Service) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*entity.User, error) {
 	user, err := s.userRepository.GetByEmail(ctx, req.Email)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, repository.ErrUserNotFound) {
 			ErrorCode = codes.NotFound
 			ErrorCodeDetail = pbErr.ErrorCode_RECORD_NOT_FOUND.String()
 			ErrorMessage = "Email belum terdaftar, mohon registrasi"
@@ -275,21 +430,29 @@ func (s *Service) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequ
 		}
 		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
 	}
+	// checked before the otp is used up, the user retries with another password
+	if err := s.passwordPolicy.Check(req.Password, user.Email, user.Name); err != nil {
+		return nil, err
+	}
 	redisKey := "otp:" + req.Email
-	if err = verifyOtpFromRedis(s, int(req.OtpCode), redisKey); err != nil {
-		ErrorMessage = "Data yang dimasukkan tidak sesuai"
-		return nil, util.NewError(ErrorCode, ErrorCodeDetail, ErrorMessage)
+	if _, err = s.consumeOtp(ctx, int(req.OtpCode), redisKey); err != nil {
+		return nil, err
+	}
+	if err := checkUserActive(user); err != nil {
+		return nil, err
 	}
-	hashedPassword, err := s.hashing.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := s.hashing.Hash([]byte(req.Password))
 	if err != nil {
 		return nil, util.NewError(codes.Internal, codes.Unknown.String(), err.Error())
 	}
-	user.Password = string(hashedPassword)
+	user.Password = hashedPassword
 	user.WrongPasswordCounter = 0
 	user.IsVerified = true
+	user.UpdatedBy = uuid.NullUUID{UUID: user.Id, Valid: true}
 	if err = s.userRepository.Save(ctx, user); err != nil {
 		return nil, util.NewError(codes.Internal, codes.Unknown.String(), err.Error())
 	}
+	s.audit(ctx, entity.AuditPasswordChanged, user.Id, user.Id, nil)
 	return user, nil
 }
 
@@ -6,13 +6,19 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/Mitra-Apps/be-user-service/config/metrics"
+	"github.com/Mitra-Apps/be-user-service/config/tools"
 	mockTools "github.com/Mitra-Apps/be-user-service/config/tools/mock"
 	mockRedis "github.com/Mitra-Apps/be-user-service/config/tools/redis/mock"
+	events "github.com/Mitra-Apps/be-user-service/domain/proto/events/v1"
 	pb "github.com/Mitra-Apps/be-user-service/domain/proto/user"
 	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
 	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/Mitra-Apps/be-user-service/service/notifier"
 	r "github.com/go-redis/redis"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/mock/gomock"
 )
 
@@ -92,10 +98,15 @@ func TestService_Login(t *testing.T) {
 			m.EXPECT().Save(gomock.Any(), gomock.Any()).Return(err)
 		}
 	}
-	mockHash := mockTools.NewMockBcryptInterface(ctrl)
-	mockCompareHash := func(err error) func(m *mockTools.MockBcryptInterface) {
-		return func(m *mockTools.MockBcryptInterface) {
-			m.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(err)
+	mockHash := mockTools.NewMockPasswordHasher(ctrl)
+	mockCompareHash := func(err error) func(m *mockTools.MockPasswordHasher) {
+		return func(m *mockTools.MockPasswordHasher) {
+			m.EXPECT().Compare(gomock.Any(), gomock.Any()).Return(err)
+		}
+	}
+	mockNeedsRehash := func(needsRehash bool) func(m *mockTools.MockPasswordHasher) {
+		return func(m *mockTools.MockPasswordHasher) {
+			m.EXPECT().NeedsRehash(gomock.Any()).Return(needsRehash)
 		}
 	}
 	userId := uuid.New()
@@ -104,24 +115,35 @@ func TestService_Login(t *testing.T) {
 		Password: "test@123",
 	}
 	unverifiedUser := &entity.User{
+		IsActive:   true,
 		Email:      "test@email.com",
 		Password:   "test@123",
 		IsVerified: false,
 	}
 	verifiedUser := &entity.User{
+		IsActive:             true,
 		Id:                   userId,
 		Email:                "test@email.com",
 		Password:             "test@123",
 		IsVerified:           true,
 		WrongPasswordCounter: 0,
 	}
+	legacyUser := &entity.User{
+		IsActive:   true,
+		Id:         userId,
+		Email:      "test@email.com",
+		Password:   "$2a$10$legacy",
+		IsVerified: true,
+	}
 	verifiedUserWrongPass2x := &entity.User{
+		IsActive:             true,
 		Id:                   userId,
 		Email:                "test@email.com",
 		Password:             "test@123",
 		WrongPasswordCounter: 2,
 	}
 	verifiedUserWrongPass3x := &entity.User{
+		IsActive:             true,
 		Id:                   userId,
 		Email:                "test@email.com",
 		Password:             "test@123",
@@ -141,7 +163,9 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "error record not found",
 			s: &Service{
-				userRepository: mockRepo,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -153,7 +177,9 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "unexpected error",
 			s: &Service{
-				userRepository: mockRepo,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -165,8 +191,10 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "error unverified account",
 			s: &Service{
-				userRepository: mockRepo,
-				hashing:        mockHash,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -178,8 +206,10 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "error password incorrect",
 			s: &Service{
-				userRepository: mockRepo,
-				hashing:        mockHash,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -191,8 +221,10 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "error password incorrect 3x",
 			s: &Service{
-				userRepository: mockRepo,
-				hashing:        mockHash,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -204,8 +236,10 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "error password incorrect 3x after wrong pass login",
 			s: &Service{
-				userRepository: mockRepo,
-				hashing:        mockHash,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -217,8 +251,10 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "error saving wrong password counter",
 			s: &Service{
-				userRepository: mockRepo,
-				hashing:        mockHash,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -230,8 +266,10 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "error saving wrong password counter back to 0",
 			s: &Service{
-				userRepository: mockRepo,
-				hashing:        mockHash,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -243,8 +281,40 @@ func TestService_Login(t *testing.T) {
 		{
 			name: "success",
 			s: &Service{
-				userRepository: mockRepo,
-				hashing:        mockHash,
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
+			},
+			args: args{
+				ctx:     context.Background(),
+				payload: *loginRequest,
+			},
+			want:    verifiedUser,
+			wantErr: false,
+		},
+		{
+			name: "success rehash outdated hash",
+			s: &Service{
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
+			},
+			args: args{
+				ctx:     context.Background(),
+				payload: *loginRequest,
+			},
+			want:    legacyUser,
+			wantErr: false,
+		},
+		{
+			name: "success keeps outdated hash when rehash fails",
+			s: &Service{
+				auditRepo:        newMockAudit(ctrl),
+				loginHistoryRepo: newMockLoginHistory(ctrl),
+				userRepository:   mockRepo,
+				hashing:          mockHash,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -258,7 +328,7 @@ func TestService_Login(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			switch tt.name {
 			case "error record not found":
-				mockLogin(nil, errors.New("record not found"))(mockRepo)
+				mockLogin(nil, repository.ErrUserNotFound)(mockRepo)
 			case "unexpected error":
 				mockLogin(nil, errors.New("other error"))(mockRepo)
 			case "error password incorrect":
@@ -281,11 +351,29 @@ func TestService_Login(t *testing.T) {
 			case "error saving wrong password counter back to 0":
 				mockLogin(verifiedUser, nil)(mockRepo)
 				mockCompareHash(nil)(mockHash)
+				mockNeedsRehash(false)(mockHash)
 				mockSaveUser(errors.New("any error"))(mockRepo)
 			case "success":
 				mockLogin(verifiedUser, nil)(mockRepo)
 				mockCompareHash(nil)(mockHash)
+				mockNeedsRehash(false)(mockHash)
 				mockSaveUser(nil)(mockRepo)
+			case "success rehash outdated hash":
+				mockLogin(legacyUser, nil)(mockRepo)
+				mockCompareHash(nil)(mockHash)
+				mockNeedsRehash(true)(mockHash)
+				mockHash.EXPECT().Hash([]byte(loginRequest.Password)).Return("$argon2id$rehashed", nil)
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Cond(func(x any) bool {
+					return x.(*entity.User).Password == "$argon2id$rehashed"
+				})).Return(nil)
+			case "success keeps outdated hash when rehash fails":
+				mockLogin(verifiedUser, nil)(mockRepo)
+				mockCompareHash(nil)(mockHash)
+				mockNeedsRehash(true)(mockHash)
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", errors.New("any error"))
+				mockRepo.EXPECT().Save(gomock.Any(), gomock.Cond(func(x any) bool {
+					return x.(*entity.User).Password == "test@123"
+				})).Return(nil)
 			}
 			got, err := tt.s.Login(tt.args.ctx, tt.args.payload)
 			if (err != nil) != tt.wantErr {
@@ -302,8 +390,17 @@ func TestService_Login(t *testing.T) {
 func TestService_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRepo := mock.NewMockUser(ctrl)
-	mockHash := mockTools.NewMockBcryptInterface(ctrl)
+	mockHash := mockTools.NewMockPasswordHasher(ctrl)
 	redis := mockRedis.NewMockRedisInterface(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	notifiers := Notifiers{entity.OtpChannelEmail: notifier.NewFake()}
+	inTransaction := func() *gomock.Call {
+		return mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
 
 	req := &pb.UserRegisterRequest{
 		Email:       "mail@mail.com",
@@ -335,10 +432,40 @@ func TestService_Register(t *testing.T) {
 		wantErr bool
 		mocks   []*gomock.Call
 	}{
+		{
+			name: "error unavailable channel",
+			s: &Service{
+				auditRepo: newMockAudit(ctrl),
+				notifiers: notifiers,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &pb.UserRegisterRequest{
+					Email:      "mail@mail.com",
+					OtpChannel: pb.OtpChannel_OTP_CHANNEL_SMS,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error password policy",
+			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
+				passwordPolicy: DefaultPasswordPolicy(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: req,
+			},
+			wantErr: true,
+		},
 		{
 			name: "error hashing password",
 			s: &Service{
-				hashing: mockHash,
+				auditRepo: newMockAudit(ctrl),
+				notifiers: notifiers,
+				hashing:   mockHash,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -346,12 +473,14 @@ func TestService_Register(t *testing.T) {
 			},
 			wantErr: true,
 			mocks: []*gomock.Call{
-				mockHash.EXPECT().GenerateFromPassword(gomock.Any(), gomock.Any()).Return(nil, errors.New("error")),
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", errors.New("error")),
 			},
 		},
 		{
 			name: "internal error",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				hashing:        mockHash,
 				userRepository: mockRepo,
 			},
@@ -361,13 +490,15 @@ func TestService_Register(t *testing.T) {
 			},
 			wantErr: true,
 			mocks: []*gomock.Call{
-				mockHash.EXPECT().GenerateFromPassword(gomock.Any(), gomock.Any()).Return([]byte{}, nil),
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
 				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, errors.New("other error")),
 			},
 		},
 		{
 			name: "data exist with inactive status",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				userRepository: mockRepo,
 				hashing:        mockHash,
 			},
@@ -377,13 +508,15 @@ func TestService_Register(t *testing.T) {
 			},
 			wantErr: true,
 			mocks: []*gomock.Call{
-				mockHash.EXPECT().GenerateFromPassword(gomock.Any(), gomock.Any()).Return([]byte{}, nil),
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
 				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(dataInactive, nil),
 			},
 		},
 		{
 			name: "data exist with active status",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				userRepository: mockRepo,
 				hashing:        mockHash,
 			},
@@ -393,15 +526,18 @@ func TestService_Register(t *testing.T) {
 			},
 			wantErr: true,
 			mocks: []*gomock.Call{
-				mockHash.EXPECT().GenerateFromPassword(gomock.Any(), gomock.Any()).Return([]byte{}, nil),
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
 				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(dataActive, nil),
 			},
 		},
 		{
 			name: "error register from create in repository layer",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				userRepository: mockRepo,
 				hashing:        mockHash,
+				transactor:     mockTransactor,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -409,17 +545,87 @@ func TestService_Register(t *testing.T) {
 			},
 			wantErr: true,
 			mocks: []*gomock.Call{
-				mockHash.EXPECT().GenerateFromPassword(gomock.Any(), gomock.Any()).Return([]byte{}, nil),
-				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, errors.New("record not found")),
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, repository.ErrUserNotFound),
+				inTransaction(),
 				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error")),
 			},
 		},
+		{
+			name: "error register caused by registered phone number",
+			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
+				userRepository: mockRepo,
+				hashing:        mockHash,
+				transactor:     mockTransactor,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: req,
+			},
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, repository.ErrUserNotFound),
+				inTransaction(),
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.ErrDuplicatePhone),
+			},
+		},
+		{
+			name: "error register caused by unknown role",
+			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
+				userRepository: mockRepo,
+				hashing:        mockHash,
+				transactor:     mockTransactor,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: req,
+			},
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, repository.ErrUserNotFound),
+				inTransaction(),
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.ErrRoleNotFound),
+			},
+		},
+		{
+			name: "error queue otp mail",
+			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
+				userRepository: mockRepo,
+				hashing:        mockHash,
+				outbox:         mockOutbox,
+				transactor:     mockTransactor,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: req,
+			},
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, repository.ErrUserNotFound),
+				inTransaction(),
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("any error")),
+			},
+		},
 		{
 			name: "success register user error in redis",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				userRepository: mockRepo,
 				hashing:        mockHash,
 				redis:          redis,
+				outbox:         mockOutbox,
+				transactor:     mockTransactor,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -427,18 +633,24 @@ func TestService_Register(t *testing.T) {
 			},
 			wantErr: true,
 			mocks: []*gomock.Call{
-				mockHash.EXPECT().GenerateFromPassword(gomock.Any(), gomock.Any()).Return([]byte{}, nil),
-				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, errors.New("record not found")),
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, repository.ErrUserNotFound),
+				inTransaction(),
 				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
 				redis.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("any error")),
 			},
 		},
 		{
 			name: "success",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				userRepository: mockRepo,
 				hashing:        mockHash,
 				redis:          redis,
+				outbox:         mockOutbox,
+				transactor:     mockTransactor,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -446,16 +658,25 @@ func TestService_Register(t *testing.T) {
 			},
 			wantErr: false,
 			mocks: []*gomock.Call{
-				mockHash.EXPECT().GenerateFromPassword(gomock.Any(), gomock.Any()).Return([]byte{}, nil),
-				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, errors.New("record not found")),
+				mockHash.EXPECT().Hash(gomock.Any()).Return("", nil),
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(nil, repository.ErrUserNotFound),
+				inTransaction(),
 				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
 				redis.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, message *entity.OutboxMessage) error {
+						if message.Topic != entity.OutboxTopicEvent {
+							t.Errorf("Service.Register() queued %v, want UserRegistered event", message.Topic)
+						}
+						return nil
+					}),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := tt.s.Register(tt.args.ctx, tt.args.req)
+			err := tt.s.Register(tt.args.ctx, tt.args.req)
 			if err != nil != tt.wantErr {
 				t.Errorf("Service.Register() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -484,7 +705,8 @@ func TestService_CreateRole(t *testing.T) {
 		{
 			name: "create role unit test",
 			s: &Service{
-				roleRepo: mockRepo,
+				auditRepo: newMockAudit(ctrl),
+				roleRepo:  mockRepo,
 			},
 			args: args{
 				ctx:  context.Background(),
@@ -592,6 +814,14 @@ func TestService_VerifyOTP(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockUser := mock.NewMockUser(ctrl)
 	redis := mockRedis.NewMockRedisInterface(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	inTransaction := func() {
+		mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
 	mockGetUser := func(user *entity.User, err error) func(m *mock.MockUser) {
 		return func(m *mock.MockUser) {
 			m.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(user, err)
@@ -604,21 +834,22 @@ func TestService_VerifyOTP(t *testing.T) {
 	}
 	mockGetStringKey := func(value string, err error) func(m *mockRedis.MockRedisInterface) {
 		return func(m *mockRedis.MockRedisInterface) {
+			m.EXPECT().Incr(gomock.Any(), "otp:test@mail.com"+tools.OtpAttemptsRedisSuffix, otpLockout).Return(int64(1), nil)
 			m.EXPECT().GetStringKey(gomock.Any(), gomock.Any()).Return(value, err)
 		}
 	}
-	mockGetContext := func(ctx context.Context) func(m *mockRedis.MockRedisInterface) {
-		return func(m *mockRedis.MockRedisInterface) {
-			m.EXPECT().GetContext().Return(ctx)
-		}
+	mockConsumed := func(m *mockRedis.MockRedisInterface) {
+		m.EXPECT().Del(gomock.Any(), "otp:test@mail.com", "otp:test@mail.com"+tools.OtpAttemptsRedisSuffix).Return(nil)
 	}
 	id := uuid.New()
 	verifiedUser := &entity.User{
+		IsActive:   true,
 		Id:         id,
 		Email:      "test@mail.com",
 		IsVerified: true,
 	}
 	unverifiedUser := &entity.User{
+		IsActive:   true,
 		Id:         id,
 		Email:      "test@mail.com",
 		IsVerified: false,
@@ -643,6 +874,7 @@ func TestService_VerifyOTP(t *testing.T) {
 		{
 			name: "error verify otp caused by verified user",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 			},
@@ -657,6 +889,7 @@ func TestService_VerifyOTP(t *testing.T) {
 		{
 			name: "error verify otp caused by no record",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 			},
@@ -671,6 +904,7 @@ func TestService_VerifyOTP(t *testing.T) {
 		{
 			name: "error verify otp caused by redis nil",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 			},
@@ -685,6 +919,7 @@ func TestService_VerifyOTP(t *testing.T) {
 		{
 			name: "error verify otp caused by other redis error",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 			},
@@ -699,6 +934,7 @@ func TestService_VerifyOTP(t *testing.T) {
 		{
 			name: "error verify otp caused by unmarshal stored json",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 			},
@@ -713,6 +949,7 @@ func TestService_VerifyOTP(t *testing.T) {
 		{
 			name: "error verify otp caused by incorrect input otp",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 			},
@@ -724,11 +961,29 @@ func TestService_VerifyOTP(t *testing.T) {
 			wantUser: nil,
 			wantErr:  true,
 		},
+		{
+			name: "error verify otp caused by too many attempts",
+			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				userRepository: mockUser,
+				redis:          redis,
+			},
+			args: args{
+				ctx:      context.Background(),
+				otp:      1234,
+				redisKey: redisKey,
+			},
+			wantUser: nil,
+			wantErr:  true,
+		},
 		{
 			name: "error verify otp caused by error saving user",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
+				outbox:         mockOutbox,
+				transactor:     mockTransactor,
 			},
 			args: args{
 				ctx:      context.Background(),
@@ -741,8 +996,11 @@ func TestService_VerifyOTP(t *testing.T) {
 		{
 			name: "success",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
+				outbox:         mockOutbox,
+				transactor:     mockTransactor,
 			},
 			args: args{
 				ctx:      context.Background(),
@@ -762,30 +1020,33 @@ func TestService_VerifyOTP(t *testing.T) {
 				mockGetUser(nil, errors.New("any error"))(mockUser)
 			case "error verify otp caused by redis nil":
 				mockGetUser(unverifiedUser, nil)(mockUser)
-				mockGetContext(context.Background())(redis)
 				mockGetStringKey("", r.Nil)(redis)
 			case "error verify otp caused by other redis error":
 				mockGetUser(unverifiedUser, nil)(mockUser)
-				mockGetContext(context.Background())(redis)
 				mockGetStringKey("", errors.New("other error"))(redis)
 			case "error verify otp caused by unmarshal stored json":
 				mockGetUser(unverifiedUser, nil)(mockUser)
-				mockGetContext(context.Background())(redis)
 				mockGetStringKey(failedStoredJSON, nil)(redis)
 			case "error verify otp caused by incorrect input otp":
 				mockGetUser(unverifiedUser, nil)(mockUser)
-				mockGetContext(context.Background())(redis)
 				mockGetStringKey(succcessStoredJSON, nil)(redis)
+			case "error verify otp caused by too many attempts":
+				mockGetUser(unverifiedUser, nil)(mockUser)
+				redis.EXPECT().Incr(gomock.Any(), redisKey+tools.OtpAttemptsRedisSuffix, otpLockout).Return(int64(maxOtpAttempts+1), nil)
+				redis.EXPECT().Del(gomock.Any(), redisKey).Return(nil)
 			case "error verify otp caused by error saving user":
 				mockGetUser(unverifiedUser, nil)(mockUser)
-				mockGetContext(context.Background())(redis)
 				mockGetStringKey(succcessStoredJSON, nil)(redis)
+				mockConsumed(redis)
+				inTransaction()
 				mockUpdateUser(false, errors.New("any error"))(mockUser)
 			case "success":
 				mockGetUser(unverifiedUser, nil)(mockUser)
-				mockGetContext(context.Background())(redis)
 				mockGetStringKey(succcessStoredJSON, nil)(redis)
+				mockConsumed(redis)
+				inTransaction()
 				mockUpdateUser(true, nil)(mockUser)
+				expectEvent(t, mockOutbox, id, &events.UserVerified{UserId: id.String(), EmailVerified: true})
 			}
 			gotUser, err := tt.s.VerifyOTP(tt.args.ctx, tt.args.otp, tt.args.redisKey)
 			if (err != nil) != tt.wantErr {
@@ -815,18 +1076,17 @@ func TestService_ChangePassword(t *testing.T) {
 	redis := mockRedis.NewMockRedisInterface(ctrl)
 	mockGetStringKey := func(value string, err error) func(m *mockRedis.MockRedisInterface) {
 		return func(m *mockRedis.MockRedisInterface) {
+			m.EXPECT().Incr(gomock.Any(), "otp:test@mail.com"+tools.OtpAttemptsRedisSuffix, otpLockout).Return(int64(1), nil)
 			m.EXPECT().GetStringKey(gomock.Any(), gomock.Any()).Return(value, err)
 		}
 	}
-	mockGetContext := func(ctx context.Context) func(m *mockRedis.MockRedisInterface) {
-		return func(m *mockRedis.MockRedisInterface) {
-			m.EXPECT().GetContext().Return(ctx)
-		}
+	mockConsumed := func(m *mockRedis.MockRedisInterface) {
+		m.EXPECT().Del(gomock.Any(), "otp:test@mail.com", "otp:test@mail.com"+tools.OtpAttemptsRedisSuffix).Return(nil)
 	}
-	mockHash := mockTools.NewMockBcryptInterface(ctrl)
-	mockHashing := func(hashedPassword []byte, err error) func(m *mockTools.MockBcryptInterface) {
-		return func(m *mockTools.MockBcryptInterface) {
-			m.EXPECT().GenerateFromPassword(gomock.Any(), gomock.Any()).Return(hashedPassword, err)
+	mockHash := mockTools.NewMockPasswordHasher(ctrl)
+	mockHashing := func(hashedPassword string, err error) func(m *mockTools.MockPasswordHasher) {
+		return func(m *mockTools.MockPasswordHasher) {
+			m.EXPECT().Hash(gomock.Any()).Return(hashedPassword, err)
 		}
 	}
 
@@ -837,6 +1097,7 @@ func TestService_ChangePassword(t *testing.T) {
 	}
 	succcessStoredJSON := `{"OTP":"1234"}`
 	user := &entity.User{
+		IsActive: true,
 		Email:    "test@mail.com",
 		Password: string([]byte{'a'}),
 	}
@@ -854,6 +1115,7 @@ func TestService_ChangePassword(t *testing.T) {
 		{
 			name: "error unregistered email",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 			},
 			args: args{
@@ -866,6 +1128,7 @@ func TestService_ChangePassword(t *testing.T) {
 		{
 			name: "error repository issue",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 			},
 			args: args{
@@ -875,9 +1138,38 @@ func TestService_ChangePassword(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "error password policy",
+			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				userRepository: mockUser,
+				passwordPolicy: DefaultPasswordPolicy(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "error verify otp",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				userRepository: mockUser,
+				redis:          redis,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error too many otp attempts",
+			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 			},
@@ -891,6 +1183,7 @@ func TestService_ChangePassword(t *testing.T) {
 		{
 			name: "error hashing password",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 				hashing:        mockHash,
@@ -905,6 +1198,7 @@ func TestService_ChangePassword(t *testing.T) {
 		{
 			name: "error update user data",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 				hashing:        mockHash,
@@ -919,6 +1213,7 @@ func TestService_ChangePassword(t *testing.T) {
 		{
 			name: "success",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
 				userRepository: mockUser,
 				redis:          redis,
 				hashing:        mockHash,
@@ -934,29 +1229,34 @@ func TestService_ChangePassword(t *testing.T) {
 	for _, tt := range tests {
 		switch tt.name {
 		case "error unregistered email":
-			mockGetUser(nil, errors.New("record not found"))(mockUser)
+			mockGetUser(nil, repository.ErrUserNotFound)(mockUser)
 		case "error repository issue":
 			mockGetUser(nil, errors.New("any error"))(mockUser)
+		case "error password policy":
+			mockGetUser(user, nil)(mockUser)
 		case "error verify otp":
 			mockGetUser(user, nil)(mockUser)
-			mockGetContext(context.Background())(redis)
 			mockGetStringKey("", errors.New("any error"))(redis)
+		case "error too many otp attempts":
+			mockGetUser(user, nil)(mockUser)
+			redis.EXPECT().Incr(gomock.Any(), "otp:test@mail.com"+tools.OtpAttemptsRedisSuffix, otpLockout).Return(int64(maxOtpAttempts+1), nil)
+			redis.EXPECT().Del(gomock.Any(), "otp:test@mail.com").Return(nil)
 		case "error hashing password":
 			mockGetUser(user, nil)(mockUser)
-			mockGetContext(context.Background())(redis)
 			mockGetStringKey(succcessStoredJSON, nil)(redis)
-			mockHashing(nil, errors.New("any error"))(mockHash)
+			mockConsumed(redis)
+			mockHashing("", errors.New("any error"))(mockHash)
 		case "error update user data":
 			mockGetUser(user, nil)(mockUser)
-			mockGetContext(context.Background())(redis)
 			mockGetStringKey(succcessStoredJSON, nil)(redis)
-			mockHashing([]byte{'a'}, nil)(mockHash)
+			mockConsumed(redis)
+			mockHashing("a", nil)(mockHash)
 			mockSaveUser(errors.New("any error"))(mockUser)
 		case "success":
 			mockGetUser(user, nil)(mockUser)
-			mockGetContext(context.Background())(redis)
 			mockGetStringKey(succcessStoredJSON, nil)(redis)
-			mockHashing([]byte{'a'}, nil)(mockHash)
+			mockConsumed(redis)
+			mockHashing("a", nil)(mockHash)
 			mockSaveUser(nil)(mockUser)
 		}
 		t.Run(tt.name, func(t *testing.T) {
@@ -972,23 +1272,50 @@ func TestService_ChangePassword(t *testing.T) {
 }
 
 func Test_verifyOtpFromRedis(t *testing.T) {
-	type args struct {
-		s        *Service
-		otp      int
-		redisKey string
-	}
+	ctrl := gomock.NewController(t)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	s := &Service{redis: redis}
 	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
+		name        string
+		stored      string
+		storedErr   error
+		wantErr     bool
+		wantFailure bool
 	}{
-		// TODO: Add test cases.
+		{
+			name:        "error otp expired",
+			storedErr:   r.Nil,
+			wantErr:     true,
+			wantFailure: true,
+		},
+		{
+			name:        "error unreadable otp",
+			stored:      "1234",
+			wantErr:     true,
+			wantFailure: true,
+		},
+		{
+			name:        "error wrong otp",
+			stored:      `{"OTP":"4321"}`,
+			wantErr:     true,
+			wantFailure: true,
+		},
+		{
+			name:   "success",
+			stored: `{"OTP":"1234","Channel":"email"}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := verifyOtpFromRedis(tt.args.s, tt.args.otp, tt.args.redisKey); (err != nil) != tt.wantErr {
+			redis.EXPECT().GetStringKey(gomock.Any(), "otp:mail@mail.com").Return(tt.stored, tt.storedErr)
+			failures := testutil.ToFloat64(metrics.OtpVerificationTotal.WithLabelValues(metrics.OutcomeFailure))
+			if _, err := verifyOtpFromRedis(context.Background(), s, 1234, "otp:mail@mail.com"); (err != nil) != tt.wantErr {
 				t.Errorf("verifyOtpFromRedis() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			counted := testutil.ToFloat64(metrics.OtpVerificationTotal.WithLabelValues(metrics.OutcomeFailure)) - failures
+			if (counted == 1) != tt.wantFailure {
+				t.Errorf("verifyOtpFromRedis() counted %v failures, want failure %v", counted, tt.wantFailure)
+			}
 		})
 	}
 }
@@ -999,90 +1326,134 @@ func TestService_ResendOTP(t *testing.T) {
 	mockUserRecord := mockUser.EXPECT()
 	redis := mockRedis.NewMockRedisInterface(ctrl)
 	redisRecord := redis.EXPECT()
+	mockOutbox := mock.NewMockOutbox(ctrl)
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	notifiers := Notifiers{entity.OtpChannelEmail: notifier.NewFake()}
+	inTransaction := func() *gomock.Call {
+		return mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
 	email := "test@mail.com"
 	user := &entity.User{
 		Name:  "test",
 		Email: email,
 	}
 	type args struct {
-		ctx   context.Context
-		email string
+		ctx     context.Context
+		email   string
+		channel string
 	}
 	tests := []struct {
 		name    string
 		s       *Service
 		args    args
-		want    *entity.OtpMailReq
 		wantErr bool
 		mocks   []*gomock.Call
 	}{
+		{
+			name: "error unavailable channel",
+			s: &Service{
+				auditRepo: newMockAudit(ctrl),
+				notifiers: notifiers,
+			},
+			args: args{
+				ctx:     context.Background(),
+				email:   email,
+				channel: entity.OtpChannelWhatsapp,
+			},
+			wantErr: true,
+		},
 		{
 			name: "error get by email repo",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				userRepository: mockUser,
 			},
 			args: args{
-				ctx:   context.Background(),
-				email: email,
+				ctx:     context.Background(),
+				email:   email,
+				channel: entity.OtpChannelEmail,
 			},
-			want:    nil,
 			wantErr: true,
 			mocks: []*gomock.Call{
 				mockUserRecord.GetByEmail(gomock.Any(), gomock.Any()).Return(nil, errors.New("any error")),
 			},
 		},
+		{
+			name: "error queue otp mail",
+			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
+				userRepository: mockUser,
+				outbox:         mockOutbox,
+				transactor:     mockTransactor,
+			},
+			args: args{
+				ctx:     context.Background(),
+				email:   email,
+				channel: entity.OtpChannelEmail,
+			},
+			wantErr: true,
+			mocks: []*gomock.Call{
+				mockUserRecord.GetByEmail(gomock.Any(), gomock.Any()).Return(user, nil),
+				inTransaction(),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("any error")),
+			},
+		},
 		{
 			name: "error set key value in redis",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				userRepository: mockUser,
 				redis:          redis,
+				outbox:         mockOutbox,
+				transactor:     mockTransactor,
 			},
 			args: args{
-				ctx:   context.Background(),
-				email: email,
+				ctx:     context.Background(),
+				email:   email,
+				channel: entity.OtpChannelEmail,
 			},
-			want:    nil,
 			wantErr: true,
 			mocks: []*gomock.Call{
 				mockUserRecord.GetByEmail(gomock.Any(), gomock.Any()).Return(user, nil),
+				inTransaction(),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
 				redisRecord.Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("any errror")),
 			},
 		},
 		{
 			name: "success",
 			s: &Service{
+				auditRepo:      newMockAudit(ctrl),
+				notifiers:      notifiers,
 				userRepository: mockUser,
 				redis:          redis,
+				outbox:         mockOutbox,
+				transactor:     mockTransactor,
 			},
 			args: args{
-				ctx:   context.Background(),
-				email: email,
-			},
-			want: &entity.OtpMailReq{
-				Name:  user.Name,
-				Email: user.Email,
+				ctx:     context.Background(),
+				email:   email,
+				channel: entity.OtpChannelEmail,
 			},
 			wantErr: false,
 			mocks: []*gomock.Call{
 				mockUserRecord.GetByEmail(gomock.Any(), gomock.Any()).Return(user, nil),
+				inTransaction(),
+				mockOutbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
 				redisRecord.Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.s.ResendOTP(tt.args.ctx, tt.args.email)
-			if (err != nil) != tt.wantErr {
+			if err := tt.s.ResendOTP(tt.args.ctx, tt.args.email, tt.args.channel); (err != nil) != tt.wantErr {
 				t.Errorf("Service.ResendOTP() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if got != nil {
-				if !reflect.DeepEqual(got.Name, tt.want.Name) {
-					t.Errorf("Service.ResendOTP() = %v, want %v", got, tt.want)
-				}
-				if !reflect.DeepEqual(got.Email, tt.want.Email) {
-					t.Errorf("Service.ResendOTP() = %v, want %v", got, tt.want)
-				}
 			}
 		})
 	}
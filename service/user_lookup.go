@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Mitra-Apps/be-user-service/config/logger"
+	"github.com/Mitra-Apps/be-user-service/config/tools"
+	"github.com/Mitra-Apps/be-user-service/config/tools/redis"
+	pbErr "github.com/Mitra-Apps/be-user-service/domain/proto"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	util "github.com/Mitra-Apps/be-utility-service/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// how long a user summary stays cached, bounding how stale a summary can be
+// should an invalidation fail
+const userSummaryCacheTTL = 10 * time.Minute
+
+// GetUsersByIds returns the summaries of the users among ids in their order, unknown ids are skipped.
+// Summaries are read from the cache first, the missing ones are loaded in a single query and cached.
+func (s *Service) GetUsersByIds(ctx context.Context, ids []uuid.UUID) ([]*entity.UserSummary, error) {
+	ids = uniqueIds(ids)
+	found := s.cachedUserSummaries(ctx, ids)
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		loaded, err := s.userRepository.GetSummariesByIDs(ctx, missing)
+		if err != nil {
+			return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+		}
+		for _, summary := range loaded {
+			found[summary.Id] = summary
+			s.cacheUserSummary(ctx, summary)
+		}
+	}
+
+	summaries := make([]*entity.UserSummary, 0, len(found))
+	for _, id := range ids {
+		if summary, ok := found[id]; ok {
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// GetUserByEmail returns the summary of the user registered with email. The cache maps emails
+// to user ids, a cached summary is only used while it still holds the email looked up.
+func (s *Service) GetUserByEmail(ctx context.Context, email string) (*entity.UserSummary, error) {
+	if cachedId, err := s.redis.GetStringKey(ctx, tools.UserEmailRedisPrefix+email); err == nil {
+		if id, err := uuid.Parse(cachedId); err == nil {
+			if summary, ok := s.cachedUserSummaries(ctx, []uuid.UUID{id})[id]; ok && summary.Email == email {
+				return summary, nil
+			}
+		}
+	}
+
+	user, err := s.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, util.NewError(codes.NotFound, pbErr.ErrorCode_RECORD_NOT_FOUND.String(), "User tidak ditemukan")
+		}
+		return nil, util.NewError(codes.Internal, pbErr.ErrorCode_UNKNOWN.String(), err.Error())
+	}
+	summary := user.Summary()
+	s.cacheUserSummary(ctx, summary)
+	return summary, nil
+}
+
+// cachedUserSummaries returns the cached summaries among ids, a failing cache is only logged
+// and reported as empty
+func (s *Service) cachedUserSummaries(ctx context.Context, ids []uuid.UUID) map[uuid.UUID]*entity.UserSummary {
+	found := make(map[uuid.UUID]*entity.UserSummary, len(ids))
+	if len(ids) == 0 {
+		return found
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = tools.UserSummaryRedisPrefix + id.String()
+	}
+	values, err := s.redis.MGet(ctx, keys...)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Warn("failed to read cached user summaries")
+		return found
+	}
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		var summary entity.UserSummary
+		if err := json.Unmarshal([]byte(value), &summary); err != nil {
+			continue
+		}
+		found[ids[i]] = &summary
+	}
+	return found
+}
+
+// cacheUserSummary caches summary by id along with the id of its email
+func (s *Service) cacheUserSummary(ctx context.Context, summary *entity.UserSummary) {
+	// a summary always encodes
+	value, _ := json.Marshal(summary)
+	log := logger.FromContext(ctx).WithField("user_id", summary.Id.String())
+	if err := s.redis.Set(ctx, tools.UserSummaryRedisPrefix+summary.Id.String(), value, userSummaryCacheTTL); err != nil {
+		log.WithError(err).Warn("failed to cache user summary")
+		return
+	}
+	if summary.Email == "" {
+		return
+	}
+	if err := s.redis.Set(ctx, tools.UserEmailRedisPrefix+summary.Email, summary.Id.String(), userSummaryCacheTTL); err != nil {
+		log.WithError(err).Warn("failed to cache user email")
+	}
+}
+
+// dropCachedUsers invalidates the cached summaries of ids once their name, email or status changed.
+// Email entries are left to expire, they are checked against the summary they point to.
+func dropCachedUsers(ctx context.Context, r redis.RedisInterface, ids ...uuid.UUID) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = tools.UserSummaryRedisPrefix + id.String()
+	}
+	if err := r.Del(ctx, keys...); err != nil {
+		logger.FromContext(ctx).WithError(err).Warn("failed to invalidate cached user summaries")
+	}
+}
+
+// uniqueIds drops the duplicates of ids, keeping the first occurrences in order
+func uniqueIds(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
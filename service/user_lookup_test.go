@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	mockRedis "github.com/Mitra-Apps/be-user-service/config/tools/redis/mock"
+	"github.com/Mitra-Apps/be-user-service/domain/user/entity"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository"
+	"github.com/Mitra-Apps/be-user-service/domain/user/repository/mock"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_GetUsersByIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	s := &Service{userRepository: mockUser, redis: redis}
+	cached := &entity.UserSummary{Id: uuid.New(), Name: "cached", Email: "cached@mail.com", IsActive: true}
+	stored := &entity.UserSummary{Id: uuid.New(), Name: "stored", Email: "stored@mail.com", IsActive: true}
+	unknownId := uuid.New()
+	cachedJSON, _ := json.Marshal(cached)
+	keys := []interface{}{"user-summary:" + cached.Id.String(), "user-summary:" + stored.Id.String(), "user-summary:" + unknownId.String()}
+	tests := []struct {
+		name    string
+		want    []*entity.UserSummary
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error loading missing users",
+			wantErr: true,
+			mocks: func() {
+				redis.EXPECT().MGet(gomock.Any(), keys...).Return([]string{string(cachedJSON), "", ""}, nil)
+				mockUser.EXPECT().GetSummariesByIDs(gomock.Any(), []uuid.UUID{stored.Id, unknownId}).Return(nil, errors.New("any error"))
+			},
+		},
+		{
+			name: "cached and loaded users in order",
+			want: []*entity.UserSummary{cached, stored},
+			mocks: func() {
+				redis.EXPECT().MGet(gomock.Any(), keys...).Return([]string{string(cachedJSON), "", ""}, nil)
+				mockUser.EXPECT().GetSummariesByIDs(gomock.Any(), []uuid.UUID{stored.Id, unknownId}).Return([]*entity.UserSummary{stored}, nil)
+				redis.EXPECT().Set(gomock.Any(), "user-summary:"+stored.Id.String(), gomock.Any(), userSummaryCacheTTL).Return(nil)
+				redis.EXPECT().Set(gomock.Any(), "user-email:stored@mail.com", stored.Id.String(), userSummaryCacheTTL).Return(nil)
+			},
+		},
+		{
+			name: "failing cache falls back to the database",
+			want: []*entity.UserSummary{cached, stored},
+			mocks: func() {
+				redis.EXPECT().MGet(gomock.Any(), keys...).Return(nil, errors.New("unavailable"))
+				mockUser.EXPECT().GetSummariesByIDs(gomock.Any(), []uuid.UUID{cached.Id, stored.Id, unknownId}).Return([]*entity.UserSummary{stored, cached}, nil)
+				redis.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), userSummaryCacheTTL).Return(errors.New("unavailable")).Times(2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			// duplicates are looked up once
+			got, err := s.GetUsersByIds(context.Background(), []uuid.UUID{cached.Id, stored.Id, cached.Id, unknownId})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.GetUsersByIds() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.GetUsersByIds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetUserByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUser := mock.NewMockUser(ctrl)
+	redis := mockRedis.NewMockRedisInterface(ctrl)
+	s := &Service{userRepository: mockUser, redis: redis}
+	user := &entity.User{Id: uuid.New(), Name: "test", Email: "test@mail.com", IsActive: true}
+	summaryKey := "user-summary:" + user.Id.String()
+	emailKey := "user-email:test@mail.com"
+	cachedJSON, _ := json.Marshal(user.Summary())
+	// the email of the user changed since it was cached
+	staleJSON, _ := json.Marshal(&entity.UserSummary{Id: user.Id, Email: "other@mail.com"})
+	cacheUser := func() {
+		redis.EXPECT().Set(gomock.Any(), summaryKey, gomock.Any(), userSummaryCacheTTL).Return(nil)
+		redis.EXPECT().Set(gomock.Any(), emailKey, user.Id.String(), userSummaryCacheTTL).Return(nil)
+	}
+	tests := []struct {
+		name    string
+		wantErr bool
+		mocks   func()
+	}{
+		{
+			name:    "error user not found",
+			wantErr: true,
+			mocks: func() {
+				redis.EXPECT().GetStringKey(gomock.Any(), emailKey).Return("", errors.New("redis: nil"))
+				mockUser.EXPECT().GetByEmail(gomock.Any(), "test@mail.com").Return(nil, repository.ErrUserNotFound)
+			},
+		},
+		{
+			name: "cached",
+			mocks: func() {
+				redis.EXPECT().GetStringKey(gomock.Any(), emailKey).Return(user.Id.String(), nil)
+				redis.EXPECT().MGet(gomock.Any(), summaryKey).Return([]string{string(cachedJSON)}, nil)
+			},
+		},
+		{
+			name: "stale cache",
+			mocks: func() {
+				redis.EXPECT().GetStringKey(gomock.Any(), emailKey).Return(user.Id.String(), nil)
+				redis.EXPECT().MGet(gomock.Any(), summaryKey).Return([]string{string(staleJSON)}, nil)
+				mockUser.EXPECT().GetByEmail(gomock.Any(), "test@mail.com").Return(user, nil)
+				cacheUser()
+			},
+		},
+		{
+			name: "not cached",
+			mocks: func() {
+				redis.EXPECT().GetStringKey(gomock.Any(), emailKey).Return("", errors.New("redis: nil"))
+				mockUser.EXPECT().GetByEmail(gomock.Any(), "test@mail.com").Return(user, nil)
+				cacheUser()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			got, err := s.GetUserByEmail(context.Background(), "test@mail.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.GetUserByEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, user.Summary()) {
+				t.Errorf("Service.GetUserByEmail() = %v, want %v", got, user.Summary())
+			}
+		})
+	}
+}